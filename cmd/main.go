@@ -10,9 +10,12 @@ import (
 
 	"github.com/identicalaffiliation/app/internal/config"
 	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/notify"
 	"github.com/identicalaffiliation/app/internal/repository/psql"
 	"github.com/identicalaffiliation/app/internal/service"
 	"github.com/identicalaffiliation/app/internal/transport/rest"
+	"github.com/identicalaffiliation/app/internal/workflow"
+	"github.com/identicalaffiliation/app/pkg/mailer"
 	"github.com/identicalaffiliation/app/pkg/parse"
 )
 
@@ -28,18 +31,118 @@ func main() {
 
 	db.MustInit(cfg)
 
+	if args := parse.Args(); len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(db, logger, args[1:]); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	if cfg.Database.AutoMigrate {
+		migrator, err := psql.NewMigrator(db, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := migrator.Up(context.Background()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	wf, err := workflow.New(&cfg.Workflow)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	terminal := make([]psql.TodoStatus, 0)
+	for _, status := range wf.TerminalStates() {
+		terminal = append(terminal, psql.TodoStatus(status))
+	}
+
 	userRepo := psql.NewUserRepository(db, logger)
-	todoRepo := psql.NewTodoRepository(db, logger)
-	userSerivce := service.NewUserService(userRepo)
-	todoService := service.NewTodoService(userRepo, todoRepo)
-	authService := service.NewAuthService(userRepo, cfg.JWTSecret)
+	todoRepo := psql.NewTodoRepository(db, logger, terminal...)
+	labelRepo := psql.NewLabelRepository(db, logger)
+	projectRepo := psql.NewProjectRepository(db, logger)
+	occurrenceRepo := psql.NewOccurrenceRepository(db, logger)
+	revisionRepo := psql.NewRevisionRepository(db, logger)
+	dependencyRepo := psql.NewDependencyRepository(db, logger)
+	timeEntryRepo := psql.NewTimeEntryRepository(db, logger)
+	templateRepo := psql.NewTemplateRepository(db, logger)
+	commentRepo := psql.NewCommentRepository(db, logger)
+	grantRepo := psql.NewGrantRepository(db, logger)
+	linkRepo := psql.NewLinkRepository(db, logger)
+	organizationRepo := psql.NewOrganizationRepository(db, logger)
+	invitationRepo := psql.NewInvitationRepository(db, logger)
+	idempotencyRepo := psql.NewIdempotencyRepository(db, logger)
+	err = wf.BindHooks(map[string]workflow.Hook{
+		"log":            workflow.NewLogHook(logger),
+		"clear_reminder": workflow.NewClearReminderHook(todoRepo),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	userSerivce := service.NewUserService(userRepo, db)
+	todoService := service.NewTodoService(userRepo, todoRepo, projectRepo, occurrenceRepo, revisionRepo,
+		dependencyRepo, templateRepo, db, wf)
+	labelService := service.NewLabelService(labelRepo, todoRepo)
+	templateService := service.NewTemplateService(templateRepo)
+	commentService := service.NewCommentService(commentRepo, todoRepo, db)
+	shareService := service.NewShareService(grantRepo, todoRepo, projectRepo, userRepo, organizationRepo)
+	linkService := service.NewLinkService(linkRepo, todoRepo, grantRepo, todoService, &cfg.PublicLinks)
+	projectService := service.NewProjectService(projectRepo, todoRepo, db)
+	timeEntryService := service.NewTimeEntryService(timeEntryRepo, todoRepo, todoService, db)
+	workflowService := service.NewWorkflowService(wf)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, logger, &cfg.Idempotency)
+	organizationService := service.NewOrganizationService(organizationRepo, db)
+	authService := service.NewAuthService(userRepo, organizationRepo, db, cfg.JWTSecret)
+	invitationMailer := mailer.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password,
+		cfg.SMTP.From)
+	invitationService := service.NewInvitationService(invitationRepo, organizationRepo, userRepo, authService,
+		invitationMailer, db, &cfg.Invitations)
 	authHandler := rest.NewAuthHandler(authService)
 	userHandler := rest.NewUserHandler(userSerivce)
 	todoHandler := rest.NewTodoHandler(todoService)
-
-	r := rest.NewRouter(cfg, authHandler, userHandler, todoHandler)
+	labelHandler := rest.NewLabelHandler(labelService)
+	templateHandler := rest.NewTemplateHandler(templateService)
+	commentHandler := rest.NewCommentHandler(commentService)
+	shareHandler := rest.NewShareHandler(shareService)
+	linkHandler := rest.NewLinkHandler(linkService)
+	organizationHandler := rest.NewOrganizationHandler(organizationService)
+	invitationHandler := rest.NewInvitationHandler(invitationService)
+	projectHandler := rest.NewProjectHandler(projectService)
+	timeEntryHandler := rest.NewTimeEntryHandler(timeEntryService)
+	workflowHandler := rest.NewWorkflowHandler(workflowService)
+
+	r := rest.NewRouter(cfg, authHandler, userHandler, todoHandler, labelHandler, projectHandler,
+		shareHandler, linkHandler, organizationHandler, invitationHandler, commentHandler, templateHandler,
+		timeEntryHandler, workflowHandler, organizationService, idempotencyService)
 	s := rest.NewHTTPServer(r, cfg)
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if cfg.Reminders.Enabled {
+		notifier, err := notify.NewNotifier(cfg, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		reminderService := service.NewReminderService(todoRepo, notifier, logger, &cfg.Reminders)
+		go reminderService.Run(workersCtx)
+	}
+
+	go idempotencyService.Run(workersCtx)
+
+	rebalanceService := service.NewRebalanceService(todoRepo, db, logger, &cfg.Positions)
+	go rebalanceService.Run(workersCtx)
+
+	if cfg.Trash.Enabled {
+		purgeService := service.NewPurgeService(todoRepo, userRepo, logger, &cfg.Trash)
+		go purgeService.Run(workersCtx)
+	}
+
 	go func() {
 
 		log.Println("server started")
@@ -53,6 +156,8 @@ func main() {
 
 	<-quit
 
+	stopWorkers()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+)
+
+var ErrMigrateUsage error = errors.New("usage: migrate up|down|status|to <version>")
+
+func runMigrate(db *psql.Postgres, logger *logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return ErrMigrateUsage
+	}
+
+	migrator, err := psql.NewMigrator(db, logger)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			return ErrMigrateUsage
+		}
+
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q: %w", args[1], ErrMigrateUsage)
+		}
+
+		return migrator.To(ctx, version)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		return printMigrationStatus(statuses)
+	default:
+		return ErrMigrateUsage
+	}
+}
+
+func printMigrationStatus(statuses []*psql.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	return w.Flush()
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -19,16 +20,90 @@ type PostgresConfig struct {
 	User     string `env:"DB_USER"`
 	Password string `env:"DB_PASSWORD"`
 	SSLmode  string `env:"DB_SSLMODE"`
+
+	AutoMigrate bool `env:"DB_AUTO_MIGRATE" env-default:"false"`
 }
 
 type HTTPConfig struct {
 	Port string `yaml:"http_port"`
 }
 
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST" env-default:"localhost"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" env-default:"1025"`
+	Username string `env:"SMTP_USERNAME"`
+	Password string `env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM" env-default:"app@localhost"`
+}
+
+type NotifierConfig struct {
+	Kind       string `yaml:"kind" env:"NOTIFIER_KIND" env-default:"log"`
+	WebhookURL string `yaml:"webhook_url" env:"NOTIFIER_WEBHOOK_URL"`
+}
+
+type RemindersConfig struct {
+	Enabled   bool           `yaml:"enabled" env:"REMINDERS_ENABLED" env-default:"false"`
+	Interval  time.Duration  `yaml:"interval" env-default:"1m"`
+	BatchSize uint64         `yaml:"batch_size" env-default:"100"`
+	Notifier  NotifierConfig `yaml:"notifier"`
+}
+
+type TrashConfig struct {
+	Enabled   bool          `yaml:"enabled" env:"TRASH_PURGE_ENABLED" env-default:"false"`
+	Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION" env-default:"720h"`
+	Interval  time.Duration `yaml:"interval" env-default:"1h"`
+}
+
+type IdempotencyConfig struct {
+	TTL      time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	Interval time.Duration `yaml:"interval" env-default:"1h"`
+}
+
+type PositionsConfig struct {
+	MaxLength int           `yaml:"max_length" env-default:"24"`
+	Interval  time.Duration `yaml:"interval" env-default:"1h"`
+	BatchSize uint64        `yaml:"batch_size" env-default:"100"`
+}
+
+type PublicLinksConfig struct {
+	Secret     string        `env:"PUBLIC_LINK_SECRET"`
+	BaseURL    string        `yaml:"base_url" env:"PUBLIC_LINK_BASE_URL"`
+	DefaultTTL time.Duration `yaml:"default_ttl" env-default:"168h"`
+	MaxTTL     time.Duration `yaml:"max_ttl" env-default:"720h"`
+}
+
+type InvitationsConfig struct {
+	TTL       time.Duration `yaml:"ttl" env-default:"72h"`
+	AcceptURL string        `yaml:"accept_url" env:"INVITATION_ACCEPT_URL"`
+}
+
+type WorkflowStateConfig struct {
+	Name        string   `yaml:"name"`
+	Label       string   `yaml:"label"`
+	Terminal    bool     `yaml:"terminal"`
+	Transitions []string `yaml:"transitions"`
+	WIPLimit    int      `yaml:"wip_limit"`
+	OnEnter     []string `yaml:"on_enter"`
+	OnExit      []string `yaml:"on_exit"`
+}
+
+type WorkflowConfig struct {
+	Initial string                `yaml:"initial"`
+	States  []WorkflowStateConfig `yaml:"states"`
+}
+
 type AppConfig struct {
-	Database   PostgresConfig
-	HTTPServer HTTPConfig `yaml:"http"`
-	JWTSecret  string     `env:"JWT_SECRET"`
+	Database    PostgresConfig
+	HTTPServer  HTTPConfig        `yaml:"http"`
+	JWTSecret   string            `env:"JWT_SECRET"`
+	SMTP        SMTPConfig        `yaml:"smtp"`
+	Reminders   RemindersConfig   `yaml:"reminders"`
+	Trash       TrashConfig       `yaml:"trash"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Positions   PositionsConfig   `yaml:"positions"`
+	Workflow    WorkflowConfig    `yaml:"workflow"`
+	PublicLinks PublicLinksConfig `yaml:"public_links"`
+	Invitations InvitationsConfig `yaml:"invitations"`
 }
 
 func MustLoadConfig(path string) *AppConfig {
@@ -44,5 +119,9 @@ func MustLoadConfig(path string) *AppConfig {
 		panic(ErrInvalidConfig)
 	}
 
+	if cfg.PublicLinks.Secret == "" {
+		cfg.PublicLinks.Secret = cfg.JWTSecret
+	}
+
 	return cfg
 }
@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type UserRegisterRequest struct {
 	Name     string `json:"name" validate:"required,min=2"`
@@ -9,12 +13,15 @@ type UserRegisterRequest struct {
 }
 
 type UserLoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Email    string     `json:"email" validate:"required,email"`
+	Password string     `json:"password" validate:"required,min=8"`
+	OrgID    *uuid.UUID `json:"orgID"`
 }
 
 type AuthResponse struct {
 	User      *UserResponse `json:"user"`
+	OrgID     uuid.UUID     `json:"orgID"`
+	Role      string        `json:"role"`
 	Token     string        `json:"token"`
 	ExpiresAt time.Time     `json:"expiresAt"`
 }
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	CommentCreateRequest struct {
+		TodoID   uuid.UUID  `json:"todoID" validate:"required"`
+		ParentID *uuid.UUID `json:"parentID"`
+		Body     string     `json:"body" validate:"required,max=10000"`
+	}
+
+	CommentUpdateRequest struct {
+		TodoID    uuid.UUID `json:"todoID" validate:"required"`
+		CommentID uuid.UUID `json:"commentID" validate:"required"`
+		Body      string    `json:"body" validate:"required,max=10000"`
+	}
+
+	CommentListRequest struct {
+		TodoID   uuid.UUID `validate:"required"`
+		ParentID *uuid.UUID
+		Cursor   string
+		Limit    int `validate:"gte=0,lte=100"`
+	}
+
+	CommentResponse struct {
+		ID         uuid.UUID  `json:"id"`
+		TodoID     uuid.UUID  `json:"todoID"`
+		AuthorID   uuid.UUID  `json:"authorID"`
+		ParentID   *uuid.UUID `json:"parentID,omitempty"`
+		Body       string     `json:"body"`
+		Edited     bool       `json:"edited"`
+		Deleted    bool       `json:"deleted"`
+		ReplyCount int        `json:"replyCount"`
+		CreatedAt  time.Time  `json:"createdAt"`
+		UpdatedAt  time.Time  `json:"updatedAt"`
+	}
+
+	CommentListResponse struct {
+		Items      []*CommentResponse `json:"items"`
+		NextCursor string             `json:"next_cursor,omitempty"`
+	}
+
+	CommentEditResponse struct {
+		Body     string    `json:"body"`
+		EditedAt time.Time `json:"editedAt"`
+	}
+)
@@ -0,0 +1,9 @@
+package dto
+
+import "net/http"
+
+type IdempotentResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	LabelCreateRequest struct {
+		Name  string `json:"name" validate:"required,max=64"`
+		Color string `json:"color" validate:"omitempty,hexcolor"`
+	}
+
+	LabelUpdateRequest struct {
+		LabelID uuid.UUID `json:"labelID" validate:"required"`
+		Name    string    `json:"name" validate:"required,max=64"`
+		Color   string    `json:"color" validate:"omitempty,hexcolor"`
+	}
+
+	LabelAttachRequest struct {
+		TodoID  uuid.UUID `json:"todoID" validate:"required"`
+		LabelID uuid.UUID `json:"labelID" validate:"required"`
+	}
+
+	LabelResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		Color     string    `json:"color"`
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+)
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	OrganizationCreateRequest struct {
+		Name string `json:"name" validate:"required,min=2,max=100"`
+	}
+
+	OrganizationResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		Role      string    `json:"role"`
+		Active    bool      `json:"active"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	MemberRoleChangeRequest struct {
+		OrgID    uuid.UUID `json:"orgID" validate:"required"`
+		MemberID uuid.UUID `json:"memberID" validate:"required"`
+		Role     string    `json:"role" validate:"required,oneof=owner admin member"`
+	}
+
+	MemberResponse struct {
+		UserID   uuid.UUID `json:"userID"`
+		Name     string    `json:"name"`
+		Email    string    `json:"email"`
+		Role     string    `json:"role"`
+		JoinedAt time.Time `json:"joinedAt"`
+	}
+
+	InvitationCreateRequest struct {
+		OrgID uuid.UUID `json:"orgID" validate:"required"`
+		Email string    `json:"email" validate:"required,email"`
+		Role  string    `json:"role" validate:"required,oneof=admin member"`
+	}
+
+	InvitationResponse struct {
+		ID        uuid.UUID `json:"id"`
+		OrgID     uuid.UUID `json:"orgID"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		ExpiresAt time.Time `json:"expiresAt"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	InvitationAcceptRequest struct {
+		Token    string `json:"token" validate:"required"`
+		Name     string `json:"name" validate:"omitempty,min=2"`
+		Password string `json:"password" validate:"omitempty,min=8"`
+	}
+
+	InvitationAcceptResponse struct {
+		OrgID      uuid.UUID `json:"orgID"`
+		Email      string    `json:"email"`
+		Role       string    `json:"role"`
+		Registered bool      `json:"registered"`
+	}
+)
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ProjectCreateRequest struct {
+		Name     string `json:"name" validate:"required,max=100"`
+		Color    string `json:"color" validate:"omitempty,hexcolor"`
+		Position int    `json:"position" validate:"gte=0"`
+	}
+
+	ProjectUpdateRequest struct {
+		ProjectID uuid.UUID `json:"projectID" validate:"required"`
+		Name      *string   `json:"name" validate:"omitempty,min=1,max=100"`
+		Color     *string   `json:"color" validate:"omitempty,hexcolor"`
+		Position  *int      `json:"position" validate:"omitempty,gte=0"`
+	}
+
+	ProjectArchiveRequest struct {
+		ProjectID uuid.UUID `json:"projectID" validate:"required"`
+		Archived  bool      `json:"archived"`
+	}
+
+	ProjectResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		Color     string    `json:"color"`
+		Archived  bool      `json:"archived"`
+		Position  int       `json:"position"`
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+)
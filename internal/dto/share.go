@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	ShareRequest struct {
+		TargetID uuid.UUID `json:"targetID" validate:"required"`
+		Email    string    `json:"email" validate:"required,email"`
+		Role     string    `json:"role" validate:"required,oneof=owner editor viewer"`
+	}
+
+	CollaboratorResponse struct {
+		UserID    uuid.UUID  `json:"userID"`
+		Name      string     `json:"name"`
+		Email     string     `json:"email"`
+		Role      string     `json:"role"`
+		Scope     string     `json:"scope"`
+		CreatedAt *time.Time `json:"createdAt,omitempty"`
+	}
+
+	LinkCreateRequest struct {
+		TodoID    *uuid.UUID  `json:"todoID"`
+		Filter    *LinkFilter `json:"filter"`
+		ExpiresAt *time.Time  `json:"expiresAt"`
+	}
+
+	LinkFilter struct {
+		ProjectID       *uuid.UUID  `json:"projectID,omitempty"`
+		IncludeArchived bool        `json:"includeArchived,omitempty"`
+		Statuses        []string    `json:"statuses,omitempty" validate:"dive,required"`
+		Overdue         bool        `json:"overdue,omitempty"`
+		LabelIDs        []uuid.UUID `json:"labelIDs,omitempty"`
+		LabelsMode      string      `json:"labelsMode,omitempty" validate:"omitempty,oneof=any all"`
+		Sort            string      `json:"sort,omitempty" validate:"omitempty,oneof=position -position created_at -created_at updated_at -updated_at content -content status -status"`
+	}
+
+	LinkResponse struct {
+		ID        uuid.UUID   `json:"id"`
+		Scope     string      `json:"scope"`
+		TodoID    *uuid.UUID  `json:"todoID,omitempty"`
+		Filter    *LinkFilter `json:"filter,omitempty"`
+		Token     string      `json:"token,omitempty"`
+		URL       string      `json:"url,omitempty"`
+		ExpiresAt time.Time   `json:"expiresAt"`
+		RevokedAt *time.Time  `json:"revokedAt,omitempty"`
+		CreatedAt time.Time   `json:"createdAt"`
+	}
+
+	PublicTodosRequest struct {
+		Token  string `validate:"required"`
+		Cursor string
+		Limit  int `validate:"gte=0,lte=100"`
+	}
+
+	PublicTodosResponse struct {
+		Scope      string          `json:"scope"`
+		Todo       *TodoResponse   `json:"todo,omitempty"`
+		Items      []*TodoResponse `json:"items,omitempty"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+		ExpiresAt  time.Time       `json:"expiresAt"`
+	}
+)
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	TemplateItem struct {
+		Content   string          `json:"content" validate:"required,max=1000"`
+		DueInDays *int            `json:"dueInDays,omitempty" validate:"omitempty,min=0,max=3650"`
+		Children  []*TemplateItem `json:"children,omitempty" validate:"max=50,dive,required"`
+	}
+
+	TemplateCreateRequest struct {
+		Name        string          `json:"name" validate:"required,max=128"`
+		Description *string         `json:"description" validate:"omitempty,max=1000"`
+		Items       []*TemplateItem `json:"items" validate:"required,min=1,max=50,dive,required"`
+	}
+
+	TemplateUpdateRequest struct {
+		TemplateID  uuid.UUID       `json:"templateID" validate:"required"`
+		Name        string          `json:"name" validate:"required,max=128"`
+		Description *string         `json:"description" validate:"omitempty,max=1000"`
+		Items       []*TemplateItem `json:"items" validate:"required,min=1,max=50,dive,required"`
+	}
+
+	TemplateInstantiateRequest struct {
+		TemplateID uuid.UUID         `json:"templateID" validate:"required"`
+		ProjectID  *uuid.UUID        `json:"projectID"`
+		ParentID   *uuid.UUID        `json:"parentID"`
+		Variables  map[string]string `json:"variables" validate:"max=50"`
+	}
+
+	TemplateResponse struct {
+		ID          uuid.UUID       `json:"id"`
+		Name        string          `json:"name"`
+		Description *string         `json:"description,omitempty"`
+		Variables   []string        `json:"variables"`
+		Items       []*TemplateItem `json:"items"`
+		CreatedAt   time.Time       `json:"createdAt"`
+		UpdatedAt   time.Time       `json:"updatedAt"`
+	}
+
+	TemplateInstantiateResponse struct {
+		TodoIDs []uuid.UUID `json:"todoIDs"`
+	}
+)
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	TimerStartRequest struct {
+		TodoID uuid.UUID `json:"todoID" validate:"required"`
+		Note   *string   `json:"note" validate:"omitempty,max=1000"`
+		Status *string   `json:"status"`
+	}
+
+	TimeEntryCreateRequest struct {
+		TodoID    uuid.UUID `json:"todoID" validate:"required"`
+		StartedAt time.Time `json:"startedAt" validate:"required"`
+		StoppedAt time.Time `json:"stoppedAt" validate:"required"`
+		Note      *string   `json:"note" validate:"omitempty,max=1000"`
+	}
+
+	TimeTotalsRequest struct {
+		GroupBy string `validate:"omitempty,oneof=todo day week"`
+		From    *time.Time
+		To      *time.Time
+	}
+
+	TimeEntryResponse struct {
+		ID        uuid.UUID  `json:"id"`
+		TodoID    uuid.UUID  `json:"todoID"`
+		StartedAt time.Time  `json:"startedAt"`
+		StoppedAt *time.Time `json:"stoppedAt,omitempty"`
+		Running   bool       `json:"running"`
+		Seconds   int64      `json:"seconds"`
+		Note      *string    `json:"note,omitempty"`
+		CreatedAt time.Time  `json:"createdAt"`
+	}
+
+	TodoTimeResponse struct {
+		TotalSeconds int64                `json:"totalSeconds"`
+		Entries      []*TimeEntryResponse `json:"entries"`
+	}
+
+	TimeTotalResponse struct {
+		TodoID  *uuid.UUID `json:"todoID,omitempty"`
+		Period  *time.Time `json:"period,omitempty"`
+		Seconds int64      `json:"seconds"`
+	}
+)
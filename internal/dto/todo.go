@@ -8,15 +8,96 @@ import (
 
 type (
 	TodoCreateRequest struct {
-		Content string `json:"content" validate:"required"`
-		Status  string `json:"status" validate:"required,oneof=todo process"`
+		Content    string     `json:"content" validate:"required"`
+		Status     string     `json:"status" validate:"required"`
+		ProjectID  *uuid.UUID `json:"projectID"`
+		ParentID   *uuid.UUID `json:"parentID"`
+		DueAt      *time.Time `json:"dueAt"`
+		Recurrence string     `json:"recurrence" validate:"omitempty,max=255"`
+		RemindAt   *time.Time `json:"remindAt"`
 	}
 
 	TodoResponse struct {
-		Content   string    `json:"content"`
-		Status    string    `json:"status"`
-		CreatedAt time.Time `json:"createdAt"`
-		UpdatedAt time.Time `json:"updatedAt"`
+		ID         uuid.UUID       `json:"id"`
+		ProjectID  *uuid.UUID      `json:"projectID,omitempty"`
+		ParentID   *uuid.UUID      `json:"parentID,omitempty"`
+		SeriesID   *uuid.UUID      `json:"seriesID,omitempty"`
+		AssigneeID *uuid.UUID      `json:"assigneeID,omitempty"`
+		Content    string          `json:"content"`
+		Status     string          `json:"status"`
+		DueAt      *time.Time      `json:"dueAt,omitempty"`
+		RemindAt   *time.Time      `json:"remindAt,omitempty"`
+		Overdue    bool            `json:"overdue"`
+		Archived   bool            `json:"archived"`
+		Version    int             `json:"version"`
+		Position   string          `json:"position"`
+		Recurrence *string         `json:"recurrence,omitempty"`
+		Progress   *TodoProgress   `json:"progress,omitempty"`
+		Children   []*TodoResponse `json:"children,omitempty"`
+		BlockedBy  []uuid.UUID     `json:"blockedBy,omitempty"`
+		Blocks     []uuid.UUID     `json:"blocks,omitempty"`
+		CreatedAt  time.Time       `json:"createdAt"`
+		UpdatedAt  time.Time       `json:"updatedAt"`
+		DeletedAt  *time.Time      `json:"deletedAt,omitempty"`
+	}
+
+	TodoProgress struct {
+		Done    int     `json:"done"`
+		Total   int     `json:"total"`
+		Percent float64 `json:"percent"`
+	}
+
+	TodoListRequest struct {
+		ProjectID       *uuid.UUID
+		IncludeArchived bool
+		Statuses        []string `validate:"dive,required"`
+		CreatedAfter    *time.Time
+		CreatedBefore   *time.Time
+		UpdatedAfter    *time.Time
+		UpdatedBefore   *time.Time
+		DueAfter        *time.Time
+		DueBefore       *time.Time
+		Overdue         bool
+		LabelIDs        []uuid.UUID
+		LabelsMode      string `validate:"omitempty,oneof=any all"`
+		Sort            string `validate:"omitempty,oneof=position -position created_at -created_at updated_at -updated_at content -content status -status"`
+		Cursor          string
+		Limit           int `validate:"gte=0,lte=100"`
+	}
+
+	TodoListResponse struct {
+		Items      []*TodoResponse `json:"items"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}
+
+	BoardRequest struct {
+		ProjectID *uuid.UUID
+		Limit     int `validate:"gte=0,lte=100"`
+	}
+
+	BoardResponse struct {
+		Columns []*BoardColumn `json:"columns"`
+	}
+
+	BoardColumn struct {
+		Status     string          `json:"status"`
+		Label      string          `json:"label"`
+		Terminal   bool            `json:"terminal"`
+		WIPLimit   int             `json:"wipLimit,omitempty"`
+		Count      int             `json:"count"`
+		Items      []*TodoResponse `json:"items"`
+		NextCursor string          `json:"next_cursor,omitempty"`
+	}
+
+	TodoSearchRequest struct {
+		Query string `validate:"required,max=200"`
+		Limit int    `validate:"gte=0,lte=100"`
+	}
+
+	TodoSearchResponse struct {
+		*TodoResponse
+		Rank    float64 `json:"rank"`
+		Snippet string  `json:"snippet"`
 	}
 
 	TodoContentChangeRequest struct {
@@ -24,8 +105,88 @@ type (
 		NewContent string    `json:"content" validate:"required"`
 	}
 
+	TodoScheduleChangeRequest struct {
+		TodoID   uuid.UUID  `json:"todoID" validate:"required"`
+		DueAt    *time.Time `json:"dueAt"`
+		RemindAt *time.Time `json:"remindAt"`
+	}
+
+	TodoProjectChangeRequest struct {
+		TodoID    uuid.UUID  `json:"todoID" validate:"required"`
+		ProjectID *uuid.UUID `json:"projectID"`
+	}
+
+	TodoAssigneeChangeRequest struct {
+		TodoID     uuid.UUID  `json:"todoID" validate:"required"`
+		AssigneeID *uuid.UUID `json:"assigneeID"`
+	}
+
+	TodoParentChangeRequest struct {
+		TodoID   uuid.UUID  `json:"todoID" validate:"required"`
+		ParentID *uuid.UUID `json:"parentID"`
+	}
+
+	TodoPositionChangeRequest struct {
+		TodoID uuid.UUID  `json:"todoID" validate:"required"`
+		Before *uuid.UUID `json:"before"`
+		After  *uuid.UUID `json:"after"`
+	}
+
+	TodoDependencyRequest struct {
+		TodoID    uuid.UUID `json:"todoID" validate:"required"`
+		BlockerID uuid.UUID `json:"blockerID" validate:"required"`
+	}
+
+	TodoRecurrenceChangeRequest struct {
+		TodoID     uuid.UUID `json:"todoID" validate:"required"`
+		Recurrence string    `json:"recurrence" validate:"omitempty,max=255"`
+	}
+
+	TodoOccurrenceResponse struct {
+		ID          uuid.UUID  `json:"id"`
+		TodoID      *uuid.UUID `json:"todoID,omitempty"`
+		NextTodoID  *uuid.UUID `json:"nextTodoID,omitempty"`
+		Content     string     `json:"content"`
+		DueAt       *time.Time `json:"dueAt,omitempty"`
+		CompletedAt time.Time  `json:"completedAt"`
+	}
+
+	TodoRevisionResponse struct {
+		ID        uuid.UUID  `json:"id"`
+		TodoID    uuid.UUID  `json:"todoID"`
+		ActorID   *uuid.UUID `json:"actorID,omitempty"`
+		Field     string     `json:"field"`
+		OldValue  *string    `json:"oldValue"`
+		NewValue  *string    `json:"newValue"`
+		CreatedAt time.Time  `json:"createdAt"`
+	}
+
+	TodoBatchRequest struct {
+		Operations []*TodoBatchOperation `json:"operations" validate:"required,min=1,max=100"`
+	}
+
+	TodoBatchOperation struct {
+		Op      string             `json:"op"`
+		TodoID  uuid.UUID          `json:"todoID"`
+		Version *int               `json:"version"`
+		Todo    *TodoCreateRequest `json:"todo"`
+		Content *string            `json:"content"`
+		Status  *string            `json:"status"`
+	}
+
+	TodoBatchResponse struct {
+		Results []*TodoBatchResult `json:"results"`
+	}
+
+	TodoBatchResult struct {
+		Index int        `json:"index"`
+		Op    string     `json:"op"`
+		ID    *uuid.UUID `json:"id,omitempty"`
+		Error string     `json:"error,omitempty"`
+	}
+
 	TodoStatusChangeRequest struct {
 		TodoID    uuid.UUID `json:"todoID" validate:"required"`
-		NewStatus string    `json:"status" validate:"required,oneof=todo process done"`
+		NewStatus string    `json:"status" validate:"required"`
 	}
 )
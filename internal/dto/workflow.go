@@ -0,0 +1,16 @@
+package dto
+
+type (
+	WorkflowStateResponse struct {
+		Name        string   `json:"name"`
+		Label       string   `json:"label"`
+		Terminal    bool     `json:"terminal"`
+		Transitions []string `json:"transitions"`
+		WIPLimit    int      `json:"wipLimit,omitempty"`
+	}
+
+	WorkflowResponse struct {
+		Initial string                   `json:"initial"`
+		States  []*WorkflowStateResponse `json:"states"`
+	}
+)
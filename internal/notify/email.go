@@ -0,0 +1,32 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/identicalaffiliation/app/pkg/mailer"
+)
+
+type emailNotifier struct {
+	mailer mailer.Mailer
+}
+
+func NewEmailNotifier(m mailer.Mailer) Notifier {
+	return &emailNotifier{mailer: m}
+}
+
+func (en *emailNotifier) Notify(ctx context.Context, notification *Notification) error {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\nreminder for your todo: %s\n", notification.UserName, notification.Content)
+	if notification.DueAt != nil {
+		fmt.Fprintf(&body, "Due at: %s\n", notification.DueAt.Format(time.RFC1123Z))
+	}
+
+	return en.mailer.Send(ctx, &mailer.Message{
+		To:      []string{notification.UserEmail},
+		Subject: mailer.HeaderText("Reminder: " + notification.Content),
+		Body:    body.String(),
+	})
+}
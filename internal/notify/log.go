@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/identicalaffiliation/app/internal/logger"
+)
+
+type logNotifier struct {
+	logger *logger.Logger
+}
+
+func NewLogNotifier(logger *logger.Logger) Notifier {
+	return &logNotifier{logger: logger}
+}
+
+func (ln *logNotifier) Notify(ctx context.Context, notification *Notification) error {
+	ln.logger.Logger.Info("todo reminder",
+		"operation", "notify",
+		"user_id", notification.UserID.String(),
+		"todo_id", notification.TodoID.String(),
+		"content", notification.Content,
+		"remind_at", notification.RemindAt,
+	)
+
+	return nil
+}
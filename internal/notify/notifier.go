@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/pkg/mailer"
+)
+
+var ErrUnknownNotifier error = errors.New("unknown notifier kind")
+
+type Notification struct {
+	TodoID    uuid.UUID  `json:"todoID"`
+	UserID    uuid.UUID  `json:"userID"`
+	UserEmail string     `json:"email"`
+	UserName  string     `json:"name"`
+	Content   string     `json:"content"`
+	DueAt     *time.Time `json:"dueAt,omitempty"`
+	RemindAt  time.Time  `json:"remindAt"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+func NewNotifier(cfg *config.AppConfig, logger *logger.Logger) (Notifier, error) {
+	switch cfg.Reminders.Notifier.Kind {
+	case "", "log":
+		return NewLogNotifier(logger), nil
+	case "webhook":
+		return NewWebhookNotifier(cfg.Reminders.Notifier.WebhookURL), nil
+	case "smtp":
+		m := mailer.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username,
+			cfg.SMTP.Password, cfg.SMTP.From)
+
+		return NewEmailNotifier(m), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNotifier, cfg.Reminders.Notifier.Kind)
+	}
+}
@@ -0,0 +1,123 @@
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits string = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidRange error = errors.New("lower rank must be less than upper rank")
+	ErrInvalidKey   error = errors.New("rank key must use base36 digits and must not end with zero")
+)
+
+func Between(lower, upper string) (string, error) {
+	if !valid(lower) || !valid(upper) {
+		return "", ErrInvalidKey
+	}
+
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidRange
+	}
+
+	return midpoint(lower, upper), nil
+}
+
+func After(key string) (string, error) {
+	return Between(key, "")
+}
+
+func Spread(n int) []string {
+	width, space := 1, len(digits)
+	for space < 2*(n+1) {
+		width++
+		space *= len(digits)
+	}
+
+	step := space / (n + 1)
+	keys := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		value := i * step
+		if value%len(digits) == 0 {
+			value++
+		}
+
+		keys = append(keys, encode(value, width))
+	}
+
+	return keys
+}
+
+func valid(key string) bool {
+	if key == "" {
+		return true
+	}
+
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+
+	return key[len(key)-1] != digits[0]
+}
+
+func midpoint(lower, upper string) string {
+	if upper != "" {
+		n := 0
+		for n < len(upper) && digitAt(lower, n) == upper[n] {
+			n++
+		}
+
+		if n > 0 {
+			return upper[:n] + midpoint(suffix(lower, n), upper[n:])
+		}
+	}
+
+	digitLower := 0
+	if lower != "" {
+		digitLower = strings.IndexByte(digits, lower[0])
+	}
+
+	digitUpper := len(digits)
+	if upper != "" {
+		digitUpper = strings.IndexByte(digits, upper[0])
+	}
+
+	if digitUpper-digitLower > 1 {
+		return string(digits[(digitLower+digitUpper+1)/2])
+	}
+
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+
+	return string(digits[digitLower]) + midpoint(suffix(lower, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+
+	return digits[0]
+}
+
+func suffix(key string, i int) string {
+	if i < len(key) {
+		return key[i:]
+	}
+
+	return ""
+}
+
+func encode(value, width int) string {
+	key := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		key[i] = digits[value%len(digits)]
+		value /= len(digits)
+	}
+
+	return string(key)
+}
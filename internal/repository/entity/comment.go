@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	ID         uuid.UUID  `db:"id"`
+	TodoID     uuid.UUID  `db:"todo_id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	ParentID   *uuid.UUID `db:"parent_id"`
+	Body       string     `db:"body"`
+	ReplyCount int        `db:"reply_count"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at"`
+}
+
+type CommentEdit struct {
+	ID        uuid.UUID `db:"id"`
+	CommentID uuid.UUID `db:"comment_id"`
+	Body      string    `db:"body"`
+	EditedAt  time.Time `db:"edited_at"`
+}
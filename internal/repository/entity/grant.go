@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Grant struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	TodoID    *uuid.UUID `db:"todo_id"`
+	ProjectID *uuid.UUID `db:"project_id"`
+	Role      string     `db:"role"`
+	GrantedBy *uuid.UUID `db:"granted_by"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type Collaborator struct {
+	UserID    uuid.UUID `db:"user_id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	Role      string    `db:"role"`
+	Scope     string    `db:"scope"`
+	CreatedAt time.Time `db:"created_at"`
+}
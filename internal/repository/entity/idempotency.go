@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type IdempotencyKey struct {
+	Scope           string           `db:"scope"`
+	Key             string           `db:"key"`
+	Fingerprint     string           `db:"fingerprint"`
+	StatusCode      *int             `db:"status_code"`
+	ResponseHeaders *ResponseHeaders `db:"response_headers"`
+	ResponseBody    []byte           `db:"response_body"`
+	CreatedAt       time.Time        `db:"created_at"`
+	CompletedAt     *time.Time       `db:"completed_at"`
+}
+
+type ResponseHeaders map[string][]string
+
+func (rh ResponseHeaders) Value() (driver.Value, error) {
+	return json.Marshal(rh)
+}
+
+func (rh *ResponseHeaders) Scan(src any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, rh)
+	case string:
+		return json.Unmarshal([]byte(data), rh)
+	default:
+		return errors.New("response headers must be JSON")
+	}
+}
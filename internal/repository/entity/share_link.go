@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ShareLink struct {
+	ID        uuid.UUID   `db:"id"`
+	UserID    uuid.UUID   `db:"user_id"`
+	OrgID     *uuid.UUID  `db:"org_id"`
+	TodoID    *uuid.UUID  `db:"todo_id"`
+	Filter    *LinkFilter `db:"filter"`
+	ExpiresAt time.Time   `db:"expires_at"`
+	RevokedAt *time.Time  `db:"revoked_at"`
+	CreatedAt time.Time   `db:"created_at"`
+}
+
+type LinkFilter struct {
+	ProjectID       *uuid.UUID  `json:"projectID,omitempty"`
+	IncludeArchived bool        `json:"includeArchived,omitempty"`
+	Statuses        []string    `json:"statuses,omitempty"`
+	Overdue         bool        `json:"overdue,omitempty"`
+	LabelIDs        []uuid.UUID `json:"labelIDs,omitempty"`
+	LabelsMode      string      `json:"labelsMode,omitempty"`
+	Sort            string      `json:"sort,omitempty"`
+}
+
+func (lf LinkFilter) Value() (driver.Value, error) {
+	return json.Marshal(lf)
+}
+
+func (lf *LinkFilter) Scan(src any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, lf)
+	case string:
+		return json.Unmarshal([]byte(data), lf)
+	default:
+		return errors.New("link filter must be JSON")
+	}
+}
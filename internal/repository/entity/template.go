@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TodoTemplate struct {
+	ID          uuid.UUID     `db:"id"`
+	UserID      uuid.UUID     `db:"user_id"`
+	Name        string        `db:"name"`
+	Description *string       `db:"description"`
+	Items       TemplateItems `db:"items"`
+	CreatedAt   time.Time     `db:"created_at"`
+	UpdatedAt   time.Time     `db:"updated_at"`
+}
+
+type TemplateItem struct {
+	Content   string          `json:"content"`
+	DueInDays *int            `json:"dueInDays,omitempty"`
+	Children  []*TemplateItem `json:"children,omitempty"`
+}
+
+type TemplateItems []*TemplateItem
+
+func (ti TemplateItems) Value() (driver.Value, error) {
+	return json.Marshal(ti)
+}
+
+func (ti *TemplateItems) Scan(src any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, ti)
+	case string:
+		return json.Unmarshal([]byte(data), ti)
+	default:
+		return errors.New("template items must be JSON")
+	}
+}
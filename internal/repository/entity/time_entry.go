@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeEntry struct {
+	ID        uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	TodoID    uuid.UUID  `db:"todo_id"`
+	StartedAt time.Time  `db:"started_at"`
+	StoppedAt *time.Time `db:"stopped_at"`
+	Note      *string    `db:"note"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type TimeTotal struct {
+	TodoID  *uuid.UUID `db:"todo_id"`
+	Period  *time.Time `db:"period"`
+	Seconds int64      `db:"seconds"`
+}
@@ -7,10 +7,72 @@ import (
 )
 
 type Todo struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Content   string    `db:"content"`
-	Status    string    `db:"status"`
+	ID              uuid.UUID  `db:"id"`
+	UserID          uuid.UUID  `db:"user_id"`
+	ProjectID       *uuid.UUID `db:"project_id"`
+	ParentID        *uuid.UUID `db:"parent_id"`
+	Content         string     `db:"content"`
+	Status          string     `db:"status"`
+	DueAt           *time.Time `db:"due_at"`
+	RemindAt        *time.Time `db:"remind_at"`
+	Recurrence      *string    `db:"recurrence"`
+	RecurrenceStart *time.Time `db:"recurrence_start"`
+	SeriesID        *uuid.UUID `db:"series_id"`
+	AssigneeID      *uuid.UUID `db:"assignee_id"`
+	Archived        bool       `db:"archived"`
+	Version         int        `db:"version"`
+	Position        string     `db:"position"`
+	ChildrenTotal   int        `db:"children_total"`
+	ChildrenDone    int        `db:"children_done"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	DeletedAt       *time.Time `db:"deleted_at"`
+}
+
+type StatusCount struct {
+	Status string `db:"status"`
+	Count  int    `db:"count"`
+}
+
+type TodoOccurrence struct {
+	ID          uuid.UUID  `db:"id"`
+	SeriesID    uuid.UUID  `db:"series_id"`
+	UserID      uuid.UUID  `db:"user_id"`
+	TodoID      *uuid.UUID `db:"todo_id"`
+	NextTodoID  *uuid.UUID `db:"next_todo_id"`
+	Content     string     `db:"content"`
+	DueAt       *time.Time `db:"due_at"`
+	CompletedAt time.Time  `db:"completed_at"`
+}
+
+type TodoSearchResult struct {
+	Todo
+	Rank    float64 `db:"rank"`
+	Snippet string  `db:"snippet"`
+}
+
+type Reminder struct {
+	TodoID    uuid.UUID  `db:"id"`
+	UserID    uuid.UUID  `db:"user_id"`
+	UserEmail string     `db:"email"`
+	UserName  string     `db:"name"`
+	Content   string     `db:"content"`
+	DueAt     *time.Time `db:"due_at"`
+	RemindAt  time.Time  `db:"remind_at"`
+}
+
+type TodoRevision struct {
+	ID        uuid.UUID  `db:"id"`
+	TodoID    uuid.UUID  `db:"todo_id"`
+	ActorID   *uuid.UUID `db:"actor_id"`
+	Field     string     `db:"field"`
+	OldValue  *string    `db:"old_value"`
+	NewValue  *string    `db:"new_value"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+type TodoDependency struct {
+	BlockerID uuid.UUID `db:"blocker_id"`
+	BlockedID uuid.UUID `db:"blocked_id"`
 	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
 }
@@ -0,0 +1,357 @@
+package psql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const (
+	commentColumns string = "id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at, " +
+		"(SELECT count(*) FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL) AS reply_count"
+	commentLockColumns string = "id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at"
+	commentEditColumns string = "id, comment_id, body, edited_at"
+)
+
+type CommentFilter struct {
+	ParentID *uuid.UUID
+	Cursor   *CommentCursor
+	Limit    uint64
+}
+
+type CommentCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func EncodeCommentCursor(comment *entity.Comment) string {
+	data, _ := json.Marshal(CommentCursor{CreatedAt: comment.CreatedAt, ID: comment.ID})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCommentCursor(encoded string) (*CommentCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor CommentCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if cursor.ID == uuid.Nil || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *entity.Comment) error
+	GetByID(ctx context.Context, commentID, todoID uuid.UUID) (*entity.Comment, error)
+	Lock(ctx context.Context, commentID, todoID uuid.UUID) (*entity.Comment, error)
+	GetByTodoID(ctx context.Context, todoID uuid.UUID, filter *CommentFilter) ([]*entity.Comment, error)
+	UpdateBody(ctx context.Context, comment *entity.Comment) error
+	Delete(ctx context.Context, commentID, userID uuid.UUID) error
+	CreateEdit(ctx context.Context, edit *entity.CommentEdit) error
+	GetEdits(ctx context.Context, commentID uuid.UUID) ([]*entity.CommentEdit, error)
+}
+
+type commentRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewCommentRepository(db *Postgres, logger *logger.Logger) CommentRepository {
+	qb := NewQueryBuilder()
+
+	return &commentRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (cr *commentRepository) Create(ctx context.Context, comment *entity.Comment) error {
+	sql, args, err := cr.qb.Builder.Insert("comments").
+		Columns("id", "todo_id", "user_id", "parent_id", "body").
+		Values(comment.ID, comment.TodoID, comment.UserID, comment.ParentID, comment.Body).
+		Suffix("RETURNING created_at, updated_at").ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for create comment",
+			"operation", "create comment",
+			"user_id", comment.UserID.String(),
+			"todo_id", comment.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = cr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		cr.logger.Logger.Error("failed to create comment",
+			"operation", "create comment",
+			"user_id", comment.UserID.String(),
+			"todo_id", comment.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert comment: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *commentRepository) GetByID(ctx context.Context, commentID, todoID uuid.UUID) (*entity.Comment, error) {
+	sql, args, err := cr.qb.Builder.Select(commentColumns).From("comments").
+		Where(squirrel.Eq{"id": commentID}).Where(squirrel.Eq{"todo_id": todoID}).ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for get comment",
+			"operation", "get comment",
+			"todo_id", todoID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var comment entity.Comment
+	if err := cr.db.conn(ctx).GetContext(ctx, &comment, sql, args...); err != nil {
+		cr.logger.Logger.Error("failed to get comment",
+			"operation", "get comment",
+			"todo_id", todoID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+func (cr *commentRepository) Lock(ctx context.Context, commentID, todoID uuid.UUID) (*entity.Comment, error) {
+	sql, args, err := cr.qb.Builder.Select(commentLockColumns).From("comments").
+		Where(squirrel.Eq{"id": commentID}).Where(squirrel.Eq{"todo_id": todoID}).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for lock comment",
+			"operation", "lock comment",
+			"todo_id", todoID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var comment entity.Comment
+	if err := cr.db.conn(ctx).GetContext(ctx, &comment, sql, args...); err != nil {
+		cr.logger.Logger.Error("failed to lock comment",
+			"operation", "lock comment",
+			"todo_id", todoID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+func (cr *commentRepository) GetByTodoID(ctx context.Context, todoID uuid.UUID,
+	filter *CommentFilter) ([]*entity.Comment, error) {
+	query := cr.qb.Builder.Select(commentColumns).From("comments").Where(squirrel.Eq{"todo_id": todoID}).
+		Where("(deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL))")
+
+	if filter.ParentID != nil {
+		query = query.Where(squirrel.Eq{"parent_id": *filter.ParentID})
+	} else {
+		query = query.Where(squirrel.Eq{"parent_id": nil})
+	}
+
+	if filter.Cursor != nil {
+		query = query.Where(squirrel.Expr("(created_at, id) > (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID))
+	}
+
+	query = query.OrderBy("created_at", "id")
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for get comments",
+			"operation", "get comments",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	comments := make([]*entity.Comment, 0)
+	if err := cr.db.conn(ctx).SelectContext(ctx, &comments, sql, args...); err != nil {
+		cr.logger.Logger.Error("failed to get comments",
+			"operation", "get comments",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (cr *commentRepository) UpdateBody(ctx context.Context, comment *entity.Comment) error {
+	sql, args, err := cr.qb.Builder.Update("comments").Set("body", comment.Body).
+		Set("updated_at", squirrel.Expr("now()")).Where(squirrel.Eq{"id": comment.ID}).
+		Where(squirrel.Eq{"user_id": comment.UserID}).Where(squirrel.Eq{"deleted_at": nil}).
+		Suffix("RETURNING updated_at").ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for update comment",
+			"operation", "update comment",
+			"user_id", comment.UserID.String(),
+			"comment_id", comment.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = cr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&comment.UpdatedAt)
+	if err != nil {
+		cr.logger.Logger.Error("failed to update comment",
+			"operation", "update comment",
+			"user_id", comment.UserID.String(),
+			"comment_id", comment.ID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("update comment: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *commentRepository) Delete(ctx context.Context, commentID, userID uuid.UUID) error {
+	sql, args, err := cr.qb.Builder.Update("comments").Set("deleted_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": commentID}).Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"deleted_at": nil}).ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for delete comment",
+			"operation", "delete comment",
+			"user_id", userID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := cr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		cr.logger.Logger.Error("failed to delete comment",
+			"operation", "delete comment",
+			"user_id", userID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete comment: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		cr.logger.Logger.Error("failed to get affected from delete comment",
+			"operation", "delete comment",
+			"user_id", userID.String(),
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		cr.logger.Logger.Error("failed to delete comment",
+			"operation", "delete comment",
+			"user_id", userID.String(),
+			"comment_id", commentID.String(),
+			"error", errors.New("comment not found").Error(),
+		)
+
+		return errors.New("comment not found")
+	}
+
+	return nil
+}
+
+func (cr *commentRepository) CreateEdit(ctx context.Context, edit *entity.CommentEdit) error {
+	sql, args, err := cr.qb.Builder.Insert("comment_edits").Columns("id", "comment_id", "body").
+		Values(edit.ID, edit.CommentID, edit.Body).Suffix("RETURNING edited_at").ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for create comment edit",
+			"operation", "create comment edit",
+			"comment_id", edit.CommentID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = cr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&edit.EditedAt)
+	if err != nil {
+		cr.logger.Logger.Error("failed to create comment edit",
+			"operation", "create comment edit",
+			"comment_id", edit.CommentID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert comment edit: %w", err)
+	}
+
+	return nil
+}
+
+func (cr *commentRepository) GetEdits(ctx context.Context, commentID uuid.UUID) ([]*entity.CommentEdit, error) {
+	sql, args, err := cr.qb.Builder.Select(commentEditColumns).From("comment_edits").
+		Where(squirrel.Eq{"comment_id": commentID}).OrderBy("edited_at DESC", "id").ToSql()
+	if err != nil {
+		cr.logger.Logger.Error("failed to build query for get comment edits",
+			"operation", "get comment edits",
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	edits := make([]*entity.CommentEdit, 0)
+	if err := cr.db.conn(ctx).SelectContext(ctx, &edits, sql, args...); err != nil {
+		cr.logger.Logger.Error("failed to get comment edits",
+			"operation", "get comment edits",
+			"comment_id", commentID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select comment edits: %w", err)
+	}
+
+	return edits, nil
+}
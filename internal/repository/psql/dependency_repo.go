@@ -0,0 +1,229 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const dependencyColumns string = "d.blocker_id, d.blocked_id, d.created_at"
+
+type DependencyRepository interface {
+	Create(ctx context.Context, dependency *entity.TodoDependency) error
+	Delete(ctx context.Context, blockerID, blockedID, userID uuid.UUID) error
+	Reaches(ctx context.Context, fromID, toID uuid.UUID) (bool, error)
+	GetByTodoIDs(ctx context.Context, todoIDs []uuid.UUID, userID uuid.UUID) ([]*entity.TodoDependency, error)
+	GetOpenBlockers(ctx context.Context, todoID uuid.UUID, terminal []string) ([]uuid.UUID, error)
+}
+
+type dependencyRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewDependencyRepository(db *Postgres, logger *logger.Logger) DependencyRepository {
+	qb := NewQueryBuilder()
+
+	return &dependencyRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (dr *dependencyRepository) Create(ctx context.Context, dependency *entity.TodoDependency) error {
+	sql, args, err := dr.qb.Builder.Insert("todo_dependencies").Columns("blocker_id", "blocked_id").
+		Values(dependency.BlockerID, dependency.BlockedID).Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		dr.logger.Logger.Error("failed to build query for create dependency",
+			"operation", "create dependency",
+			"blocker_id", dependency.BlockerID.String(),
+			"blocked_id", dependency.BlockedID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = dr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&dependency.CreatedAt)
+	if err != nil {
+		dr.logger.Logger.Error("failed to create dependency",
+			"operation", "create dependency",
+			"blocker_id", dependency.BlockerID.String(),
+			"blocked_id", dependency.BlockedID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert dependency: %w", err)
+	}
+
+	return nil
+}
+
+func (dr *dependencyRepository) Delete(ctx context.Context, blockerID, blockedID, userID uuid.UUID) error {
+	owned := dr.qb.Builder.Select("id").From("todos").Where(squirrel.Eq{"user_id": userID})
+
+	sql, args, err := dr.qb.Builder.Delete("todo_dependencies").Where(squirrel.Eq{"blocker_id": blockerID}).
+		Where(squirrel.Eq{"blocked_id": blockedID}).
+		Where(owned.Prefix("blocked_id IN (").Suffix(")")).ToSql()
+	if err != nil {
+		dr.logger.Logger.Error("failed to build query for delete dependency",
+			"operation", "delete dependency",
+			"user_id", userID.String(),
+			"blocker_id", blockerID.String(),
+			"blocked_id", blockedID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := dr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		dr.logger.Logger.Error("failed to delete dependency",
+			"operation", "delete dependency",
+			"user_id", userID.String(),
+			"blocker_id", blockerID.String(),
+			"blocked_id", blockedID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete dependency: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		dr.logger.Logger.Error("failed to get affected from delete dependency",
+			"operation", "delete dependency",
+			"user_id", userID.String(),
+			"blocker_id", blockerID.String(),
+			"blocked_id", blockedID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		dr.logger.Logger.Error("failed to delete dependency",
+			"operation", "delete dependency",
+			"user_id", userID.String(),
+			"blocker_id", blockerID.String(),
+			"blocked_id", blockedID.String(),
+			"error", errors.New("dependency not found").Error(),
+		)
+
+		return errors.New("dependency not found")
+	}
+
+	return nil
+}
+
+func (dr *dependencyRepository) Reaches(ctx context.Context, fromID, toID uuid.UUID) (bool, error) {
+	chain := "WITH RECURSIVE chain AS (" +
+		"SELECT blocked_id FROM todo_dependencies WHERE blocker_id = ? " +
+		"UNION " +
+		"SELECT d.blocked_id FROM todo_dependencies d JOIN chain ON d.blocker_id = chain.blocked_id)"
+
+	sql, args, err := dr.qb.Builder.Select("EXISTS (SELECT 1 FROM chain WHERE blocked_id = ?)").
+		Prefix(chain, fromID).ToSql()
+	if err != nil {
+		dr.logger.Logger.Error("failed to build query for check dependency chain",
+			"operation", "check dependency chain",
+			"from_id", fromID.String(),
+			"to_id", toID.String(),
+			"error", err.Error(),
+		)
+
+		return false, ErrFailBuildQuery
+	}
+
+	args = append(args, toID)
+
+	var reaches bool
+	if err := dr.db.conn(ctx).GetContext(ctx, &reaches, sql, args...); err != nil {
+		dr.logger.Logger.Error("failed to check dependency chain",
+			"operation", "check dependency chain",
+			"from_id", fromID.String(),
+			"to_id", toID.String(),
+			"error", err.Error(),
+		)
+
+		return false, fmt.Errorf("select dependency chain: %w", err)
+	}
+
+	return reaches, nil
+}
+
+func (dr *dependencyRepository) GetByTodoIDs(ctx context.Context, todoIDs []uuid.UUID,
+	userID uuid.UUID) ([]*entity.TodoDependency, error) {
+	sql, args, err := dr.qb.Builder.Select(dependencyColumns).From("todo_dependencies d").
+		Join("todos b ON b.id = d.blocker_id").Join("todos t ON t.id = d.blocked_id").
+		Where(squirrel.Or{squirrel.Eq{"d.blocker_id": todoIDs}, squirrel.Eq{"d.blocked_id": todoIDs}}).
+		Where(squirrel.Eq{"t.user_id": userID}).
+		Where(squirrel.Eq{"b.deleted_at": nil}).Where(squirrel.Eq{"t.deleted_at": nil}).
+		OrderBy("d.created_at", "d.blocker_id", "d.blocked_id").ToSql()
+	if err != nil {
+		dr.logger.Logger.Error("failed to build query for get dependencies",
+			"operation", "get dependencies",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	dependencies := make([]*entity.TodoDependency, 0)
+	if err := dr.db.conn(ctx).SelectContext(ctx, &dependencies, sql, args...); err != nil {
+		dr.logger.Logger.Error("failed to get dependencies",
+			"operation", "get dependencies",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select dependencies: %w", err)
+	}
+
+	return dependencies, nil
+}
+
+func (dr *dependencyRepository) GetOpenBlockers(ctx context.Context, todoID uuid.UUID,
+	terminal []string) ([]uuid.UUID, error) {
+	sql, args, err := dr.qb.Builder.Select("d.blocker_id").From("todo_dependencies d").
+		Join("todos b ON b.id = d.blocker_id").
+		Where(squirrel.Eq{"d.blocked_id": todoID}).Where(squirrel.Eq{"b.deleted_at": nil}).
+		Where(squirrel.NotEq{"b.status": terminal}).
+		OrderBy("d.blocker_id").ToSql()
+	if err != nil {
+		dr.logger.Logger.Error("failed to build query for get open blockers",
+			"operation", "get open blockers",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	blockerIDs := make([]uuid.UUID, 0)
+	if err := dr.db.conn(ctx).SelectContext(ctx, &blockerIDs, sql, args...); err != nil {
+		dr.logger.Logger.Error("failed to get open blockers",
+			"operation", "get open blockers",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select open blockers: %w", err)
+	}
+
+	return blockerIDs, nil
+}
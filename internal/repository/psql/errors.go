@@ -2,10 +2,26 @@ package psql
 
 import (
 	"errors"
+
+	"github.com/lib/pq"
 )
 
+const uniqueViolationCode pq.ErrorCode = "23505"
+
 var (
 	ErrFailBuildQuery error = errors.New("fail to build query")
 	ErrInvalidUserID  error = errors.New("invalid user ID")
 	ErrGetAffected    error = errors.New("result does not affected")
+	ErrInvalidCursor  error = errors.New("invalid cursor")
+	ErrAlreadyExists  error = errors.New("already exists")
+
+	ErrInvalidMigrationName error = errors.New("invalid migration file name")
+	ErrUnknownMigration     error = errors.New("unknown migration version")
+	ErrNoMigrationsApplied  error = errors.New("no migrations applied")
 )
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,271 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/lib/pq"
+)
+
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+var roleOrder = []Role{RoleViewer, RoleEditor, RoleOwner}
+
+func roleRank(role Role) int {
+	for rank, candidate := range roleOrder {
+		if candidate == role {
+			return rank + 1
+		}
+	}
+
+	return 0
+}
+
+func ValidRole(role Role) bool {
+	return roleRank(role) > 0
+}
+
+func (r Role) Includes(role Role) bool {
+	return roleRank(r) >= roleRank(role)
+}
+
+func rolesIncluding(role Role) []string {
+	roles := make([]string, 0, len(roleOrder))
+	for _, candidate := range roleOrder {
+		if candidate.Includes(role) {
+			roles = append(roles, string(candidate))
+		}
+	}
+
+	return roles
+}
+
+func accessCondition(table string, userID uuid.UUID, role Role) (string, []interface{}) {
+	condition := fmt.Sprintf("(%[1]s.user_id = ? OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = ? "+
+		"AND g.role = ANY(?) AND (g.todo_id = %[1]s.id OR g.project_id = %[1]s.project_id)))", table)
+
+	return condition, []interface{}{userID, userID, pq.Array(rolesIncluding(role))}
+}
+
+func canAccess(table string, userID uuid.UUID, role Role) squirrel.Sqlizer {
+	condition, args := accessCondition(table, userID, role)
+
+	return squirrel.Expr(condition, args...)
+}
+
+type GrantTarget struct {
+	TodoID    *uuid.UUID
+	ProjectID *uuid.UUID
+}
+
+func (t *GrantTarget) String() string {
+	if t.TodoID != nil {
+		return "todo:" + t.TodoID.String()
+	}
+
+	return "project:" + t.ProjectID.String()
+}
+
+func (t *GrantTarget) exact() squirrel.Eq {
+	if t.TodoID != nil {
+		return squirrel.Eq{"todo_id": *t.TodoID}
+	}
+
+	return squirrel.Eq{"project_id": *t.ProjectID}
+}
+
+func (t *GrantTarget) inherited() squirrel.Sqlizer {
+	if t.TodoID != nil {
+		return squirrel.Expr("(g.todo_id = ? OR g.project_id = (SELECT project_id FROM todos WHERE id = ?))",
+			*t.TodoID, *t.TodoID)
+	}
+
+	return squirrel.Eq{"g.project_id": *t.ProjectID}
+}
+
+func (t *GrantTarget) conflict() string {
+	if t.TodoID != nil {
+		return "ON CONFLICT (todo_id, user_id) WHERE todo_id IS NOT NULL"
+	}
+
+	return "ON CONFLICT (project_id, user_id) WHERE project_id IS NOT NULL"
+}
+
+type GrantRepository interface {
+	Upsert(ctx context.Context, grant *entity.Grant) error
+	GetCollaborators(ctx context.Context, target *GrantTarget) ([]*entity.Collaborator, error)
+	GetRoles(ctx context.Context, target *GrantTarget, userID uuid.UUID) ([]Role, error)
+	Delete(ctx context.Context, target *GrantTarget, userID uuid.UUID) error
+}
+
+type grantRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewGrantRepository(db *Postgres, logger *logger.Logger) GrantRepository {
+	qb := NewQueryBuilder()
+
+	return &grantRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (gr *grantRepository) Upsert(ctx context.Context, grant *entity.Grant) error {
+	target := &GrantTarget{TodoID: grant.TodoID, ProjectID: grant.ProjectID}
+
+	sql, args, err := gr.qb.Builder.Insert("grants").
+		Columns("id", "user_id", "todo_id", "project_id", "role", "granted_by").
+		Values(grant.ID, grant.UserID, grant.TodoID, grant.ProjectID, grant.Role, grant.GrantedBy).
+		Suffix(target.conflict() + " DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by " +
+			"RETURNING id, created_at").ToSql()
+	if err != nil {
+		gr.logger.Logger.Error("failed to build query for upsert grant",
+			"operation", "upsert grant",
+			"user_id", grant.UserID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = gr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&grant.ID, &grant.CreatedAt)
+	if err != nil {
+		gr.logger.Logger.Error("failed to upsert grant",
+			"operation", "upsert grant",
+			"user_id", grant.UserID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("upsert grant: %w", err)
+	}
+
+	return nil
+}
+
+func (gr *grantRepository) GetCollaborators(ctx context.Context, target *GrantTarget) ([]*entity.Collaborator, error) {
+	sql, args, err := gr.qb.Builder.
+		Select("g.user_id", "u.name", "u.email", "g.role",
+			"CASE WHEN g.todo_id IS NULL THEN 'project' ELSE 'todo' END AS scope", "g.created_at").
+		From("grants g").Join("users u ON u.id = g.user_id").Where(squirrel.Eq{"u.deleted_at": nil}).
+		Where(target.inherited()).OrderBy("g.created_at", "g.user_id").ToSql()
+	if err != nil {
+		gr.logger.Logger.Error("failed to build query for get collaborators",
+			"operation", "get collaborators",
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	collaborators := make([]*entity.Collaborator, 0)
+	if err := gr.db.conn(ctx).SelectContext(ctx, &collaborators, sql, args...); err != nil {
+		gr.logger.Logger.Error("failed to get collaborators",
+			"operation", "get collaborators",
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select collaborators: %w", err)
+	}
+
+	return collaborators, nil
+}
+
+func (gr *grantRepository) GetRoles(ctx context.Context, target *GrantTarget, userID uuid.UUID) ([]Role, error) {
+	sql, args, err := gr.qb.Builder.Select("g.role").From("grants g").
+		Where(squirrel.Eq{"g.user_id": userID}).Where(target.inherited()).ToSql()
+	if err != nil {
+		gr.logger.Logger.Error("failed to build query for get roles",
+			"operation", "get roles",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	roles := make([]Role, 0)
+	if err := gr.db.conn(ctx).SelectContext(ctx, &roles, sql, args...); err != nil {
+		gr.logger.Logger.Error("failed to get roles",
+			"operation", "get roles",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (gr *grantRepository) Delete(ctx context.Context, target *GrantTarget, userID uuid.UUID) error {
+	sql, args, err := gr.qb.Builder.Delete("grants").Where(squirrel.Eq{"user_id": userID}).
+		Where(target.exact()).ToSql()
+	if err != nil {
+		gr.logger.Logger.Error("failed to build query for delete grant",
+			"operation", "delete grant",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := gr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		gr.logger.Logger.Error("failed to delete grant",
+			"operation", "delete grant",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete grant: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		gr.logger.Logger.Error("failed to get affected from delete grant",
+			"operation", "delete grant",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		gr.logger.Logger.Error("failed to delete grant",
+			"operation", "delete grant",
+			"user_id", userID.String(),
+			"target", target.String(),
+			"error", errors.New("grant not found").Error(),
+		)
+
+		return errors.New("grant not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+type IdempotencyRepository interface {
+	Create(ctx context.Context, record *entity.IdempotencyKey) error
+	Get(ctx context.Context, scope, key string) (*entity.IdempotencyKey, error)
+	Complete(ctx context.Context, record *entity.IdempotencyKey) error
+	Delete(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewIdempotencyRepository(db *Postgres, logger *logger.Logger) IdempotencyRepository {
+	qb := NewQueryBuilder()
+
+	return &idempotencyRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (ir *idempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyKey) error {
+	sql, args, err := ir.qb.Builder.Insert("idempotency_keys").Columns("scope", "key", "fingerprint").
+		Values(record.Scope, record.Key, record.Fingerprint).Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for create idempotency key",
+			"operation", "create idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := ir.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		ir.logger.Logger.Error("failed to create idempotency key",
+			"operation", "create idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		ir.logger.Logger.Error("failed to get affected from create idempotency key",
+			"operation", "create idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+func (ir *idempotencyRepository) Get(ctx context.Context, scope, key string) (*entity.IdempotencyKey, error) {
+	sql, args, err := ir.qb.Builder.
+		Select("scope, key, fingerprint, status_code, response_headers, response_body, created_at, completed_at").
+		From("idempotency_keys").Where(squirrel.Eq{"scope": scope}).Where(squirrel.Eq{"key": key}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for get idempotency key",
+			"operation", "get idempotency key",
+			"scope", scope,
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var record entity.IdempotencyKey
+	if err := ir.db.conn(ctx).GetContext(ctx, &record, sql, args...); err != nil {
+		ir.logger.Logger.Error("failed to get idempotency key",
+			"operation", "get idempotency key",
+			"scope", scope,
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select idempotency key: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (ir *idempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyKey) error {
+	sql, args, err := ir.qb.Builder.Update("idempotency_keys").Set("status_code", record.StatusCode).
+		Set("response_headers", record.ResponseHeaders).Set("response_body", record.ResponseBody).
+		Set("completed_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"scope": record.Scope}).Where(squirrel.Eq{"key": record.Key}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for complete idempotency key",
+			"operation", "complete idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := ir.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		ir.logger.Logger.Error("failed to complete idempotency key",
+			"operation", "complete idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		ir.logger.Logger.Error("failed to get affected from complete idempotency key",
+			"operation", "complete idempotency key",
+			"scope", record.Scope,
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		ir.logger.Logger.Error("failed to complete idempotency key",
+			"operation", "complete idempotency key",
+			"scope", record.Scope,
+			"error", errors.New("idempotency key not found").Error(),
+		)
+
+		return errors.New("idempotency key not found")
+	}
+
+	return nil
+}
+
+func (ir *idempotencyRepository) Delete(ctx context.Context, scope, key string) error {
+	sql, args, err := ir.qb.Builder.Delete("idempotency_keys").
+		Where(squirrel.Eq{"scope": scope}).Where(squirrel.Eq{"key": key}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for delete idempotency key",
+			"operation", "delete idempotency key",
+			"scope", scope,
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if _, err := ir.db.conn(ctx).ExecContext(ctx, sql, args...); err != nil {
+		ir.logger.Logger.Error("failed to delete idempotency key",
+			"operation", "delete idempotency key",
+			"scope", scope,
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (ir *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := ir.qb.Builder.Delete("idempotency_keys").
+		Where(squirrel.Lt{"created_at": before}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for delete expired idempotency keys",
+			"operation", "delete expired idempotency keys",
+			"error", err.Error(),
+		)
+
+		return 0, ErrFailBuildQuery
+	}
+
+	result, err := ir.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		ir.logger.Logger.Error("failed to delete expired idempotency keys",
+			"operation", "delete expired idempotency keys",
+			"error", err.Error(),
+		)
+
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		ir.logger.Logger.Error("failed to get affected from delete expired idempotency keys",
+			"operation", "delete expired idempotency keys",
+			"error", err.Error(),
+		)
+
+		return 0, ErrGetAffected
+	}
+
+	return affected, nil
+}
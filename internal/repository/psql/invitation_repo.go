@@ -0,0 +1,193 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const invitationColumns string = "id, org_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at"
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *entity.Invitation) error
+	GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*entity.Invitation, error)
+	GetPendingForUpdate(ctx context.Context, tokenHash string) (*entity.Invitation, error)
+	Accept(ctx context.Context, invitationID uuid.UUID) error
+	Delete(ctx context.Context, invitationID, orgID uuid.UUID) error
+}
+
+type invitationRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewInvitationRepository(db *Postgres, logger *logger.Logger) InvitationRepository {
+	qb := NewQueryBuilder()
+
+	return &invitationRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (ir *invitationRepository) Create(ctx context.Context, invitation *entity.Invitation) error {
+	sql, args, err := ir.qb.Builder.Insert("invitations").
+		Columns("id", "org_id", "email", "role", "token_hash", "invited_by", "expires_at").
+		Values(invitation.ID, invitation.OrgID, invitation.Email, invitation.Role, invitation.TokenHash,
+			invitation.InvitedBy, invitation.ExpiresAt).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for create invitation",
+			"operation", "create invitation",
+			"org_id", invitation.OrgID.String(),
+			"invitation_id", invitation.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if err := ir.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&invitation.CreatedAt); err != nil {
+		ir.logger.Logger.Error("failed to create invitation",
+			"operation", "create invitation",
+			"org_id", invitation.OrgID.String(),
+			"invitation_id", invitation.ID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert invitation: %w", err)
+	}
+
+	return nil
+}
+
+func (ir *invitationRepository) GetPendingByOrgID(ctx context.Context, orgID uuid.UUID) ([]*entity.Invitation, error) {
+	sql, args, err := ir.qb.Builder.Select(invitationColumns).From("invitations").
+		Where(squirrel.Eq{"org_id": orgID}).Where(squirrel.Eq{"accepted_at": nil}).
+		Where("expires_at > now()").OrderBy("created_at DESC", "id").ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for get invitations",
+			"operation", "get invitations",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	invitations := make([]*entity.Invitation, 0)
+	if err := ir.db.conn(ctx).SelectContext(ctx, &invitations, sql, args...); err != nil {
+		ir.logger.Logger.Error("failed to get invitations",
+			"operation", "get invitations",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+func (ir *invitationRepository) GetPendingForUpdate(ctx context.Context, tokenHash string) (*entity.Invitation, error) {
+	sql, args, err := ir.qb.Builder.Select(invitationColumns).From("invitations").
+		Where(squirrel.Eq{"token_hash": tokenHash}).Where(squirrel.Eq{"accepted_at": nil}).
+		Where("expires_at > now()").Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for lock invitation",
+			"operation", "lock invitation",
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var invitation entity.Invitation
+	if err := ir.db.conn(ctx).GetContext(ctx, &invitation, sql, args...); err != nil {
+		ir.logger.Logger.Error("failed to lock invitation",
+			"operation", "lock invitation",
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock invitation: %w", err)
+	}
+
+	return &invitation, nil
+}
+
+func (ir *invitationRepository) Accept(ctx context.Context, invitationID uuid.UUID) error {
+	sql, args, err := ir.qb.Builder.Update("invitations").Set("accepted_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": invitationID}).Where(squirrel.Eq{"accepted_at": nil}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for accept invitation",
+			"operation", "accept invitation",
+			"invitation_id", invitationID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return ir.execInvitation(ctx, "accept invitation", sql, args, invitationID)
+}
+
+func (ir *invitationRepository) Delete(ctx context.Context, invitationID, orgID uuid.UUID) error {
+	sql, args, err := ir.qb.Builder.Delete("invitations").
+		Where(squirrel.Eq{"id": invitationID}).Where(squirrel.Eq{"org_id": orgID}).
+		Where(squirrel.Eq{"accepted_at": nil}).ToSql()
+	if err != nil {
+		ir.logger.Logger.Error("failed to build query for delete invitation",
+			"operation", "delete invitation",
+			"invitation_id", invitationID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return ir.execInvitation(ctx, "delete invitation", sql, args, invitationID)
+}
+
+func (ir *invitationRepository) execInvitation(ctx context.Context, operation, sql string, args []interface{},
+	invitationID uuid.UUID) error {
+	result, err := ir.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		ir.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"invitation_id", invitationID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		ir.logger.Logger.Error("failed to get affected from "+operation,
+			"operation", operation,
+			"invitation_id", invitationID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		ir.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"invitation_id", invitationID.String(),
+			"error", errors.New("invitation not found").Error(),
+		)
+
+		return errors.New("invitation not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,361 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+type LabelRepository interface {
+	Create(ctx context.Context, label *entity.Label) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Label, error)
+	GetByID(ctx context.Context, labelID, userID uuid.UUID) (*entity.Label, error)
+	GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.Label, error)
+	Update(ctx context.Context, label *entity.Label) error
+	Delete(ctx context.Context, labelID, userID uuid.UUID) error
+	Attach(ctx context.Context, todoID, labelID, userID uuid.UUID) error
+	Detach(ctx context.Context, todoID, labelID, userID uuid.UUID) error
+}
+
+type labelRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewLabelRepository(db *Postgres, logger *logger.Logger) LabelRepository {
+	qb := NewQueryBuilder()
+
+	return &labelRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (lr *labelRepository) Create(ctx context.Context, label *entity.Label) error {
+	sql, args, err := lr.qb.Builder.Insert("labels").Columns("id", "user_id", "name", "color").
+		Values(label.ID, label.UserID, label.Name, label.Color).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for create label",
+			"operation", "create label",
+			"label_id", label.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = lr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&label.ID, &label.CreatedAt)
+	if err != nil {
+		lr.logger.Logger.Error("failed to create label",
+			"operation", "create label",
+			"label_id", label.ID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert label: %w", err)
+	}
+
+	return nil
+}
+
+func (lr *labelRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Label, error) {
+	sql, args, err := lr.qb.Builder.Select("id, user_id, name, color, created_at, updated_at").
+		From("labels").Where(squirrel.Eq{"user_id": userID}).OrderBy("name").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for get labels",
+			"operation", "get labels",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	labels := make([]*entity.Label, 0)
+	if err := lr.db.conn(ctx).SelectContext(ctx, &labels, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to get labels",
+			"operation", "get labels",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (lr *labelRepository) GetByID(ctx context.Context, labelID, userID uuid.UUID) (*entity.Label, error) {
+	sql, args, err := lr.qb.Builder.Select("id, user_id, name, color, created_at, updated_at").
+		From("labels").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"id": labelID}).ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for get label",
+			"operation", "get label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var label entity.Label
+	if err := lr.db.conn(ctx).GetContext(ctx, &label, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to get label",
+			"operation", "get label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select label: %w", err)
+	}
+
+	return &label, nil
+}
+
+func (lr *labelRepository) GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.Label, error) {
+	sql, args, err := lr.qb.Builder.Select("l.id, l.user_id, l.name, l.color, l.created_at, l.updated_at").
+		From("labels l").Join("todo_labels tl ON tl.label_id = l.id").
+		Where(squirrel.Eq{"l.user_id": userID}).Where(squirrel.Eq{"tl.todo_id": todoID}).
+		OrderBy("l.name").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for get todo labels",
+			"operation", "get todo labels",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	labels := make([]*entity.Label, 0)
+	if err := lr.db.conn(ctx).SelectContext(ctx, &labels, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to get todo labels",
+			"operation", "get todo labels",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select todo labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+func (lr *labelRepository) Update(ctx context.Context, label *entity.Label) error {
+	sql, args, err := lr.qb.Builder.Update("labels").Set("name", label.Name).Set("color", label.Color).
+		Set("updated_at", squirrel.Expr("now()")).Where(squirrel.Eq{"id": label.ID}).
+		Where(squirrel.Eq{"user_id": label.UserID}).ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for update label",
+			"operation", "update label",
+			"user_id", label.UserID.String(),
+			"label_id", label.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := lr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		lr.logger.Logger.Error("failed to update label",
+			"operation", "update label",
+			"user_id", label.UserID.String(),
+			"label_id", label.ID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("update label: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		lr.logger.Logger.Error("failed to get affected from update label",
+			"operation", "update label",
+			"user_id", label.UserID.String(),
+			"label_id", label.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		lr.logger.Logger.Error("failed to update label",
+			"operation", "update label",
+			"user_id", label.UserID.String(),
+			"label_id", label.ID.String(),
+			"error", errors.New("label not found").Error(),
+		)
+
+		return errors.New("label not found")
+	}
+
+	return nil
+}
+
+func (lr *labelRepository) Delete(ctx context.Context, labelID, userID uuid.UUID) error {
+	sql, args, err := lr.qb.Builder.Delete("labels").Where(squirrel.Eq{"id": labelID}).
+		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for delete label",
+			"operation", "delete label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := lr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		lr.logger.Logger.Error("failed to delete label",
+			"operation", "delete label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete label: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		lr.logger.Logger.Error("failed to get affected from delete label",
+			"operation", "delete label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		lr.logger.Logger.Error("failed to delete label",
+			"operation", "delete label",
+			"user_id", userID.String(),
+			"label_id", labelID.String(),
+			"error", errors.New("label not found").Error(),
+		)
+
+		return errors.New("label not found")
+	}
+
+	return nil
+}
+
+func (lr *labelRepository) Attach(ctx context.Context, todoID, labelID, userID uuid.UUID) error {
+	owned := lr.qb.Builder.Select("t.id", "l.id").From("todos t").
+		Join("labels l ON l.user_id = t.user_id").
+		Where(squirrel.Eq{"t.id": todoID}).Where(squirrel.Eq{"l.id": labelID}).
+		Where(squirrel.Eq{"t.user_id": userID}).Where(squirrel.Eq{"t.deleted_at": nil})
+
+	sql, args, err := lr.qb.Builder.Insert("todo_labels").Columns("todo_id", "label_id").
+		Select(owned).Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for attach label",
+			"operation", "attach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if _, err := lr.db.conn(ctx).ExecContext(ctx, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to attach label",
+			"operation", "attach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("attach label: %w", err)
+	}
+
+	return nil
+}
+
+func (lr *labelRepository) Detach(ctx context.Context, todoID, labelID, userID uuid.UUID) error {
+	owned := lr.qb.Builder.Select("id").From("labels").Where(squirrel.Eq{"user_id": userID})
+
+	sql, args, err := lr.qb.Builder.Delete("todo_labels").Where(squirrel.Eq{"todo_id": todoID}).
+		Where(squirrel.Eq{"label_id": labelID}).
+		Where(owned.Prefix("label_id IN (").Suffix(")")).ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for detach label",
+			"operation", "detach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := lr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		lr.logger.Logger.Error("failed to detach label",
+			"operation", "detach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("detach label: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		lr.logger.Logger.Error("failed to get affected from detach label",
+			"operation", "detach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		lr.logger.Logger.Error("failed to detach label",
+			"operation", "detach label",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"label_id", labelID.String(),
+			"error", errors.New("label is not attached").Error(),
+		)
+
+		return errors.New("label is not attached")
+	}
+
+	return nil
+}
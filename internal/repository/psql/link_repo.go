@@ -0,0 +1,174 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const linkColumns string = "id, user_id, org_id, todo_id, filter, expires_at, revoked_at, created_at"
+
+type LinkRepository interface {
+	Create(ctx context.Context, link *entity.ShareLink) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.ShareLink, error)
+	GetActive(ctx context.Context, linkID uuid.UUID) (*entity.ShareLink, error)
+	Revoke(ctx context.Context, linkID, userID uuid.UUID) error
+}
+
+type linkRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewLinkRepository(db *Postgres, logger *logger.Logger) LinkRepository {
+	qb := NewQueryBuilder()
+
+	return &linkRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (lr *linkRepository) Create(ctx context.Context, link *entity.ShareLink) error {
+	sql, args, err := lr.qb.Builder.Insert("share_links").
+		Columns("id", "user_id", "org_id", "todo_id", "filter", "expires_at").
+		Values(link.ID, link.UserID, organizationID(ctx), link.TodoID, link.Filter, link.ExpiresAt).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for create share link",
+			"operation", "create share link",
+			"link_id", link.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if err := lr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&link.CreatedAt); err != nil {
+		lr.logger.Logger.Error("failed to create share link",
+			"operation", "create share link",
+			"link_id", link.ID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert share link: %w", err)
+	}
+
+	return nil
+}
+
+func (lr *linkRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.ShareLink, error) {
+	sql, args, err := lr.qb.Builder.Select(linkColumns).From("share_links").
+		Where(squirrel.Eq{"user_id": userID}).Where(inOrganization(ctx, "org_id")).
+		OrderBy("created_at DESC", "id").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for get share links",
+			"operation", "get share links",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	links := make([]*entity.ShareLink, 0)
+	if err := lr.db.conn(ctx).SelectContext(ctx, &links, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to get share links",
+			"operation", "get share links",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select share links: %w", err)
+	}
+
+	return links, nil
+}
+
+func (lr *linkRepository) GetActive(ctx context.Context, linkID uuid.UUID) (*entity.ShareLink, error) {
+	sql, args, err := lr.qb.Builder.Select(linkColumns).From("share_links").
+		Where(squirrel.Eq{"id": linkID}).Where(squirrel.Eq{"revoked_at": nil}).
+		Where("expires_at > now()").ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for get share link",
+			"operation", "get share link",
+			"link_id", linkID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var link entity.ShareLink
+	if err := lr.db.conn(ctx).GetContext(ctx, &link, sql, args...); err != nil {
+		lr.logger.Logger.Error("failed to get share link",
+			"operation", "get share link",
+			"link_id", linkID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+func (lr *linkRepository) Revoke(ctx context.Context, linkID, userID uuid.UUID) error {
+	sql, args, err := lr.qb.Builder.Update("share_links").Set("revoked_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": linkID}).Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"revoked_at": nil}).Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		lr.logger.Logger.Error("failed to build query for revoke share link",
+			"operation", "revoke share link",
+			"user_id", userID.String(),
+			"link_id", linkID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := lr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		lr.logger.Logger.Error("failed to revoke share link",
+			"operation", "revoke share link",
+			"user_id", userID.String(),
+			"link_id", linkID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("revoke share link: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		lr.logger.Logger.Error("failed to get affected from revoke share link",
+			"operation", "revoke share link",
+			"user_id", userID.String(),
+			"link_id", linkID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		lr.logger.Logger.Error("failed to revoke share link",
+			"operation", "revoke share link",
+			"user_id", userID.String(),
+			"link_id", linkID.String(),
+			"error", errors.New("share link not found").Error(),
+		)
+
+		return errors.New("share link not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS todos (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    content    TEXT        NOT NULL,
+    status     TEXT        NOT NULL DEFAULT 'todo',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS todos_user_id_idx ON todos (user_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS todos_user_id_updated_at_idx;
+DROP INDEX IF EXISTS todos_user_id_created_at_idx;
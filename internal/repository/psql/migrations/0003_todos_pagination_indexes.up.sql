@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS todos_user_id_created_at_idx ON todos (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS todos_user_id_updated_at_idx ON todos (user_id, updated_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS todos_content_trgm_idx;
+DROP INDEX IF EXISTS todos_search_vector_idx;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+        GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+CREATE INDEX IF NOT EXISTS todos_search_vector_idx ON todos USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS todos_content_trgm_idx ON todos USING GIN (content gin_trgm_ops);
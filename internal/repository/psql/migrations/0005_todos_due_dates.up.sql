@@ -0,0 +1,7 @@
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS due_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS remind_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reminded_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS todos_user_id_due_at_idx ON todos (user_id, due_at);
+CREATE INDEX IF NOT EXISTS todos_pending_reminders_idx ON todos (remind_at) WHERE reminded_at IS NULL;
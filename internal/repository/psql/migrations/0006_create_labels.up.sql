@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS labels (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    color      TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS todo_labels (
+    todo_id    UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    label_id   UUID        NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (todo_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS todo_labels_label_id_idx ON todo_labels (label_id);
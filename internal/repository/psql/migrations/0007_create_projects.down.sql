@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS todos_project_id_idx;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS archived,
+    DROP COLUMN IF EXISTS project_id;
+
+DROP TABLE IF EXISTS projects;
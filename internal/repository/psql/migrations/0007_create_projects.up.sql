@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT        NOT NULL,
+    color      TEXT        NOT NULL DEFAULT '',
+    archived   BOOLEAN     NOT NULL DEFAULT false,
+    position   INTEGER     NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS projects_user_id_position_idx ON projects (user_id, position);
+
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS project_id UUID REFERENCES projects (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS archived   BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS todos_project_id_idx ON todos (project_id);
@@ -0,0 +1,5 @@
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES todos (id) ON DELETE CASCADE,
+    ADD CONSTRAINT todos_parent_not_self_chk CHECK (parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS todos_parent_id_idx ON todos (parent_id);
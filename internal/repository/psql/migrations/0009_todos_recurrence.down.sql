@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS todo_occurrences;
+
+DROP INDEX IF EXISTS todos_series_id_idx;
+
+ALTER TABLE todos
+    DROP COLUMN IF EXISTS series_id,
+    DROP COLUMN IF EXISTS recurrence_start,
+    DROP COLUMN IF EXISTS recurrence;
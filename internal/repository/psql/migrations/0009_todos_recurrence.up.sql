@@ -0,0 +1,20 @@
+ALTER TABLE todos
+    ADD COLUMN IF NOT EXISTS recurrence       TEXT,
+    ADD COLUMN IF NOT EXISTS recurrence_start TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS series_id        UUID;
+
+CREATE INDEX IF NOT EXISTS todos_series_id_idx ON todos (series_id) WHERE series_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS todo_occurrences (
+    id           UUID PRIMARY KEY,
+    series_id    UUID        NOT NULL,
+    user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    todo_id      UUID        REFERENCES todos (id) ON DELETE SET NULL,
+    next_todo_id UUID        REFERENCES todos (id) ON DELETE SET NULL,
+    content      TEXT        NOT NULL,
+    due_at       TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (todo_id)
+);
+
+CREATE INDEX IF NOT EXISTS todo_occurrences_series_idx ON todo_occurrences (series_id, completed_at DESC);
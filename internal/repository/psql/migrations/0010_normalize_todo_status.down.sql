@@ -0,0 +1 @@
+-- 'processed' was never a valid API status, nothing to restore.
@@ -0,0 +1 @@
+UPDATE todos SET status = 'process' WHERE status = 'processed';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS todo_revisions (
+    id         UUID PRIMARY KEY,
+    todo_id    UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    actor_id   UUID        REFERENCES users (id) ON DELETE SET NULL,
+    field      VARCHAR(32) NOT NULL,
+    old_value  TEXT,
+    new_value  TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS todo_revisions_todo_idx ON todo_revisions (todo_id, created_at DESC);
@@ -0,0 +1,12 @@
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS position TEXT COLLATE "C";
+
+UPDATE todos t SET position = ranked.position
+FROM (
+    SELECT id, lpad(to_hex(row_number() OVER (PARTITION BY user_id ORDER BY created_at, id)), 8, '0') || 'i' AS position
+    FROM todos
+) ranked
+WHERE t.id = ranked.id;
+
+ALTER TABLE todos ALTER COLUMN position SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS todos_user_position_idx ON todos (user_id, position, id);
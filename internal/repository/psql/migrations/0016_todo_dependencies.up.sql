@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS todo_dependencies (
+    blocker_id UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    blocked_id UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS todo_dependencies_blocked_idx ON todo_dependencies (blocked_id);
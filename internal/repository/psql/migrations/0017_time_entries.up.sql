@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    todo_id    UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    stopped_at TIMESTAMPTZ,
+    note       TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (stopped_at IS NULL OR stopped_at >= started_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_running_idx ON time_entries (user_id) WHERE stopped_at IS NULL;
+CREATE INDEX IF NOT EXISTS time_entries_todo_idx ON time_entries (todo_id, started_at);
+CREATE INDEX IF NOT EXISTS time_entries_user_started_idx ON time_entries (user_id, started_at);
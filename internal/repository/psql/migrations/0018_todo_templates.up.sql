@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS todo_templates (
+    id          UUID PRIMARY KEY,
+    user_id     UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name        VARCHAR(128) NOT NULL,
+    description TEXT,
+    items       JSONB        NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id         UUID PRIMARY KEY,
+    todo_id    UUID        NOT NULL REFERENCES todos (id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    parent_id  UUID        REFERENCES comments (id) ON DELETE CASCADE,
+    body       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS comments_todo_idx ON comments (todo_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_parent_idx ON comments (parent_id, created_at, id);
+
+CREATE TABLE IF NOT EXISTS comment_edits (
+    id         UUID PRIMARY KEY,
+    comment_id UUID        NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    body       TEXT        NOT NULL,
+    edited_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS comment_edits_comment_idx ON comment_edits (comment_id, edited_at DESC);
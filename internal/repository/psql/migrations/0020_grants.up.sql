@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS grants (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    todo_id    UUID        REFERENCES todos (id) ON DELETE CASCADE,
+    project_id UUID        REFERENCES projects (id) ON DELETE CASCADE,
+    role       VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    granted_by UUID        REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((todo_id IS NULL) <> (project_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS grants_todo_idx ON grants (todo_id, user_id) WHERE todo_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS grants_project_idx ON grants (project_id, user_id) WHERE project_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS grants_user_idx ON grants (user_id);
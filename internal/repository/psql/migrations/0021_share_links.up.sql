@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS share_links (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    todo_id    UUID        REFERENCES todos (id) ON DELETE CASCADE,
+    filter     JSONB,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((todo_id IS NULL) <> (filter IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS share_links_user_idx ON share_links (user_id, created_at DESC);
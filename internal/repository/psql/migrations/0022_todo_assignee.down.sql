@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todos_assignee_idx;
+
+ALTER TABLE todos DROP COLUMN IF EXISTS assignee_id;
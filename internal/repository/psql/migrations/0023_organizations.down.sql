@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS todos_org_user_idx;
+DROP INDEX IF EXISTS projects_org_user_idx;
+
+ALTER TABLE share_links DROP COLUMN IF EXISTS org_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS org_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS memberships;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id         UUID PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS memberships (
+    org_id     UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS memberships_user_idx ON memberships (user_id, created_at);
+
+INSERT INTO organizations (id, name, created_at)
+SELECT id, name, created_at FROM users
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO memberships (org_id, user_id, role, created_at)
+SELECT id, id, 'owner', created_at FROM users
+ON CONFLICT (org_id, user_id) DO NOTHING;
+
+INSERT INTO memberships (org_id, user_id, role)
+SELECT t.user_id, g.user_id, 'member' FROM grants g JOIN todos t ON t.id = g.todo_id
+UNION
+SELECT p.user_id, g.user_id, 'member' FROM grants g JOIN projects p ON p.id = g.project_id
+UNION
+SELECT user_id, assignee_id, 'member' FROM todos WHERE assignee_id IS NOT NULL
+ON CONFLICT (org_id, user_id) DO NOTHING;
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+UPDATE projects SET org_id = user_id WHERE org_id IS NULL;
+ALTER TABLE projects ALTER COLUMN org_id SET NOT NULL;
+
+ALTER TABLE todos ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+UPDATE todos SET org_id = user_id WHERE org_id IS NULL;
+ALTER TABLE todos ALTER COLUMN org_id SET NOT NULL;
+
+ALTER TABLE share_links ADD COLUMN IF NOT EXISTS org_id UUID REFERENCES organizations (id) ON DELETE CASCADE;
+UPDATE share_links SET org_id = user_id WHERE org_id IS NULL;
+ALTER TABLE share_links ALTER COLUMN org_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS projects_org_user_idx ON projects (org_id, user_id);
+CREATE INDEX IF NOT EXISTS todos_org_user_idx ON todos (org_id, user_id) WHERE deleted_at IS NULL;
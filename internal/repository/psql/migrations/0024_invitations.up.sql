@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS invitations (
+    id          UUID PRIMARY KEY,
+    org_id      UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    email       TEXT        NOT NULL,
+    role        VARCHAR(16) NOT NULL CHECK (role IN ('admin', 'member')),
+    token_hash  TEXT        NOT NULL UNIQUE,
+    invited_by  UUID        REFERENCES users (id) ON DELETE SET NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS invitations_org_idx ON invitations (org_id, created_at DESC);
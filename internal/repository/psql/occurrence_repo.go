@@ -0,0 +1,97 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+type OccurrenceRepository interface {
+	Create(ctx context.Context, occurrence *entity.TodoOccurrence) error
+	GetBySeriesID(ctx context.Context, seriesID, userID uuid.UUID) ([]*entity.TodoOccurrence, error)
+}
+
+type occurrenceRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewOccurrenceRepository(db *Postgres, logger *logger.Logger) OccurrenceRepository {
+	qb := NewQueryBuilder()
+
+	return &occurrenceRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (or *occurrenceRepository) Create(ctx context.Context, occurrence *entity.TodoOccurrence) error {
+	sql, args, err := or.qb.Builder.Insert("todo_occurrences").
+		Columns("id", "series_id", "user_id", "todo_id", "next_todo_id", "content", "due_at").
+		Values(occurrence.ID, occurrence.SeriesID, occurrence.UserID, occurrence.TodoID, occurrence.NextTodoID,
+			occurrence.Content, occurrence.DueAt).
+		Suffix("RETURNING completed_at").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for create occurrence",
+			"operation", "create occurrence",
+			"series_id", occurrence.SeriesID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = or.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&occurrence.CompletedAt)
+	if err != nil {
+		or.logger.Logger.Error("failed to create occurrence",
+			"operation", "create occurrence",
+			"series_id", occurrence.SeriesID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert occurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (or *occurrenceRepository) GetBySeriesID(ctx context.Context, seriesID, userID uuid.UUID) ([]*entity.TodoOccurrence, error) {
+	sql, args, err := or.qb.Builder.
+		Select("id, series_id, user_id, todo_id, next_todo_id, content, due_at, completed_at").
+		From("todo_occurrences").Where(squirrel.Eq{"series_id": seriesID}).
+		Where(squirrel.Eq{"user_id": userID}).OrderBy("completed_at DESC").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get occurrences",
+			"operation", "get occurrences",
+			"user_id", userID.String(),
+			"series_id", seriesID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	occurrences := make([]*entity.TodoOccurrence, 0)
+	if err := or.db.conn(ctx).SelectContext(ctx, &occurrences, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get occurrences",
+			"operation", "get occurrences",
+			"user_id", userID.String(),
+			"series_id", seriesID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select occurrences: %w", err)
+	}
+
+	return occurrences, nil
+}
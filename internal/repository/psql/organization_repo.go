@@ -0,0 +1,363 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const membershipColumns string = "org_id, user_id, role, created_at"
+
+type MemberRole string
+
+const (
+	MemberOwner  MemberRole = "owner"
+	MemberAdmin  MemberRole = "admin"
+	MemberMember MemberRole = "member"
+)
+
+func ValidMemberRole(role string) bool {
+	switch MemberRole(role) {
+	case MemberOwner, MemberAdmin, MemberMember:
+		return true
+	}
+
+	return false
+}
+
+func (r MemberRole) CanManage() bool {
+	return r == MemberOwner || r == MemberAdmin
+}
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *entity.Organization) error
+	GetByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error)
+	AddMember(ctx context.Context, membership *entity.Membership) error
+	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error)
+	GetDefaultMembership(ctx context.Context, userID uuid.UUID) (*entity.Membership, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.MemberOrganization, error)
+	GetMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.Member, error)
+	GetMembershipsForUpdate(ctx context.Context, orgID uuid.UUID) ([]*entity.Membership, error)
+	UpdateRole(ctx context.Context, orgID, userID uuid.UUID, role string) error
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+}
+
+type organizationRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewOrganizationRepository(db *Postgres, logger *logger.Logger) OrganizationRepository {
+	qb := NewQueryBuilder()
+
+	return &organizationRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (or *organizationRepository) Create(ctx context.Context, organization *entity.Organization) error {
+	sql, args, err := or.qb.Builder.Insert("organizations").Columns("id", "name").
+		Values(organization.ID, organization.Name).Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for create organization",
+			"operation", "create organization",
+			"org_id", organization.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if err := or.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&organization.CreatedAt); err != nil {
+		or.logger.Logger.Error("failed to create organization",
+			"operation", "create organization",
+			"org_id", organization.ID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert organization: %w", err)
+	}
+
+	return nil
+}
+
+func (or *organizationRepository) GetByID(ctx context.Context, orgID uuid.UUID) (*entity.Organization, error) {
+	sql, args, err := or.qb.Builder.Select("id", "name", "created_at").From("organizations").
+		Where(squirrel.Eq{"id": orgID}).ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get organization",
+			"operation", "get organization",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var organization entity.Organization
+	if err := or.db.conn(ctx).GetContext(ctx, &organization, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get organization",
+			"operation", "get organization",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select organization: %w", err)
+	}
+
+	return &organization, nil
+}
+
+func (or *organizationRepository) AddMember(ctx context.Context, membership *entity.Membership) error {
+	sql, args, err := or.qb.Builder.Insert("memberships").Columns("org_id", "user_id", "role").
+		Values(membership.OrgID, membership.UserID, membership.Role).Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for add member",
+			"operation", "add member",
+			"org_id", membership.OrgID.String(),
+			"user_id", membership.UserID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if err := or.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&membership.CreatedAt); err != nil {
+		or.logger.Logger.Error("failed to add member",
+			"operation", "add member",
+			"org_id", membership.OrgID.String(),
+			"user_id", membership.UserID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert membership: %w", err)
+	}
+
+	return nil
+}
+
+func (or *organizationRepository) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (*entity.Membership, error) {
+	sql, args, err := or.qb.Builder.Select(membershipColumns).From("memberships").
+		Where(squirrel.Eq{"org_id": orgID}).Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get membership",
+			"operation", "get membership",
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var membership entity.Membership
+	if err := or.db.conn(ctx).GetContext(ctx, &membership, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get membership",
+			"operation", "get membership",
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select membership: %w", err)
+	}
+
+	return &membership, nil
+}
+
+func (or *organizationRepository) GetDefaultMembership(ctx context.Context, userID uuid.UUID) (*entity.Membership, error) {
+	sql, args, err := or.qb.Builder.Select(membershipColumns).From("memberships").
+		Where(squirrel.Eq{"user_id": userID}).OrderBy("created_at", "org_id").Limit(1).ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get default membership",
+			"operation", "get default membership",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var membership entity.Membership
+	if err := or.db.conn(ctx).GetContext(ctx, &membership, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get default membership",
+			"operation", "get default membership",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select default membership: %w", err)
+	}
+
+	return &membership, nil
+}
+
+func (or *organizationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.MemberOrganization, error) {
+	sql, args, err := or.qb.Builder.Select("m.org_id", "o.name", "m.role", "m.created_at").
+		From("memberships m").Join("organizations o ON o.id = m.org_id").
+		Where(squirrel.Eq{"m.user_id": userID}).OrderBy("m.created_at", "m.org_id").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get organizations",
+			"operation", "get organizations",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	organizations := make([]*entity.MemberOrganization, 0)
+	if err := or.db.conn(ctx).SelectContext(ctx, &organizations, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get organizations",
+			"operation", "get organizations",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (or *organizationRepository) GetMembers(ctx context.Context, orgID uuid.UUID) ([]*entity.Member, error) {
+	sql, args, err := or.qb.Builder.Select("m.user_id", "u.name", "u.email", "m.role", "m.created_at").
+		From("memberships m").Join("users u ON u.id = m.user_id").
+		Where(squirrel.Eq{"m.org_id": orgID}).OrderBy("m.created_at", "m.user_id").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for get members",
+			"operation", "get members",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	members := make([]*entity.Member, 0)
+	if err := or.db.conn(ctx).SelectContext(ctx, &members, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to get members",
+			"operation", "get members",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (or *organizationRepository) GetMembershipsForUpdate(ctx context.Context,
+	orgID uuid.UUID) ([]*entity.Membership, error) {
+	sql, args, err := or.qb.Builder.Select(membershipColumns).From("memberships").
+		Where(squirrel.Eq{"org_id": orgID}).OrderBy("user_id").Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for lock memberships",
+			"operation", "lock memberships",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	memberships := make([]*entity.Membership, 0)
+	if err := or.db.conn(ctx).SelectContext(ctx, &memberships, sql, args...); err != nil {
+		or.logger.Logger.Error("failed to lock memberships",
+			"operation", "lock memberships",
+			"org_id", orgID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock memberships: %w", err)
+	}
+
+	return memberships, nil
+}
+
+func (or *organizationRepository) UpdateRole(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+	sql, args, err := or.qb.Builder.Update("memberships").Set("role", role).
+		Where(squirrel.Eq{"org_id": orgID}).Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for update member role",
+			"operation", "update member role",
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return or.execMembership(ctx, "update member role", sql, args, orgID, userID)
+}
+
+func (or *organizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	sql, args, err := or.qb.Builder.Delete("memberships").
+		Where(squirrel.Eq{"org_id": orgID}).Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		or.logger.Logger.Error("failed to build query for remove member",
+			"operation", "remove member",
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return or.execMembership(ctx, "remove member", sql, args, orgID, userID)
+}
+
+func (or *organizationRepository) execMembership(ctx context.Context, operation, sql string, args []interface{},
+	orgID, userID uuid.UUID) error {
+	result, err := or.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		or.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		or.logger.Logger.Error("failed to get affected from "+operation,
+			"operation", operation,
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		or.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"org_id", orgID.String(),
+			"user_id", userID.String(),
+			"error", errors.New("membership not found").Error(),
+		)
+
+		return errors.New("membership not found")
+	}
+
+	return nil
+}
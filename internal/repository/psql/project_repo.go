@@ -0,0 +1,219 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const projectColumns string = "id, user_id, name, color, archived, position, created_at, updated_at"
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *entity.Project) error
+	GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*entity.Project, error)
+	GetByID(ctx context.Context, projectID, userID uuid.UUID) (*entity.Project, error)
+	Update(ctx context.Context, project *entity.Project) error
+	SetArchived(ctx context.Context, archived bool, projectID, userID uuid.UUID) error
+	Delete(ctx context.Context, projectID, userID uuid.UUID) error
+}
+
+type projectRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewProjectRepository(db *Postgres, logger *logger.Logger) ProjectRepository {
+	qb := NewQueryBuilder()
+
+	return &projectRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (pr *projectRepository) Create(ctx context.Context, project *entity.Project) error {
+	sql, args, err := pr.qb.Builder.Insert("projects").Columns("id", "user_id", "org_id", "name", "color", "position").
+		Values(project.ID, project.UserID, organizationID(ctx), project.Name, project.Color, project.Position).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		pr.logger.Logger.Error("failed to build query for create project",
+			"operation", "create project",
+			"project_id", project.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = pr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&project.ID, &project.CreatedAt)
+	if err != nil {
+		pr.logger.Logger.Error("failed to create project",
+			"operation", "create project",
+			"project_id", project.ID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert project: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *projectRepository) GetByUserID(ctx context.Context, userID uuid.UUID, includeArchived bool) ([]*entity.Project, error) {
+	query := pr.qb.Builder.Select(projectColumns).From("projects").Where(squirrel.Eq{"user_id": userID}).
+		Where(inOrganization(ctx, "org_id"))
+	if !includeArchived {
+		query = query.Where(squirrel.Eq{"archived": false})
+	}
+
+	sql, args, err := query.OrderBy("position", "name").ToSql()
+	if err != nil {
+		pr.logger.Logger.Error("failed to build query for get projects",
+			"operation", "get projects",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	projects := make([]*entity.Project, 0)
+	if err := pr.db.conn(ctx).SelectContext(ctx, &projects, sql, args...); err != nil {
+		pr.logger.Logger.Error("failed to get projects",
+			"operation", "get projects",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select projects: %w", err)
+	}
+
+	return projects, nil
+}
+
+func (pr *projectRepository) GetByID(ctx context.Context, projectID, userID uuid.UUID) (*entity.Project, error) {
+	sql, args, err := pr.qb.Builder.Select(projectColumns).From("projects").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"id": projectID}).
+		Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		pr.logger.Logger.Error("failed to build query for get project",
+			"operation", "get project",
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var project entity.Project
+	if err := pr.db.conn(ctx).GetContext(ctx, &project, sql, args...); err != nil {
+		pr.logger.Logger.Error("failed to get project",
+			"operation", "get project",
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select project: %w", err)
+	}
+
+	return &project, nil
+}
+
+func (pr *projectRepository) Update(ctx context.Context, project *entity.Project) error {
+	query := pr.qb.Builder.Update("projects").Set("name", project.Name).Set("color", project.Color).
+		Set("position", project.Position).Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": project.ID}).Where(squirrel.Eq{"user_id": project.UserID})
+
+	return pr.execProjectUpdate(ctx, "update project", query, project.ID, project.UserID)
+}
+
+func (pr *projectRepository) SetArchived(ctx context.Context, archived bool, projectID, userID uuid.UUID) error {
+	query := pr.qb.Builder.Update("projects").Set("archived", archived).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": projectID}).Where(squirrel.Eq{"user_id": userID})
+
+	return pr.execProjectUpdate(ctx, "archive project", query, projectID, userID)
+}
+
+func (pr *projectRepository) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
+	sql, args, err := pr.qb.Builder.Delete("projects").Where(squirrel.Eq{"id": projectID}).
+		Where(squirrel.Eq{"user_id": userID}).Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		pr.logger.Logger.Error("failed to build query for delete project",
+			"operation", "delete project",
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return pr.execAffected(ctx, "delete project", sql, args, projectID, userID)
+}
+
+func (pr *projectRepository) execProjectUpdate(ctx context.Context, operation string, query squirrel.UpdateBuilder,
+	projectID, userID uuid.UUID) error {
+	sql, args, err := query.Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		pr.logger.Logger.Error("failed to build query for "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	return pr.execAffected(ctx, operation, sql, args, projectID, userID)
+}
+
+func (pr *projectRepository) execAffected(ctx context.Context, operation, sql string, args []interface{},
+	projectID, userID uuid.UUID) error {
+	result, err := pr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		pr.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		pr.logger.Logger.Error("failed to get affected from "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		pr.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", errors.New("project not found").Error(),
+		)
+
+		return errors.New("project not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const revisionColumns string = "r.id, r.todo_id, r.actor_id, r.field, r.old_value, r.new_value, r.created_at"
+
+type RevisionRepository interface {
+	Create(ctx context.Context, revision *entity.TodoRevision) error
+	GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.TodoRevision, error)
+	GetByID(ctx context.Context, revisionID, todoID, userID uuid.UUID) (*entity.TodoRevision, error)
+}
+
+type revisionRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewRevisionRepository(db *Postgres, logger *logger.Logger) RevisionRepository {
+	qb := NewQueryBuilder()
+
+	return &revisionRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (rr *revisionRepository) Create(ctx context.Context, revision *entity.TodoRevision) error {
+	sql, args, err := rr.qb.Builder.Insert("todo_revisions").
+		Columns("id", "todo_id", "actor_id", "field", "old_value", "new_value").
+		Values(revision.ID, revision.TodoID, revision.ActorID, revision.Field, revision.OldValue, revision.NewValue).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		rr.logger.Logger.Error("failed to build query for create revision",
+			"operation", "create revision",
+			"todo_id", revision.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = rr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&revision.CreatedAt)
+	if err != nil {
+		rr.logger.Logger.Error("failed to create revision",
+			"operation", "create revision",
+			"todo_id", revision.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert revision: %w", err)
+	}
+
+	return nil
+}
+
+func (rr *revisionRepository) GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.TodoRevision, error) {
+	sql, args, err := rr.qb.Builder.Select(revisionColumns).From("todo_revisions r").
+		Join("todos t ON t.id = r.todo_id").
+		Where(squirrel.Eq{"r.todo_id": todoID}).Where(squirrel.Eq{"t.user_id": userID}).
+		OrderBy("r.created_at DESC", "r.id").ToSql()
+	if err != nil {
+		rr.logger.Logger.Error("failed to build query for get revisions",
+			"operation", "get revisions",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	revisions := make([]*entity.TodoRevision, 0)
+	if err := rr.db.conn(ctx).SelectContext(ctx, &revisions, sql, args...); err != nil {
+		rr.logger.Logger.Error("failed to get revisions",
+			"operation", "get revisions",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (rr *revisionRepository) GetByID(ctx context.Context, revisionID, todoID, userID uuid.UUID) (*entity.TodoRevision, error) {
+	sql, args, err := rr.qb.Builder.Select(revisionColumns).From("todo_revisions r").
+		Join("todos t ON t.id = r.todo_id").
+		Where(squirrel.Eq{"r.id": revisionID}).Where(squirrel.Eq{"r.todo_id": todoID}).
+		Where(squirrel.Eq{"t.user_id": userID}).ToSql()
+	if err != nil {
+		rr.logger.Logger.Error("failed to build query for get revision",
+			"operation", "get revision",
+			"user_id", userID.String(),
+			"revision_id", revisionID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var revision entity.TodoRevision
+	if err := rr.db.conn(ctx).GetContext(ctx, &revision, sql, args...); err != nil {
+		rr.logger.Logger.Error("failed to get revision",
+			"operation", "get revision",
+			"user_id", userID.String(),
+			"revision_id", revisionID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select revision: %w", err)
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,23 @@
+package psql
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	searchHeadlineOptions string  = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+	similarityThreshold   float64 = 0.3
+)
+
+func toPrefixTSQuery(query string) string {
+	terms := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+
+	return strings.Join(terms, " & ")
+}
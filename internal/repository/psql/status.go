@@ -3,7 +3,7 @@ package psql
 type TodoStatus string
 
 const (
-	Todo      TodoStatus = "todo"
-	Processed TodoStatus = "processed"
-	Done      TodoStatus = "done"
+	Todo    TodoStatus = "todo"
+	Process TodoStatus = "process"
+	Done    TodoStatus = "done"
 )
@@ -0,0 +1,237 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const templateColumns string = "id, user_id, name, description, items, created_at, updated_at"
+
+type TemplateRepository interface {
+	Create(ctx context.Context, template *entity.TodoTemplate) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.TodoTemplate, error)
+	GetByID(ctx context.Context, templateID, userID uuid.UUID) (*entity.TodoTemplate, error)
+	Update(ctx context.Context, template *entity.TodoTemplate) error
+	Delete(ctx context.Context, templateID, userID uuid.UUID) error
+}
+
+type templateRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewTemplateRepository(db *Postgres, logger *logger.Logger) TemplateRepository {
+	qb := NewQueryBuilder()
+
+	return &templateRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (tr *templateRepository) Create(ctx context.Context, template *entity.TodoTemplate) error {
+	sql, args, err := tr.qb.Builder.Insert("todo_templates").
+		Columns("id", "user_id", "name", "description", "items").
+		Values(template.ID, template.UserID, template.Name, template.Description, template.Items).
+		Suffix("RETURNING created_at, updated_at").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for create template",
+			"operation", "create template",
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = tr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		tr.logger.Logger.Error("failed to create template",
+			"operation", "create template",
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert template: %w", err)
+	}
+
+	return nil
+}
+
+func (tr *templateRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.TodoTemplate, error) {
+	sql, args, err := tr.qb.Builder.Select(templateColumns).From("todo_templates").
+		Where(squirrel.Eq{"user_id": userID}).OrderBy("name").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get templates",
+			"operation", "get templates",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	templates := make([]*entity.TodoTemplate, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &templates, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get templates",
+			"operation", "get templates",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (tr *templateRepository) GetByID(ctx context.Context, templateID, userID uuid.UUID) (*entity.TodoTemplate, error) {
+	sql, args, err := tr.qb.Builder.Select(templateColumns).From("todo_templates").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"id": templateID}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get template",
+			"operation", "get template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var template entity.TodoTemplate
+	if err := tr.db.conn(ctx).GetContext(ctx, &template, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get template",
+			"operation", "get template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select template: %w", err)
+	}
+
+	return &template, nil
+}
+
+func (tr *templateRepository) Update(ctx context.Context, template *entity.TodoTemplate) error {
+	sql, args, err := tr.qb.Builder.Update("todo_templates").Set("name", template.Name).
+		Set("description", template.Description).Set("items", template.Items).
+		Set("updated_at", squirrel.Expr("now()")).Where(squirrel.Eq{"id": template.ID}).
+		Where(squirrel.Eq{"user_id": template.UserID}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for update template",
+			"operation", "update template",
+			"user_id", template.UserID.String(),
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		tr.logger.Logger.Error("failed to update template",
+			"operation", "update template",
+			"user_id", template.UserID.String(),
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("update template: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tr.logger.Logger.Error("failed to get affected from update template",
+			"operation", "update template",
+			"user_id", template.UserID.String(),
+			"template_id", template.ID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		tr.logger.Logger.Error("failed to update template",
+			"operation", "update template",
+			"user_id", template.UserID.String(),
+			"template_id", template.ID.String(),
+			"error", errors.New("template not found").Error(),
+		)
+
+		return errors.New("template not found")
+	}
+
+	return nil
+}
+
+func (tr *templateRepository) Delete(ctx context.Context, templateID, userID uuid.UUID) error {
+	sql, args, err := tr.qb.Builder.Delete("todo_templates").Where(squirrel.Eq{"id": templateID}).
+		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for delete template",
+			"operation", "delete template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		tr.logger.Logger.Error("failed to delete template",
+			"operation", "delete template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("delete template: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tr.logger.Logger.Error("failed to get affected from delete template",
+			"operation", "delete template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		tr.logger.Logger.Error("failed to delete template",
+			"operation", "delete template",
+			"user_id", userID.String(),
+			"template_id", templateID.String(),
+			"error", errors.New("template not found").Error(),
+		)
+
+		return errors.New("template not found")
+	}
+
+	return nil
+}
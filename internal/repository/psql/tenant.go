@@ -0,0 +1,28 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func organizationID(ctx context.Context) *uuid.UUID {
+	raw, _ := ctx.Value("orgID").(string)
+
+	orgID, err := uuid.Parse(raw)
+	if err != nil {
+		return nil
+	}
+
+	return &orgID
+}
+
+func inOrganization(ctx context.Context, column string) squirrel.Sqlizer {
+	orgID := organizationID(ctx)
+	if orgID == nil {
+		return squirrel.Expr("FALSE")
+	}
+
+	return squirrel.Eq{column: *orgID}
+}
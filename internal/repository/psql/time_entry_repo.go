@@ -0,0 +1,221 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const (
+	timeEntryColumns string = "id, user_id, todo_id, started_at, stopped_at, note, created_at"
+	timeSecondsExpr  string = "CAST(sum(extract(epoch FROM COALESCE(e.stopped_at, now()) - e.started_at)) AS bigint) AS seconds"
+)
+
+type TimeGroup string
+
+const (
+	GroupByTodo TimeGroup = "todo"
+	GroupByDay  TimeGroup = "day"
+	GroupByWeek TimeGroup = "week"
+)
+
+type TimeTotalsFilter struct {
+	GroupBy TimeGroup
+	From    *time.Time
+	To      *time.Time
+}
+
+type TimeEntryRepository interface {
+	Create(ctx context.Context, entry *entity.TimeEntry) error
+	GetRunning(ctx context.Context, userID uuid.UUID) (*entity.TimeEntry, error)
+	Stop(ctx context.Context, userID uuid.UUID) (*entity.TimeEntry, error)
+	GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.TimeEntry, error)
+	GetTotals(ctx context.Context, userID uuid.UUID, filter *TimeTotalsFilter) ([]*entity.TimeTotal, error)
+}
+
+type timeEntryRepository struct {
+	db     *Postgres
+	qb     *builder
+	logger *logger.Logger
+}
+
+func NewTimeEntryRepository(db *Postgres, logger *logger.Logger) TimeEntryRepository {
+	qb := NewQueryBuilder()
+
+	return &timeEntryRepository{
+		db:     db,
+		qb:     qb,
+		logger: logger,
+	}
+}
+
+func (er *timeEntryRepository) Create(ctx context.Context, entry *entity.TimeEntry) error {
+	sql, args, err := er.qb.Builder.Insert("time_entries").
+		Columns("id", "user_id", "todo_id", "started_at", "stopped_at", "note").
+		Values(entry.ID, entry.UserID, entry.TodoID, entry.StartedAt, entry.StoppedAt, entry.Note).
+		Suffix("RETURNING created_at").ToSql()
+	if err != nil {
+		er.logger.Logger.Error("failed to build query for create time entry",
+			"operation", "create time entry",
+			"user_id", entry.UserID.String(),
+			"todo_id", entry.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	err = er.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&entry.CreatedAt)
+	if err != nil {
+		er.logger.Logger.Error("failed to create time entry",
+			"operation", "create time entry",
+			"user_id", entry.UserID.String(),
+			"todo_id", entry.TodoID.String(),
+			"error", err.Error(),
+		)
+
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+
+		return fmt.Errorf("insert time entry: %w", err)
+	}
+
+	return nil
+}
+
+func (er *timeEntryRepository) GetRunning(ctx context.Context, userID uuid.UUID) (*entity.TimeEntry, error) {
+	sql, args, err := er.qb.Builder.Select(timeEntryColumns).From("time_entries").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"stopped_at": nil}).ToSql()
+	if err != nil {
+		er.logger.Logger.Error("failed to build query for get running time entry",
+			"operation", "get running time entry",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var entry entity.TimeEntry
+	if err := er.db.conn(ctx).GetContext(ctx, &entry, sql, args...); err != nil {
+		er.logger.Logger.Error("failed to get running time entry",
+			"operation", "get running time entry",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select running time entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func (er *timeEntryRepository) Stop(ctx context.Context, userID uuid.UUID) (*entity.TimeEntry, error) {
+	sql, args, err := er.qb.Builder.Update("time_entries").
+		Set("stopped_at", squirrel.Expr("GREATEST(now(), started_at)")).Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"stopped_at": nil}).Suffix("RETURNING " + timeEntryColumns).ToSql()
+	if err != nil {
+		er.logger.Logger.Error("failed to build query for stop time entry",
+			"operation", "stop time entry",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var entry entity.TimeEntry
+	if err := er.db.conn(ctx).GetContext(ctx, &entry, sql, args...); err != nil {
+		er.logger.Logger.Error("failed to stop time entry",
+			"operation", "stop time entry",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("stop time entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+func (er *timeEntryRepository) GetByTodoID(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.TimeEntry, error) {
+	sql, args, err := er.qb.Builder.Select(timeEntryColumns).From("time_entries").
+		Where(squirrel.Eq{"todo_id": todoID}).Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("started_at DESC", "id").ToSql()
+	if err != nil {
+		er.logger.Logger.Error("failed to build query for get time entries",
+			"operation", "get time entries",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	entries := make([]*entity.TimeEntry, 0)
+	if err := er.db.conn(ctx).SelectContext(ctx, &entries, sql, args...); err != nil {
+		er.logger.Logger.Error("failed to get time entries",
+			"operation", "get time entries",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select time entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (er *timeEntryRepository) GetTotals(ctx context.Context, userID uuid.UUID,
+	filter *TimeTotalsFilter) ([]*entity.TimeTotal, error) {
+	query := er.qb.Builder.Select().From("time_entries e").Join("todos t ON t.id = e.todo_id").
+		Where(squirrel.Eq{"e.user_id": userID}).Where(squirrel.Eq{"t.deleted_at": nil})
+
+	switch filter.GroupBy {
+	case GroupByDay, GroupByWeek:
+		period := fmt.Sprintf("date_trunc('%s', e.started_at AT TIME ZONE 'UTC') AS period", filter.GroupBy)
+		query = query.Columns(period, timeSecondsExpr).GroupBy("period").OrderBy("period")
+	default:
+		query = query.Columns("e.todo_id", timeSecondsExpr).GroupBy("e.todo_id").OrderBy("seconds DESC", "e.todo_id")
+	}
+
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{"e.started_at": *filter.From})
+	}
+
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{"e.started_at": *filter.To})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		er.logger.Logger.Error("failed to build query for get time totals",
+			"operation", "get time totals",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	totals := make([]*entity.TimeTotal, 0)
+	if err := er.db.conn(ctx).SelectContext(ctx, &totals, sql, args...); err != nil {
+		er.logger.Logger.Error("failed to get time totals",
+			"operation", "get time totals",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select time totals: %w", err)
+	}
+
+	return totals, nil
+}
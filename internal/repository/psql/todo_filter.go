@@ -0,0 +1,187 @@
+package psql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/lib/pq"
+)
+
+type TodoSortField string
+
+const (
+	SortByCreatedAt TodoSortField = "created_at"
+	SortByUpdatedAt TodoSortField = "updated_at"
+	SortByContent   TodoSortField = "content"
+	SortByStatus    TodoSortField = "status"
+	SortByPosition  TodoSortField = "position"
+)
+
+var todoSortCasts = map[TodoSortField]string{
+	SortByCreatedAt: "timestamptz",
+	SortByUpdatedAt: "timestamptz",
+	SortByContent:   "text",
+	SortByStatus:    "text",
+	SortByPosition:  "text",
+}
+
+type TodoFilter struct {
+	ProjectID       *uuid.UUID
+	IncludeArchived bool
+	Statuses        []string
+	CreatedAfter    *time.Time
+	CreatedBefore   *time.Time
+	UpdatedAfter    *time.Time
+	UpdatedBefore   *time.Time
+	DueAfter        *time.Time
+	DueBefore       *time.Time
+	Overdue         bool
+	LabelIDs        []uuid.UUID
+	AllLabels       bool
+	SortBy          TodoSortField
+	Descending      bool
+	Cursor          *TodoCursor
+	Limit           uint64
+}
+
+type TodoCursor struct {
+	SortBy TodoSortField `json:"s"`
+	Value  string        `json:"v"`
+	ID     uuid.UUID     `json:"id"`
+}
+
+func ValidTodoSortField(field TodoSortField) bool {
+	_, ok := todoSortCasts[field]
+
+	return ok
+}
+
+func EncodeTodoCursor(todo *entity.Todo, sortBy TodoSortField) string {
+	cursor := TodoCursor{SortBy: sortBy, ID: todo.ID}
+
+	switch sortBy {
+	case SortByUpdatedAt:
+		cursor.Value = todo.UpdatedAt.Format(time.RFC3339Nano)
+	case SortByContent:
+		cursor.Value = todo.Content
+	case SortByStatus:
+		cursor.Value = todo.Status
+	case SortByPosition:
+		cursor.Value = todo.Position
+	default:
+		cursor.Value = todo.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeTodoCursor(encoded string) (*TodoCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor TodoCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if !ValidTodoSortField(cursor.SortBy) || cursor.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func (f *TodoFilter) apply(query squirrel.SelectBuilder, terminal []TodoStatus) squirrel.SelectBuilder {
+	if f.ProjectID != nil {
+		query = query.Where(squirrel.Eq{"project_id": *f.ProjectID})
+	}
+
+	if !f.IncludeArchived {
+		query = query.Where(squirrel.Eq{"archived": false})
+	}
+
+	if len(f.Statuses) > 0 {
+		query = query.Where(squirrel.Eq{"status": f.Statuses})
+	}
+
+	if f.CreatedAfter != nil {
+		query = query.Where(squirrel.GtOrEq{"created_at": *f.CreatedAfter})
+	}
+
+	if f.CreatedBefore != nil {
+		query = query.Where(squirrel.Lt{"created_at": *f.CreatedBefore})
+	}
+
+	if f.UpdatedAfter != nil {
+		query = query.Where(squirrel.GtOrEq{"updated_at": *f.UpdatedAfter})
+	}
+
+	if f.UpdatedBefore != nil {
+		query = query.Where(squirrel.Lt{"updated_at": *f.UpdatedBefore})
+	}
+
+	if f.DueAfter != nil {
+		query = query.Where(squirrel.GtOrEq{"due_at": *f.DueAfter})
+	}
+
+	if f.DueBefore != nil {
+		query = query.Where(squirrel.Lt{"due_at": *f.DueBefore})
+	}
+
+	if f.Overdue {
+		query = query.Where("due_at < now()").Where(squirrel.NotEq{"status": terminal})
+	}
+
+	if len(f.LabelIDs) > 0 {
+		labelIDs := make([]string, 0, len(f.LabelIDs))
+		for _, labelID := range f.LabelIDs {
+			labelIDs = append(labelIDs, labelID.String())
+		}
+
+		if f.AllLabels {
+			query = query.Where(squirrel.Expr(
+				"(SELECT count(*) FROM todo_labels tl WHERE tl.todo_id = todos.id AND tl.label_id = ANY(?::uuid[])) = ?",
+				pq.Array(labelIDs), len(labelIDs),
+			))
+		} else {
+			query = query.Where(squirrel.Expr(
+				"EXISTS (SELECT 1 FROM todo_labels tl WHERE tl.todo_id = todos.id AND tl.label_id = ANY(?::uuid[]))",
+				pq.Array(labelIDs),
+			))
+		}
+	}
+
+	sortBy := f.SortBy
+	if !ValidTodoSortField(sortBy) {
+		sortBy = SortByCreatedAt
+	}
+
+	direction, comparison := "ASC", ">"
+	if f.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if f.Cursor != nil {
+		query = query.Where(squirrel.Expr(
+			fmt.Sprintf("(%s, id) %s (?::%s, ?::uuid)", sortBy, comparison, todoSortCasts[sortBy]),
+			f.Cursor.Value, f.Cursor.ID,
+		))
+	}
+
+	query = query.OrderBy(fmt.Sprintf("%s %s", sortBy, direction), fmt.Sprintf("id %s", direction))
+
+	if f.Limit > 0 {
+		query = query.Limit(f.Limit)
+	}
+
+	return query
+}
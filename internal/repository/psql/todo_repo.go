@@ -4,41 +4,100 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/identicalaffiliation/app/internal/logger"
 	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/lib/pq"
 )
 
+const (
+	todoColumns string = "id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, " +
+		"recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at"
+	maxTreeDepth int = 32
+)
+
+func (tr *todoRepository) progressColumns(table string) string {
+	terminal := make([]string, 0, len(tr.terminal))
+	for _, status := range tr.terminal {
+		terminal = append(terminal, pq.QuoteLiteral(string(status)))
+	}
+
+	return fmt.Sprintf("(SELECT count(*) FROM todos c WHERE c.parent_id = %[1]s.id AND c.deleted_at IS NULL) AS children_total, "+
+		"(SELECT count(*) FROM todos c WHERE c.parent_id = %[1]s.id AND c.deleted_at IS NULL "+
+		"AND c.status IN (%[2]s)) AS children_done",
+		table, strings.Join(terminal, ", "))
+}
+
 type TodoRepository interface {
 	Create(ctx context.Context, todo *entity.Todo) error
-	GetTodosByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error)
+	GetTodosByUserID(ctx context.Context, userID uuid.UUID, filter *TodoFilter) ([]*entity.Todo, error)
 	GetTodoByUserID(ctx context.Context, todoID, userID uuid.UUID) (*entity.Todo, error)
+	GetTodoForUpdate(ctx context.Context, todoID, userID uuid.UUID) (*entity.Todo, error)
+	GetShared(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error)
+	GetAssigned(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error)
+	CanAccess(ctx context.Context, todoID, userID uuid.UUID, role Role) (bool, error)
+	GetTree(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.Todo, error)
+	IsAncestor(ctx context.Context, ancestorID, todoID uuid.UUID) (bool, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, limit uint64) ([]*entity.TodoSearchResult, error)
 	UpdateStatus(ctx context.Context, newStatus TodoStatus, todoID, userID uuid.UUID) error
 	UpdateContent(ctx context.Context, newContent string, todoID, userID uuid.UUID) error
+	UpdateSchedule(ctx context.Context, dueAt, remindAt *time.Time, todoID, userID uuid.UUID) error
+	UpdateProject(ctx context.Context, projectID *uuid.UUID, todoID, userID uuid.UUID) error
+	UpdateAssignee(ctx context.Context, assigneeID *uuid.UUID, todoID, userID uuid.UUID) error
+	UpdateParent(ctx context.Context, parentID *uuid.UUID, todoID, userID uuid.UUID) error
+	UpdateRecurrence(ctx context.Context, todo *entity.Todo) error
+	SetArchivedByProject(ctx context.Context, archived bool, projectID, userID uuid.UUID) error
+	ClaimDueReminders(ctx context.Context, now time.Time, limit uint64) ([]*entity.Reminder, error)
+	ReleaseReminder(ctx context.Context, todoID uuid.UUID) error
+	SkipReminder(ctx context.Context, todoID uuid.UUID) error
+	GetTrash(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error)
+	Restore(ctx context.Context, todoID, userID uuid.UUID) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 	Delete(ctx context.Context, todoID, userID uuid.UUID) error
+	CreateBatch(ctx context.Context, todos []*entity.Todo) error
+	GetTodosForUpdate(ctx context.Context, todoIDs []uuid.UUID, userID uuid.UUID) ([]*entity.Todo, error)
+	UpdateContentBatch(ctx context.Context, todos []*entity.Todo, userID uuid.UUID) error
+	DeleteBatch(ctx context.Context, todoIDs []uuid.UUID, userID uuid.UUID) error
+	CountByStatus(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) (map[string]int, error)
+	GetLastPosition(ctx context.Context, userID uuid.UUID) (string, error)
+	GetNeighborPosition(ctx context.Context, position string, next bool, todoID, userID uuid.UUID) (string, error)
+	UpdatePosition(ctx context.Context, position string, todoID, userID uuid.UUID) error
+	GetUsersWithLongPositions(ctx context.Context, maxLength int, limit uint64) ([]uuid.UUID, error)
+	GetPositionsForUpdate(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error)
+	UpdatePositions(ctx context.Context, todos []*entity.Todo, userID uuid.UUID) error
 }
 
 type todoRepository struct {
-	db     *Postgres
-	qb     *builder
-	logger *logger.Logger
+	db       *Postgres
+	qb       *builder
+	logger   *logger.Logger
+	terminal []TodoStatus
 }
 
-func NewTodoRepository(db *Postgres, logger *logger.Logger) TodoRepository {
+func NewTodoRepository(db *Postgres, logger *logger.Logger, terminal ...TodoStatus) TodoRepository {
 	qb := NewQueryBuilder()
 
+	if len(terminal) == 0 {
+		terminal = []TodoStatus{Done}
+	}
+
 	return &todoRepository{
-		db:     db,
-		qb:     qb,
-		logger: logger,
+		db:       db,
+		qb:       qb,
+		logger:   logger,
+		terminal: terminal,
 	}
 }
 
 func (tr *todoRepository) Create(ctx context.Context, todo *entity.Todo) error {
-	sql, args, err := tr.qb.Builder.Insert("todos").Columns("id", "user_id", "content",
-		"status").Values(todo.ID, todo.UserID, todo.Content, todo.Status).
+	sql, args, err := tr.qb.Builder.Insert("todos").Columns("id", "user_id", "org_id", "project_id", "parent_id",
+		"content", "status", "due_at", "remind_at", "recurrence", "recurrence_start", "series_id", "position").
+		Values(todo.ID, todo.UserID, organizationID(ctx), todo.ProjectID, todo.ParentID, todo.Content, todo.Status,
+			todo.DueAt, todo.RemindAt, todo.Recurrence, todo.RecurrenceStart, todo.SeriesID, todo.Position).
 		Suffix("RETURNING id, created_at").ToSql()
 	if err != nil {
 		tr.logger.Logger.Error("failed to build query for create todo",
@@ -50,7 +109,7 @@ func (tr *todoRepository) Create(ctx context.Context, todo *entity.Todo) error {
 		return ErrFailBuildQuery
 	}
 
-	err = tr.db.DB.QueryRowxContext(ctx, sql, args...).Scan(&todo.ID, &todo.CreatedAt)
+	err = tr.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&todo.ID, &todo.CreatedAt)
 	if err != nil {
 		tr.logger.Logger.Error("failed to create todo",
 			"operation", "create todo",
@@ -64,9 +123,15 @@ func (tr *todoRepository) Create(ctx context.Context, todo *entity.Todo) error {
 	return nil
 }
 
-func (tr *todoRepository) GetTodosByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error) {
-	sql, args, err := tr.qb.Builder.Select("id, user_id, content, status, created_at, updated_at").
-		From("todos").Where(squirrel.Eq{"user_id": userID}).ToSql()
+func (tr *todoRepository) GetTodosByUserID(ctx context.Context, userID uuid.UUID, filter *TodoFilter) ([]*entity.Todo, error) {
+	query := tr.qb.Builder.Select(todoColumns, tr.progressColumns("todos")).
+		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id"))
+	if filter != nil {
+		query = filter.apply(query, tr.terminal)
+	}
+
+	sql, args, err := query.ToSql()
 	if err != nil {
 		tr.logger.Logger.Error("failed to build query for get todos",
 			"operation", "get todos",
@@ -78,7 +143,7 @@ func (tr *todoRepository) GetTodosByUserID(ctx context.Context, userID uuid.UUID
 	}
 
 	users := make([]*entity.Todo, 0)
-	if err := tr.db.DB.SelectContext(ctx, &users, sql, args...); err != nil {
+	if err := tr.db.conn(ctx).SelectContext(ctx, &users, sql, args...); err != nil {
 		tr.logger.Logger.Error("failed to get todos",
 			"operation", "get todos",
 			"user_id", userID.String(),
@@ -92,8 +157,10 @@ func (tr *todoRepository) GetTodosByUserID(ctx context.Context, userID uuid.UUID
 }
 
 func (tr *todoRepository) GetTodoByUserID(ctx context.Context, todoID, userID uuid.UUID) (*entity.Todo, error) {
-	sql, args, err := tr.qb.Builder.Select("id, user_id, content, status, created_at, updated_at").
-		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"id": todoID}).ToSql()
+	sql, args, err := tr.qb.Builder.Select(todoColumns, tr.progressColumns("todos")).
+		From("todos").Where(squirrel.Eq{"id": todoID}).Where(canAccess("todos", userID, RoleViewer)).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id")).ToSql()
 	if err != nil {
 		tr.logger.Logger.Error("failed to build query for get todo",
 			"operation", "get todo",
@@ -106,7 +173,7 @@ func (tr *todoRepository) GetTodoByUserID(ctx context.Context, todoID, userID uu
 	}
 
 	var todo entity.Todo
-	if err := tr.db.DB.GetContext(ctx, &todo, sql, args...); err != nil {
+	if err := tr.db.conn(ctx).GetContext(ctx, &todo, sql, args...); err != nil {
 		tr.logger.Logger.Error("failed to get todo",
 			"operation", "get todo",
 			"user_id", userID.String(),
@@ -120,9 +187,290 @@ func (tr *todoRepository) GetTodoByUserID(ctx context.Context, todoID, userID uu
 	return &todo, nil
 }
 
+func (tr *todoRepository) GetTodoForUpdate(ctx context.Context, todoID, userID uuid.UUID) (*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns).
+		From("todos").Where(squirrel.Eq{"id": todoID}).Where(canAccess("todos", userID, RoleEditor)).
+		Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for lock todo",
+			"operation", "lock todo",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var todo entity.Todo
+	if err := tr.db.conn(ctx).GetContext(ctx, &todo, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to lock todo",
+			"operation", "lock todo",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock todo: %w", err)
+	}
+
+	return &todo, nil
+}
+
+func (tr *todoRepository) GetShared(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns, tr.progressColumns("todos")).
+		From("todos").Where(squirrel.NotEq{"user_id": userID}).Where(canAccess("todos", userID, RoleViewer)).
+		Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		OrderBy("created_at DESC", "id").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get shared todos",
+			"operation", "get shared todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	todos := make([]*entity.Todo, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get shared todos",
+			"operation", "get shared todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select shared todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) GetAssigned(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns, tr.progressColumns("todos")).
+		From("todos").Where(squirrel.Eq{"assignee_id": userID}).Where(canAccess("todos", userID, RoleViewer)).
+		Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		OrderBy("due_at NULLS LAST", "created_at DESC", "id").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get assigned todos",
+			"operation", "get assigned todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	todos := make([]*entity.Todo, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get assigned todos",
+			"operation", "get assigned todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select assigned todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) CanAccess(ctx context.Context, todoID, userID uuid.UUID, role Role) (bool, error) {
+	sql, args, err := tr.qb.Builder.Select("1").From("todos").Where(squirrel.Eq{"id": todoID}).
+		Where(canAccess("todos", userID, role)).Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		Prefix("SELECT EXISTS (").Suffix(")").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for check todo access",
+			"operation", "check todo access",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return false, ErrFailBuildQuery
+	}
+
+	var allowed bool
+	if err := tr.db.conn(ctx).GetContext(ctx, &allowed, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to check todo access",
+			"operation", "check todo access",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return false, fmt.Errorf("check todo access: %w", err)
+	}
+
+	return allowed, nil
+}
+
+func (tr *todoRepository) GetTree(ctx context.Context, todoID, userID uuid.UUID) ([]*entity.Todo, error) {
+	childColumns := "t." + strings.ReplaceAll(todoColumns, ", ", ", t.")
+	rootAccess, rootArgs := accessCondition("todos", userID, RoleViewer)
+	childAccess, childArgs := accessCondition("t", userID, RoleViewer)
+	scope, scopeArgs, _ := inOrganization(ctx, "org_id").ToSql()
+	rootAccess += " AND " + scope
+	rootArgs = append(rootArgs, scopeArgs...)
+
+	tree := fmt.Sprintf("WITH RECURSIVE tree AS ("+
+		"SELECT %s, 0 AS depth FROM todos WHERE id = ? AND %s AND deleted_at IS NULL "+
+		"UNION ALL "+
+		"SELECT %s, tree.depth + 1 FROM todos t JOIN tree ON t.parent_id = tree.id "+
+		"WHERE %s AND t.deleted_at IS NULL AND tree.depth < ?)", todoColumns, rootAccess, childColumns, childAccess)
+
+	treeArgs := append([]interface{}{todoID}, rootArgs...)
+	treeArgs = append(append(treeArgs, childArgs...), maxTreeDepth)
+
+	sql, args, err := tr.qb.Builder.Select(todoColumns, tr.progressColumns("tree")).
+		Prefix(tree, treeArgs...).
+		From("tree").OrderBy("depth", "created_at", "id").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get todo tree",
+			"operation", "get todo tree",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	todos := make([]*entity.Todo, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get todo tree",
+			"operation", "get todo tree",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select todo tree: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) IsAncestor(ctx context.Context, ancestorID, todoID uuid.UUID) (bool, error) {
+	scope, scopeArgs, _ := inOrganization(ctx, "org_id").ToSql()
+	joinScope, joinScopeArgs, _ := inOrganization(ctx, "t.org_id").ToSql()
+
+	ancestors := "WITH RECURSIVE ancestors AS (" +
+		"SELECT id, parent_id FROM todos WHERE id = ? AND " + scope + " " +
+		"UNION " +
+		"SELECT t.id, t.parent_id FROM todos t JOIN ancestors a ON t.id = a.parent_id WHERE " + joinScope + ")"
+
+	prefixArgs := append(append([]interface{}{todoID}, scopeArgs...), joinScopeArgs...)
+
+	sql, args, err := tr.qb.Builder.Select("EXISTS (SELECT 1 FROM ancestors WHERE id = ?)").
+		Prefix(ancestors, prefixArgs...).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for check todo ancestors",
+			"operation", "check todo ancestors",
+			"todo_id", todoID.String(),
+			"ancestor_id", ancestorID.String(),
+			"error", err.Error(),
+		)
+
+		return false, ErrFailBuildQuery
+	}
+
+	args = append(args, ancestorID)
+
+	var found bool
+	if err := tr.db.conn(ctx).GetContext(ctx, &found, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to check todo ancestors",
+			"operation", "check todo ancestors",
+			"todo_id", todoID.String(),
+			"ancestor_id", ancestorID.String(),
+			"error", err.Error(),
+		)
+
+		return false, fmt.Errorf("select todo ancestors: %w", err)
+	}
+
+	return found, nil
+}
+
+func (tr *todoRepository) Search(ctx context.Context, userID uuid.UUID, query string, limit uint64) ([]*entity.TodoSearchResult, error) {
+	tsQuery := toPrefixTSQuery(query)
+	if tsQuery == "" {
+		return []*entity.TodoSearchResult{}, nil
+	}
+
+	sql, args, err := tr.qb.Builder.Select(todoColumns).
+		Column(squirrel.Alias(squirrel.Expr("ts_rank(search_vector, to_tsquery('simple', ?))", tsQuery), "rank")).
+		Column(squirrel.Alias(squirrel.Expr("ts_headline('simple', content, to_tsquery('simple', ?), ?)",
+			tsQuery, searchHeadlineOptions), "snippet")).
+		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		Where(squirrel.Expr("search_vector @@ to_tsquery('simple', ?)", tsQuery)).
+		OrderBy("rank DESC", "created_at DESC").Limit(limit).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for search todos",
+			"operation", "search todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	results := make([]*entity.TodoSearchResult, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &results, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to search todos",
+			"operation", "search todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("search todos: %w", err)
+	}
+
+	if len(results) > 0 {
+		return results, nil
+	}
+
+	return tr.searchSimilar(ctx, userID, query, limit)
+}
+
+func (tr *todoRepository) searchSimilar(ctx context.Context, userID uuid.UUID, query string, limit uint64) ([]*entity.TodoSearchResult, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns).
+		Column(squirrel.Alias(squirrel.Expr("word_similarity(?, content)", query), "rank")).
+		Column("content AS snippet").
+		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		Where(squirrel.Expr("word_similarity(?, content) >= ?", query, similarityThreshold)).
+		OrderBy("rank DESC", "created_at DESC").Limit(limit).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for similar todos",
+			"operation", "search similar todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	results := make([]*entity.TodoSearchResult, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &results, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to search similar todos",
+			"operation", "search similar todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("search similar todos: %w", err)
+	}
+
+	return results, nil
+}
+
 func (tr *todoRepository) UpdateStatus(ctx context.Context, newStatus TodoStatus, todoID, userID uuid.UUID) error {
-	sql, args, err := tr.qb.Builder.Update("todos").Set("status", newStatus).Where(squirrel.Eq{"id": todoID}).
-		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	sql, args, err := tr.qb.Builder.Update("todos").Set("status", newStatus).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(canAccess("todos", userID, RoleEditor)).Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id")).ToSql()
 	if err != nil {
 		tr.logger.Logger.Error("failed to build query for update todo status",
 			"operation", "update status",
@@ -135,7 +483,7 @@ func (tr *todoRepository) UpdateStatus(ctx context.Context, newStatus TodoStatus
 		return ErrFailBuildQuery
 	}
 
-	result, err := tr.db.DB.ExecContext(ctx, sql, args...)
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		tr.logger.Logger.Error("failed to update status",
 			"operation", "update status",
@@ -177,8 +525,11 @@ func (tr *todoRepository) UpdateStatus(ctx context.Context, newStatus TodoStatus
 }
 
 func (tr *todoRepository) UpdateContent(ctx context.Context, newContent string, todoID, userID uuid.UUID) error {
-	sql, args, err := tr.qb.Builder.Update("todos").Set("content", newContent).Where(squirrel.Eq{"id": todoID}).
-		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	sql, args, err := tr.qb.Builder.Update("todos").Set("content", newContent).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(canAccess("todos", userID, RoleEditor)).Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id")).ToSql()
 	if err != nil {
 		tr.logger.Logger.Error("failed to build query for update todo content",
 			"operation", "update content",
@@ -191,7 +542,7 @@ func (tr *todoRepository) UpdateContent(ctx context.Context, newContent string,
 		return ErrFailBuildQuery
 	}
 
-	result, err := tr.db.DB.ExecContext(ctx, sql, args...)
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		tr.logger.Logger.Error("failed to update content",
 			"operation", "update content",
@@ -232,12 +583,134 @@ func (tr *todoRepository) UpdateContent(ctx context.Context, newContent string,
 	return nil
 }
 
-func (tr *todoRepository) Delete(ctx context.Context, todoID, userID uuid.UUID) error {
-	sql, args, err := tr.qb.Builder.Delete("todos").Where(squirrel.Eq{"id": todoID}).
-		Where(squirrel.Eq{"user_id": userID}).ToSql()
+func (tr *todoRepository) UpdateSchedule(ctx context.Context, dueAt, remindAt *time.Time, todoID, userID uuid.UUID) error {
+	sql, args, err := tr.qb.Builder.Update("todos").Set("due_at", dueAt).Set("remind_at", remindAt).
+		Set("reminded_at", nil).Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(canAccess("todos", userID, RoleEditor)).Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for update todo schedule",
+			"operation", "update schedule",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		tr.logger.Logger.Error("failed to update schedule",
+			"operation", "update schedule",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("update schedule: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tr.logger.Logger.Error("failed to get affected from update todo schedule",
+			"operation", "update schedule",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrGetAffected
+	}
+
+	if affected == 0 {
+		tr.logger.Logger.Error("failed to update schedule",
+			"operation", "update schedule",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", errors.New("todo not found").Error(),
+		)
+
+		return errors.New("todo not found")
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) UpdateProject(ctx context.Context, projectID *uuid.UUID, todoID, userID uuid.UUID) error {
+	query := tr.qb.Builder.Update("todos").Set("project_id", projectID).Set("archived", false).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil})
+
+	return tr.execTodoUpdate(ctx, "update project", query, todoID, userID)
+}
+
+func (tr *todoRepository) UpdateAssignee(ctx context.Context, assigneeID *uuid.UUID, todoID, userID uuid.UUID) error {
+	query := tr.qb.Builder.Update("todos").Set("assignee_id", assigneeID).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(canAccess("todos", userID, RoleEditor)).Where(squirrel.Eq{"deleted_at": nil})
+
+	return tr.execTodoUpdate(ctx, "update assignee", query, todoID, userID)
+}
+
+func (tr *todoRepository) UpdateParent(ctx context.Context, parentID *uuid.UUID, todoID, userID uuid.UUID) error {
+	query := tr.qb.Builder.Update("todos").Set("parent_id", parentID).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil})
+
+	return tr.execTodoUpdate(ctx, "update parent", query, todoID, userID)
+}
+
+func (tr *todoRepository) UpdateRecurrence(ctx context.Context, todo *entity.Todo) error {
+	query := tr.qb.Builder.Update("todos").Set("recurrence", todo.Recurrence).
+		Set("recurrence_start", todo.RecurrenceStart).Set("series_id", todo.SeriesID).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todo.ID}).
+		Where(squirrel.Eq{"user_id": todo.UserID}).Where(squirrel.Eq{"deleted_at": nil})
+
+	return tr.execTodoUpdate(ctx, "update recurrence", query, todo.ID, todo.UserID)
+}
+
+func (tr *todoRepository) SetArchivedByProject(ctx context.Context, archived bool, projectID, userID uuid.UUID) error {
+	sql, args, err := tr.qb.Builder.Update("todos").Set("archived", archived).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"project_id": projectID}).
+		Where(squirrel.Eq{"user_id": userID}).Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for archive project todos",
+			"operation", "archive project todos",
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if _, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to archive project todos",
+			"operation", "archive project todos",
+			"user_id", userID.String(),
+			"project_id", projectID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("archive project todos: %w", err)
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) execTodoUpdate(ctx context.Context, operation string, query squirrel.UpdateBuilder,
+	todoID, userID uuid.UUID) error {
+	sql, args, err := query.Where(inOrganization(ctx, "org_id")).ToSql()
 	if err != nil {
-		tr.logger.Logger.Error("failed to build query for delete todo",
-			"operation", "delete todo",
+		tr.logger.Logger.Error("failed to build query for "+operation,
+			"operation", operation,
 			"user_id", userID.String(),
 			"todo_id", todoID.String(),
 			"error", err.Error(),
@@ -246,22 +719,22 @@ func (tr *todoRepository) Delete(ctx context.Context, todoID, userID uuid.UUID)
 		return ErrFailBuildQuery
 	}
 
-	result, err := tr.db.DB.ExecContext(ctx, sql, args...)
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
-		tr.logger.Logger.Error("failed to delete todo",
-			"operation", "delete todo",
+		tr.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
 			"user_id", userID.String(),
 			"todo_id", todoID.String(),
 			"error", err.Error(),
 		)
 
-		return fmt.Errorf("delete todo: %w", err)
+		return fmt.Errorf("%s: %w", operation, err)
 	}
 
 	affected, err := result.RowsAffected()
 	if err != nil {
-		tr.logger.Logger.Error("failed to get affected from delete todo",
-			"operation", "delete todo",
+		tr.logger.Logger.Error("failed to get affected from "+operation,
+			"operation", operation,
 			"user_id", userID.String(),
 			"todo_id", todoID.String(),
 			"error", err.Error(),
@@ -271,8 +744,8 @@ func (tr *todoRepository) Delete(ctx context.Context, todoID, userID uuid.UUID)
 	}
 
 	if affected == 0 {
-		tr.logger.Logger.Error("failed to delete todo",
-			"operation", "delete todo",
+		tr.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
 			"user_id", userID.String(),
 			"todo_id", todoID.String(),
 			"error", errors.New("todo not found").Error(),
@@ -283,3 +756,524 @@ func (tr *todoRepository) Delete(ctx context.Context, todoID, userID uuid.UUID)
 
 	return nil
 }
+
+func (tr *todoRepository) ClaimDueReminders(ctx context.Context, now time.Time, limit uint64) ([]*entity.Reminder, error) {
+	due := tr.qb.Builder.Select("id").From("todos").
+		Where(squirrel.LtOrEq{"remind_at": now}).Where(squirrel.Eq{"reminded_at": nil}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("remind_at").Limit(limit).Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := tr.qb.Builder.Update("todos t").Set("reminded_at", squirrel.Expr("now()")).
+		From("users u").Where("u.id = t.user_id").Where("u.deleted_at IS NULL").
+		Where(due.Prefix("t.id IN (").Suffix(")")).
+		Suffix("RETURNING t.id, t.user_id, u.email, u.name, t.content, t.due_at, t.remind_at").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for claim reminders",
+			"operation", "claim reminders",
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	reminders := make([]*entity.Reminder, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &reminders, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to claim reminders",
+			"operation", "claim reminders",
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("claim reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+func (tr *todoRepository) ReleaseReminder(ctx context.Context, todoID uuid.UUID) error {
+	sql, args, err := tr.qb.Builder.Update("todos").Set("reminded_at", nil).
+		Where(squirrel.Eq{"id": todoID}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for release reminder",
+			"operation", "release reminder",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if _, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to release reminder",
+			"operation", "release reminder",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("release reminder: %w", err)
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) SkipReminder(ctx context.Context, todoID uuid.UUID) error {
+	sql, args, err := tr.qb.Builder.Update("todos").Set("reminded_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": todoID}).Where(squirrel.Eq{"reminded_at": nil}).
+		Where(squirrel.NotEq{"remind_at": nil}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for skip reminder",
+			"operation", "skip reminder",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	if _, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to skip reminder",
+			"operation", "skip reminder",
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("skip reminder: %w", err)
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) GetTrash(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns, "deleted_at").From("todos").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.NotEq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		OrderBy("deleted_at DESC", "id").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get trash",
+			"operation", "get trash",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	todos := make([]*entity.Todo, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get trash",
+			"operation", "get trash",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select trash: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) Restore(ctx context.Context, todoID, userID uuid.UUID) error {
+	subtree := "WITH RECURSIVE subtree AS (" +
+		"SELECT id, deleted_at FROM todos WHERE id = ? AND user_id = ? AND deleted_at IS NOT NULL " +
+		"UNION " +
+		"SELECT t.id, t.deleted_at FROM todos t JOIN subtree ON t.parent_id = subtree.id " +
+		"WHERE t.deleted_at = subtree.deleted_at)"
+
+	query := tr.qb.Builder.Update("todos").Prefix(subtree, todoID, userID).
+		Set("deleted_at", nil).Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).
+		Where("id IN (SELECT id FROM subtree)")
+
+	return tr.execTodoUpdate(ctx, "restore todo", query, todoID, userID)
+}
+
+func (tr *todoRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := tr.qb.Builder.Delete("todos").
+		Where(squirrel.Lt{"deleted_at": before}).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for purge todos",
+			"operation", "purge todos",
+			"error", err.Error(),
+		)
+
+		return 0, ErrFailBuildQuery
+	}
+
+	result, err := tr.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		tr.logger.Logger.Error("failed to purge todos",
+			"operation", "purge todos",
+			"error", err.Error(),
+		)
+
+		return 0, fmt.Errorf("purge todos: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		tr.logger.Logger.Error("failed to get affected from purge todos",
+			"operation", "purge todos",
+			"error", err.Error(),
+		)
+
+		return 0, ErrGetAffected
+	}
+
+	return affected, nil
+}
+
+func (tr *todoRepository) Delete(ctx context.Context, todoID, userID uuid.UUID) error {
+	ownerAccess, ownerArgs := accessCondition("todos", userID, RoleOwner)
+	subtree := "WITH RECURSIVE subtree AS (" +
+		"SELECT id FROM todos WHERE id = ? AND " + ownerAccess + " AND deleted_at IS NULL " +
+		"UNION " +
+		"SELECT t.id FROM todos t JOIN subtree ON t.parent_id = subtree.id WHERE t.deleted_at IS NULL)"
+
+	query := tr.qb.Builder.Update("todos").Prefix(subtree, append([]interface{}{todoID}, ownerArgs...)...).
+		Set("deleted_at", squirrel.Expr("now()")).Set("version", squirrel.Expr("version + 1")).
+		Where("id IN (SELECT id FROM subtree)")
+
+	return tr.execTodoUpdate(ctx, "delete todo", query, todoID, userID)
+}
+
+func (tr *todoRepository) CreateBatch(ctx context.Context, todos []*entity.Todo) error {
+	orgID := organizationID(ctx)
+	query := tr.qb.Builder.Insert("todos").Columns("id", "user_id", "org_id", "project_id", "parent_id",
+		"content", "status", "due_at", "remind_at", "recurrence", "recurrence_start", "series_id", "position")
+	for _, todo := range todos {
+		query = query.Values(todo.ID, todo.UserID, orgID, todo.ProjectID, todo.ParentID, todo.Content, todo.Status,
+			todo.DueAt, todo.RemindAt, todo.Recurrence, todo.RecurrenceStart, todo.SeriesID, todo.Position)
+	}
+
+	sql, args, err := query.Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for create todos",
+			"operation", "create todos",
+			"count", len(todos),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	var created []*entity.Todo
+	if err := tr.db.conn(ctx).SelectContext(ctx, &created, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to create todos",
+			"operation", "create todos",
+			"count", len(todos),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("insert todos: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*entity.Todo, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+
+	for _, row := range created {
+		if todo, ok := byID[row.ID]; ok {
+			todo.CreatedAt = row.CreatedAt
+		}
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) GetTodosForUpdate(ctx context.Context, todoIDs []uuid.UUID, userID uuid.UUID) ([]*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select(todoColumns).
+		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"id": todoIDs}).
+		Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).
+		OrderBy("id").Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for lock todos",
+			"operation", "lock todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var todos []*entity.Todo
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to lock todos",
+			"operation", "lock todos",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock todos: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) UpdateContentBatch(ctx context.Context, todos []*entity.Todo, userID uuid.UUID) error {
+	values := make([]string, 0, len(todos))
+	args := make([]interface{}, 0, len(todos)*2)
+	todoIDs := make([]uuid.UUID, 0, len(todos))
+	for _, todo := range todos {
+		values = append(values, "(?::uuid, ?)")
+		args = append(args, todo.ID, todo.Content)
+		todoIDs = append(todoIDs, todo.ID)
+	}
+
+	query := tr.qb.Builder.Update("todos t").
+		Prefix("WITH v (id, content) AS (VALUES "+strings.Join(values, ", ")+")", args...).
+		Set("content", squirrel.Expr("v.content")).Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("t.version + 1")).
+		From("v").Where("t.id = v.id").
+		Where(squirrel.Eq{"t.user_id": userID}).Where(squirrel.Eq{"t.deleted_at": nil}).
+		Where(inOrganization(ctx, "t.org_id")).Suffix("RETURNING t.id")
+
+	return tr.execTodoBatch(ctx, "update content batch", query, todoIDs, userID)
+}
+
+func (tr *todoRepository) DeleteBatch(ctx context.Context, todoIDs []uuid.UUID, userID uuid.UUID) error {
+	subtree := tr.qb.Builder.Select("id").From("todos").
+		Where(squirrel.Eq{"id": todoIDs}).Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Eq{"deleted_at": nil}).
+		Prefix("WITH RECURSIVE subtree AS (").
+		Suffix("UNION SELECT t.id FROM todos t JOIN subtree ON t.parent_id = subtree.id WHERE t.deleted_at IS NULL)")
+
+	query := tr.qb.Builder.Update("todos").PrefixExpr(subtree).
+		Set("deleted_at", squirrel.Expr("now()")).Set("version", squirrel.Expr("version + 1")).
+		Where("id IN (SELECT id FROM subtree)").Where(inOrganization(ctx, "org_id")).Suffix("RETURNING id")
+
+	return tr.execTodoBatch(ctx, "delete todos", query, todoIDs, userID)
+}
+
+func (tr *todoRepository) execTodoBatch(ctx context.Context, operation string, query squirrel.UpdateBuilder,
+	todoIDs []uuid.UUID, userID uuid.UUID) error {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return ErrFailBuildQuery
+	}
+
+	var changedIDs []uuid.UUID
+	if err := tr.db.conn(ctx).SelectContext(ctx, &changedIDs, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to "+operation,
+			"operation", operation,
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return fmt.Errorf("%s: %w", operation, err)
+	}
+
+	changed := make(map[uuid.UUID]struct{}, len(changedIDs))
+	for _, id := range changedIDs {
+		changed[id] = struct{}{}
+	}
+
+	for _, id := range todoIDs {
+		if _, ok := changed[id]; !ok {
+			tr.logger.Logger.Error("failed to "+operation,
+				"operation", operation,
+				"user_id", userID.String(),
+				"todo_id", id.String(),
+				"error", errors.New("todo not found").Error(),
+			)
+
+			return errors.New("todo not found")
+		}
+	}
+
+	return nil
+}
+
+func (tr *todoRepository) CountByStatus(ctx context.Context, userID uuid.UUID, projectID *uuid.UUID) (map[string]int, error) {
+	query := tr.qb.Builder.Select("status", "count(*) AS count").From("todos").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil}).
+		Where(inOrganization(ctx, "org_id"))
+	if projectID != nil {
+		query = query.Where(squirrel.Eq{"project_id": *projectID})
+	} else {
+		query = query.Where(squirrel.Eq{"archived": false})
+	}
+
+	sql, args, err := query.GroupBy("status").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for count todos by status",
+			"operation", "count by status",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	counts := make([]*entity.StatusCount, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &counts, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to count todos by status",
+			"operation", "count by status",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("count todos by status: %w", err)
+	}
+
+	byStatus := make(map[string]int, len(counts))
+	for _, count := range counts {
+		byStatus[count.Status] = count.Count
+	}
+
+	return byStatus, nil
+}
+
+func (tr *todoRepository) GetLastPosition(ctx context.Context, userID uuid.UUID) (string, error) {
+	sql, args, err := tr.qb.Builder.Select("COALESCE(max(position), '')").
+		From("todos").Where(squirrel.Eq{"user_id": userID}).Where(inOrganization(ctx, "org_id")).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get last position",
+			"operation", "get last position",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return "", ErrFailBuildQuery
+	}
+
+	var position string
+	if err := tr.db.conn(ctx).GetContext(ctx, &position, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get last position",
+			"operation", "get last position",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return "", fmt.Errorf("select last position: %w", err)
+	}
+
+	return position, nil
+}
+
+func (tr *todoRepository) GetNeighborPosition(ctx context.Context, position string, next bool,
+	todoID, userID uuid.UUID) (string, error) {
+	query := tr.qb.Builder.Select("position").From("todos").
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.NotEq{"id": todoID}).
+		Where(squirrel.Eq{"deleted_at": nil}).Where(inOrganization(ctx, "org_id")).Limit(1)
+	if next {
+		query = query.Where(squirrel.Gt{"position": position}).OrderBy("position ASC")
+	} else {
+		query = query.Where(squirrel.Lt{"position": position}).OrderBy("position DESC")
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get neighbor position",
+			"operation", "get neighbor position",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return "", ErrFailBuildQuery
+	}
+
+	var positions []string
+	if err := tr.db.conn(ctx).SelectContext(ctx, &positions, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get neighbor position",
+			"operation", "get neighbor position",
+			"user_id", userID.String(),
+			"todo_id", todoID.String(),
+			"error", err.Error(),
+		)
+
+		return "", fmt.Errorf("select neighbor position: %w", err)
+	}
+
+	if len(positions) == 0 {
+		return "", nil
+	}
+
+	return positions[0], nil
+}
+
+func (tr *todoRepository) UpdatePosition(ctx context.Context, position string, todoID, userID uuid.UUID) error {
+	query := tr.qb.Builder.Update("todos").Set("position", position).
+		Set("updated_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).Where(squirrel.Eq{"id": todoID}).
+		Where(squirrel.Eq{"user_id": userID}).Where(squirrel.Eq{"deleted_at": nil})
+
+	return tr.execTodoUpdate(ctx, "update position", query, todoID, userID)
+}
+
+func (tr *todoRepository) GetUsersWithLongPositions(ctx context.Context, maxLength int, limit uint64) ([]uuid.UUID, error) {
+	sql, args, err := tr.qb.Builder.Select("user_id").From("todos").
+		GroupBy("user_id").Having("max(length(position)) > ?", maxLength).
+		OrderBy("user_id").Limit(limit).ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for get users with long positions",
+			"operation", "get users with long positions",
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	userIDs := make([]uuid.UUID, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &userIDs, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to get users with long positions",
+			"operation", "get users with long positions",
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("select users with long positions: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+func (tr *todoRepository) GetPositionsForUpdate(ctx context.Context, userID uuid.UUID) ([]*entity.Todo, error) {
+	sql, args, err := tr.qb.Builder.Select("id", "position").From("todos").
+		Where(squirrel.Eq{"user_id": userID}).OrderBy("position", "id").
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		tr.logger.Logger.Error("failed to build query for lock positions",
+			"operation", "lock positions",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	todos := make([]*entity.Todo, 0)
+	if err := tr.db.conn(ctx).SelectContext(ctx, &todos, sql, args...); err != nil {
+		tr.logger.Logger.Error("failed to lock positions",
+			"operation", "lock positions",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock positions: %w", err)
+	}
+
+	return todos, nil
+}
+
+func (tr *todoRepository) UpdatePositions(ctx context.Context, todos []*entity.Todo, userID uuid.UUID) error {
+	values := make([]string, 0, len(todos))
+	args := make([]interface{}, 0, len(todos)*2)
+	todoIDs := make([]uuid.UUID, 0, len(todos))
+	for _, todo := range todos {
+		values = append(values, "(?::uuid, ?)")
+		args = append(args, todo.ID, todo.Position)
+		todoIDs = append(todoIDs, todo.ID)
+	}
+
+	query := tr.qb.Builder.Update("todos t").
+		Prefix("WITH v (id, position) AS (VALUES "+strings.Join(values, ", ")+")", args...).
+		Set("position", squirrel.Expr("v.position")).
+		From("v").Where("t.id = v.id").Where(squirrel.Eq{"t.user_id": userID}).Suffix("RETURNING t.id")
+
+	return tr.execTodoBatch(ctx, "update positions", query, todoIDs, userID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -14,12 +15,14 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
 	GetByID(ctx context.Context, userID uuid.UUID) (*entity.User, error)
+	GetByIDForUpdate(ctx context.Context, userID uuid.UUID) (*entity.User, error)
 	GetByEmail(ctx context.Context, userEmail string) (*entity.User, error)
 	GetAllUsers(ctx context.Context) ([]*entity.User, error)
 	ChangeName(ctx context.Context, newName string, userID uuid.UUID) error
 	ChangeEmail(ctx context.Context, newEmail string, userID uuid.UUID) error
 	ChangePassword(ctx context.Context, newPassword string, userID uuid.UUID) error
 	Delete(ctx context.Context, userID uuid.UUID) error
+	Purge(ctx context.Context, before time.Time) (int64, error)
 }
 
 type userRepository struct {
@@ -51,7 +54,7 @@ func (ur *userRepository) Create(ctx context.Context, user *entity.User) error {
 
 		return ErrFailBuildQuery
 	}
-	err = ur.db.DB.QueryRowxContext(ctx, sql, args...).Scan(&user.ID, &user.CreatedAt)
+	err = ur.db.conn(ctx).QueryRowxContext(ctx, sql, args...).Scan(&user.ID, &user.CreatedAt)
 	if err != nil {
 		ur.logger.Logger.Error("failed to create user",
 			"operation", "create user",
@@ -67,7 +70,8 @@ func (ur *userRepository) Create(ctx context.Context, user *entity.User) error {
 
 func (ur *userRepository) GetAllUsers(ctx context.Context) ([]*entity.User, error) {
 	sql, args, err := ur.qb.Builder.Select("id", "name", "email", "password",
-		"created_at", "updated_at").From("users").OrderBy("email").ToSql()
+		"version", "created_at", "updated_at").From("users").Where(squirrel.Eq{"deleted_at": nil}).
+		OrderBy("email").ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for get users",
 			"operation", "get users",
@@ -78,7 +82,7 @@ func (ur *userRepository) GetAllUsers(ctx context.Context) ([]*entity.User, erro
 	}
 
 	var users []*entity.User
-	if err := ur.db.DB.SelectContext(ctx, &users, sql, args...); err != nil {
+	if err := ur.db.conn(ctx).SelectContext(ctx, &users, sql, args...); err != nil {
 		ur.logger.Logger.Error("failed to get users",
 			"operation", "get users",
 			"error", err.Error(),
@@ -91,8 +95,8 @@ func (ur *userRepository) GetAllUsers(ctx context.Context) ([]*entity.User, erro
 }
 
 func (ur *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
-	sql, args, err := ur.qb.Builder.Select("id, name, email, password, created_at, updated_at").
-		From("users").Where(squirrel.Eq{"id": userID}).ToSql()
+	sql, args, err := ur.qb.Builder.Select("id, name, email, password, version, created_at, updated_at").
+		From("users").Where(squirrel.Eq{"id": userID, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for get user",
 			"operation", "get user",
@@ -104,7 +108,7 @@ func (ur *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*entit
 	}
 
 	var user entity.User
-	if err := ur.db.DB.GetContext(ctx, &user, sql, args...); err != nil {
+	if err := ur.db.conn(ctx).GetContext(ctx, &user, sql, args...); err != nil {
 		ur.logger.Logger.Error("failed to get user",
 			"operation", "get user",
 			"user_id", userID.String(),
@@ -117,9 +121,36 @@ func (ur *userRepository) GetByID(ctx context.Context, userID uuid.UUID) (*entit
 	return &user, nil
 }
 
+func (ur *userRepository) GetByIDForUpdate(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
+	sql, args, err := ur.qb.Builder.Select("id, name, email, password, version, created_at, updated_at").
+		From("users").Where(squirrel.Eq{"id": userID, "deleted_at": nil}).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		ur.logger.Logger.Error("failed to build query for lock user",
+			"operation", "lock user",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, ErrFailBuildQuery
+	}
+
+	var user entity.User
+	if err := ur.db.conn(ctx).GetContext(ctx, &user, sql, args...); err != nil {
+		ur.logger.Logger.Error("failed to lock user",
+			"operation", "lock user",
+			"user_id", userID.String(),
+			"error", err.Error(),
+		)
+
+		return nil, fmt.Errorf("lock user: %w", err)
+	}
+
+	return &user, nil
+}
+
 func (ur *userRepository) GetByEmail(ctx context.Context, userEmail string) (*entity.User, error) {
-	sql, args, err := ur.qb.Builder.Select("id, name, email, password, created_at, updated_at").
-		From("users").Where(squirrel.Eq{"email": userEmail}).ToSql()
+	sql, args, err := ur.qb.Builder.Select("id, name, email, password, version, created_at, updated_at").
+		From("users").Where(squirrel.Eq{"email": userEmail, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for get user",
 			"operation", "get user",
@@ -131,7 +162,7 @@ func (ur *userRepository) GetByEmail(ctx context.Context, userEmail string) (*en
 	}
 
 	var user entity.User
-	if err := ur.db.DB.GetContext(ctx, &user, sql, args...); err != nil {
+	if err := ur.db.conn(ctx).GetContext(ctx, &user, sql, args...); err != nil {
 		ur.logger.Logger.Error("failed to get user",
 			"operation", "get user",
 			"user_email", userEmail,
@@ -146,7 +177,8 @@ func (ur *userRepository) GetByEmail(ctx context.Context, userEmail string) (*en
 
 func (ur *userRepository) ChangeName(ctx context.Context, newName string, userID uuid.UUID) error {
 	sql, args, err := ur.qb.Builder.Update("users").Set("name", newName).
-		Where(squirrel.Eq{"id": userID}).ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": userID, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for update name",
 			"operation", "update name",
@@ -158,7 +190,7 @@ func (ur *userRepository) ChangeName(ctx context.Context, newName string, userID
 		return ErrFailBuildQuery
 	}
 
-	result, err := ur.db.DB.ExecContext(ctx, sql, args...)
+	result, err := ur.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		ur.logger.Logger.Error("failed to update name",
 			"operation", "update name",
@@ -198,7 +230,8 @@ func (ur *userRepository) ChangeName(ctx context.Context, newName string, userID
 
 func (ur *userRepository) ChangeEmail(ctx context.Context, newEmail string, userID uuid.UUID) error {
 	sql, args, err := ur.qb.Builder.Update("users").Set("email", newEmail).
-		Where(squirrel.Eq{"id": userID}).ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": userID, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for update email",
 			"operation", "update email",
@@ -210,7 +243,7 @@ func (ur *userRepository) ChangeEmail(ctx context.Context, newEmail string, user
 		return ErrFailBuildQuery
 	}
 
-	result, err := ur.db.DB.ExecContext(ctx, sql, args...)
+	result, err := ur.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		ur.logger.Logger.Error("failed to update email",
 			"operation", "update email",
@@ -250,7 +283,8 @@ func (ur *userRepository) ChangeEmail(ctx context.Context, newEmail string, user
 
 func (ur *userRepository) ChangePassword(ctx context.Context, newPassword string, userID uuid.UUID) error {
 	sql, args, err := ur.qb.Builder.Update("users").Set("password", newPassword).
-		Where(squirrel.Eq{"id": userID}).ToSql()
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": userID, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for update password",
 			"operation", "update password",
@@ -262,7 +296,7 @@ func (ur *userRepository) ChangePassword(ctx context.Context, newPassword string
 		return ErrFailBuildQuery
 	}
 
-	result, err := ur.db.DB.ExecContext(ctx, sql, args...)
+	result, err := ur.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		ur.logger.Logger.Error("failed to update password",
 			"operation", "update password",
@@ -301,8 +335,9 @@ func (ur *userRepository) ChangePassword(ctx context.Context, newPassword string
 }
 
 func (ur *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
-	sql, args, err := ur.qb.Builder.Delete("users").
-		Where(squirrel.Eq{"id": userID}).ToSql()
+	sql, args, err := ur.qb.Builder.Update("users").Set("deleted_at", squirrel.Expr("now()")).
+		Set("version", squirrel.Expr("version + 1")).
+		Where(squirrel.Eq{"id": userID, "deleted_at": nil}).ToSql()
 	if err != nil {
 		ur.logger.Logger.Error("failed to build query for delete user",
 			"operation", "delete user",
@@ -313,7 +348,7 @@ func (ur *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
 		return ErrFailBuildQuery
 	}
 
-	result, err := ur.db.DB.ExecContext(ctx, sql, args...)
+	result, err := ur.db.conn(ctx).ExecContext(ctx, sql, args...)
 	if err != nil {
 		ur.logger.Logger.Error("failed to delete user",
 			"operation", "delete user",
@@ -347,3 +382,38 @@ func (ur *userRepository) Delete(ctx context.Context, userID uuid.UUID) error {
 
 	return nil
 }
+
+func (ur *userRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	sql, args, err := ur.qb.Builder.Delete("users").
+		Where(squirrel.Lt{"deleted_at": before}).ToSql()
+	if err != nil {
+		ur.logger.Logger.Error("failed to build query for purge users",
+			"operation", "purge users",
+			"error", err.Error(),
+		)
+
+		return 0, ErrFailBuildQuery
+	}
+
+	result, err := ur.db.conn(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		ur.logger.Logger.Error("failed to purge users",
+			"operation", "purge users",
+			"error", err.Error(),
+		)
+
+		return 0, fmt.Errorf("purge users: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		ur.logger.Logger.Error("failed to get affected from purge users",
+			"operation", "purge users",
+			"error", err.Error(),
+		)
+
+		return 0, ErrGetAffected
+	}
+
+	return affected, nil
+}
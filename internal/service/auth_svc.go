@@ -15,21 +15,26 @@ import (
 )
 
 type authService struct {
-	userRepo  psql.UserRepository
-	validator *se.Validator
-	hasher    hash.Hasher
-	jwtSecret string
+	userRepo         psql.UserRepository
+	organizationRepo psql.OrganizationRepository
+	transactor       psql.Transactor
+	validator        *se.Validator
+	hasher           hash.Hasher
+	jwtSecret        string
 }
 
-func NewAuthService(ur psql.UserRepository, secret string) se.AuthUseCases {
+func NewAuthService(ur psql.UserRepository, or psql.OrganizationRepository, tx psql.Transactor,
+	secret string) se.AuthUseCases {
 	v := se.InitValidator()
 	h := hash.NewHasher()
 
 	return &authService{
-		userRepo:  ur,
-		validator: v,
-		hasher:    h,
-		jwtSecret: secret,
+		userRepo:         ur,
+		organizationRepo: or,
+		transactor:       tx,
+		validator:        v,
+		hasher:           h,
+		jwtSecret:        secret,
 	}
 }
 
@@ -38,21 +43,38 @@ func (as *authService) Register(ctx context.Context, userRequest *dto.UserRegist
 		return err
 	}
 
-	uuid := uuid.New()
-
 	hashedPassword, err := as.hasher.HashPassword(userRequest.Password)
 	if err != nil {
 		return err
 	}
 
 	user := &re.User{
-		ID:       uuid,
+		ID:       uuid.New(),
 		Name:     userRequest.Name,
 		Email:    userRequest.Email,
 		Password: hashedPassword,
 	}
 
-	return as.userRepo.Create(ctx, user)
+	organization := &re.Organization{
+		ID:   uuid.New(),
+		Name: userRequest.Name,
+	}
+
+	return as.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := as.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+
+		if err := as.organizationRepo.Create(ctx, organization); err != nil {
+			return err
+		}
+
+		return as.organizationRepo.AddMember(ctx, &re.Membership{
+			OrgID:  organization.ID,
+			UserID: user.ID,
+			Role:   string(psql.MemberOwner),
+		})
+	})
 }
 
 func (as *authService) Login(ctx context.Context, userRequest *dto.UserLoginRequest) (*dto.AuthResponse, error) {
@@ -69,7 +91,44 @@ func (as *authService) Login(ctx context.Context, userRequest *dto.UserLoginRequ
 		return nil, se.ErrInvalidPassword
 	}
 
-	token, expires, err := as.generateToken(user)
+	var membership *re.Membership
+	if userRequest.OrgID != nil {
+		membership, err = as.organizationRepo.GetMembership(ctx, *userRequest.OrgID, user.ID)
+	} else {
+		membership, err = as.organizationRepo.GetDefaultMembership(ctx, user.ID)
+	}
+	if err != nil {
+		return nil, se.ErrNotOrgMember
+	}
+
+	return as.authResponse(user, membership)
+}
+
+func (as *authService) SwitchOrganization(ctx context.Context, orgID uuid.UUID) (*dto.AuthResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return nil, se.ErrInvalidOrgID
+	}
+
+	membership, err := as.organizationRepo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return nil, se.ErrNotOrgMember
+	}
+
+	user, err := as.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	return as.authResponse(user, membership)
+}
+
+func (as *authService) authResponse(user *re.User, membership *re.Membership) (*dto.AuthResponse, error) {
+	token, expires, err := as.generateToken(user, membership.OrgID)
 	if err != nil {
 		return nil, err
 	}
@@ -80,6 +139,8 @@ func (as *authService) Login(ctx context.Context, userRequest *dto.UserLoginRequ
 			Name:  user.Name,
 			Email: user.Email,
 		},
+		OrgID:     membership.OrgID,
+		Role:      membership.Role,
 		Token:     token,
 		ExpiresAt: expires,
 	}
@@ -87,12 +148,13 @@ func (as *authService) Login(ctx context.Context, userRequest *dto.UserLoginRequ
 	return response, nil
 }
 
-func (as *authService) generateToken(user *re.User) (string, time.Time, error) {
+func (as *authService) generateToken(user *re.User, orgID uuid.UUID) (string, time.Time, error) {
 	expiresAt := time.Now().Add(24 * time.Hour)
 
 	claims := jwt.MapClaims{
 		"userID": user.ID.String(),
 		"email":  user.Email,
+		"orgID":  orgID.String(),
 		"exp":    expiresAt.Unix(),
 		"iat":    time.Now().Unix(),
 	}
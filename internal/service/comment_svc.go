@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+const defaultCommentPageSize uint64 = 50
+
+type commentService struct {
+	commentRepo psql.CommentRepository
+	todoRepo    psql.TodoRepository
+	transactor  psql.Transactor
+	validator   *se.Validator
+}
+
+func NewCommentService(cr psql.CommentRepository, tr psql.TodoRepository, tx psql.Transactor) se.CommentUseCases {
+	v := se.InitValidator()
+
+	return &commentService{
+		commentRepo: cr,
+		todoRepo:    tr,
+		transactor:  tx,
+		validator:   v,
+	}
+}
+
+func (cs *commentService) CreateComment(ctx context.Context, commentRequest *dto.CommentCreateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := cs.validator.CommentCreateRequestValidate(commentRequest); err != nil {
+		return err
+	}
+
+	if _, err := cs.todoRepo.GetTodoByUserID(ctx, commentRequest.TodoID, userID); err != nil {
+		return se.ErrInvalidTodoID
+	}
+
+	comment := &re.Comment{
+		ID:     uuid.New(),
+		TodoID: commentRequest.TodoID,
+		UserID: userID,
+		Body:   commentRequest.Body,
+	}
+
+	if commentRequest.ParentID != nil {
+		parent, err := cs.commentRepo.GetByID(ctx, *commentRequest.ParentID, commentRequest.TodoID)
+		if err != nil {
+			return se.ErrInvalidCommentID
+		}
+
+		if parent.DeletedAt != nil {
+			return se.ErrCommentDeleted
+		}
+
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	return cs.commentRepo.Create(ctx, comment)
+}
+
+func (cs *commentService) GetComments(ctx context.Context,
+	listRequest *dto.CommentListRequest) (*dto.CommentListResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := cs.validator.CommentListRequestValidate(listRequest); err != nil {
+		return nil, err
+	}
+
+	if _, err := cs.todoRepo.GetTodoByUserID(ctx, listRequest.TodoID, userID); err != nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	filter := &psql.CommentFilter{ParentID: listRequest.ParentID, Limit: defaultCommentPageSize}
+	if listRequest.Limit > 0 {
+		filter.Limit = uint64(listRequest.Limit)
+	}
+
+	if listRequest.Cursor != "" {
+		cursor, err := psql.DecodeCommentCursor(listRequest.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Cursor = cursor
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	comments, err := cs.commentRepo.GetByTodoID(ctx, listRequest.TodoID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.CommentListResponse{}
+	if uint64(len(comments)) > limit {
+		comments = comments[:limit]
+		response.NextCursor = psql.EncodeCommentCursor(comments[len(comments)-1])
+	}
+
+	response.Items = make([]*dto.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		response.Items = append(response.Items, commentToResponse(comment))
+	}
+
+	return response, nil
+}
+
+func (cs *commentService) UpdateComment(ctx context.Context, commentRequest *dto.CommentUpdateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := cs.validator.CommentUpdateRequestValidate(commentRequest); err != nil {
+		return err
+	}
+
+	if _, err := cs.todoRepo.GetTodoByUserID(ctx, commentRequest.TodoID, userID); err != nil {
+		return se.ErrInvalidTodoID
+	}
+
+	return cs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		comment, err := cs.commentRepo.Lock(ctx, commentRequest.CommentID, commentRequest.TodoID)
+		if err != nil {
+			return se.ErrInvalidCommentID
+		}
+
+		if comment.DeletedAt != nil {
+			return se.ErrCommentDeleted
+		}
+
+		if comment.UserID != userID {
+			return se.ErrNotCommentAuthor
+		}
+
+		if comment.Body == commentRequest.Body {
+			return nil
+		}
+
+		edit := &re.CommentEdit{
+			ID:        uuid.New(),
+			CommentID: comment.ID,
+			Body:      comment.Body,
+		}
+
+		if err := cs.commentRepo.CreateEdit(ctx, edit); err != nil {
+			return err
+		}
+
+		comment.Body = commentRequest.Body
+
+		return cs.commentRepo.UpdateBody(ctx, comment)
+	})
+}
+
+func (cs *commentService) DeleteComment(ctx context.Context, todoID, commentID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if commentID == uuid.Nil {
+		return se.ErrInvalidCommentID
+	}
+
+	if _, err := cs.todoRepo.GetTodoByUserID(ctx, todoID, userID); err != nil {
+		return se.ErrInvalidTodoID
+	}
+
+	comment, err := cs.commentRepo.GetByID(ctx, commentID, todoID)
+	if err != nil {
+		return se.ErrInvalidCommentID
+	}
+
+	if comment.UserID != userID {
+		return se.ErrNotCommentAuthor
+	}
+
+	return cs.commentRepo.Delete(ctx, commentID, userID)
+}
+
+func (cs *commentService) GetCommentHistory(ctx context.Context, todoID,
+	commentID uuid.UUID) ([]*dto.CommentEditResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	if commentID == uuid.Nil {
+		return nil, se.ErrInvalidCommentID
+	}
+
+	if _, err := cs.todoRepo.GetTodoByUserID(ctx, todoID, userID); err != nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	comment, err := cs.commentRepo.GetByID(ctx, commentID, todoID)
+	if err != nil {
+		return nil, se.ErrInvalidCommentID
+	}
+
+	if comment.DeletedAt != nil {
+		return nil, se.ErrCommentDeleted
+	}
+
+	edits, err := cs.commentRepo.GetEdits(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.CommentEditResponse, 0, len(edits))
+	for _, edit := range edits {
+		response = append(response, &dto.CommentEditResponse{
+			Body:     edit.Body,
+			EditedAt: edit.EditedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func commentToResponse(comment *re.Comment) *dto.CommentResponse {
+	response := &dto.CommentResponse{
+		ID:         comment.ID,
+		TodoID:     comment.TodoID,
+		AuthorID:   comment.UserID,
+		ParentID:   comment.ParentID,
+		Body:       comment.Body,
+		Edited:     comment.UpdatedAt.After(comment.CreatedAt),
+		Deleted:    comment.DeletedAt != nil,
+		ReplyCount: comment.ReplyCount,
+		CreatedAt:  comment.CreatedAt,
+		UpdatedAt:  comment.UpdatedAt,
+	}
+
+	if response.Deleted {
+		response.Body = ""
+	}
+
+	return response
+}
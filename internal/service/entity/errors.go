@@ -7,6 +7,76 @@ var (
 	ErrInvalidPassword  error = errors.New("invalid password")
 	ErrInvalidUserID    error = errors.New("invalid user ID")
 
-	ErrInvalidTodoStatus error = errors.New("cannot create todo that already have done")
+	ErrVersionMismatch error = errors.New("resource has been modified, version does not match")
+
+	ErrIdempotencyKeyReused  error = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress error = errors.New("request with this idempotency key is still in progress")
+
+	ErrInvalidTodoStatus error = errors.New("cannot create todo in a terminal status")
+	ErrWIPLimitReached   error = errors.New("WIP limit reached")
 	ErrInvalidTodoID     error = errors.New("invalid todo ID")
+	ErrInvalidCursor     error = errors.New("cursor does not match sort order")
+	ErrInvalidReminder   error = errors.New("reminder must not be later than due date")
+
+	ErrBatchFailed        error = errors.New("batch was rolled back because some operations failed")
+	ErrInvalidBatchOp     error = errors.New("batch operation must be create, update or delete")
+	ErrEmptyBatchUpdate   error = errors.New("batch update must change content or status")
+	ErrDuplicateBatchTodo error = errors.New("todo is referenced by more than one batch operation")
+
+	ErrInvalidLabelID error = errors.New("invalid label ID")
+	ErrLabelExists    error = errors.New("label with this name already exists")
+
+	ErrInvalidProjectID error = errors.New("invalid project ID")
+	ErrProjectArchived  error = errors.New("project is archived")
+
+	ErrInvalidParentID error = errors.New("invalid parent todo ID")
+	ErrInvalidAssignee error = errors.New("assignee must be able to edit the todo")
+	ErrTodoCycle       error = errors.New("todo cannot be nested under itself or its subtasks")
+
+	ErrInvalidBlockerID error = errors.New("invalid blocker todo ID")
+	ErrDependencyCycle  error = errors.New("dependency would create a cycle")
+	ErrDependencyExists error = errors.New("dependency already exists")
+	ErrTodoBlocked      error = errors.New("todo has open blockers")
+
+	ErrTimerRunning     error = errors.New("another timer is already running")
+	ErrNoRunningTimer   error = errors.New("no running timer")
+	ErrInvalidTimeRange error = errors.New("time entry must stop after it starts and not in the future")
+
+	ErrInvalidTemplateID error = errors.New("invalid template ID")
+	ErrTemplateExists    error = errors.New("template with this name already exists")
+	ErrTemplateTooLarge  error = errors.New("template has too many items or is nested too deep")
+	ErrMissingVariable   error = errors.New("template variable is missing")
+
+	ErrShareForbidden    error = errors.New("only owners can manage sharing")
+	ErrShareUserNotFound error = errors.New("no user with this email")
+	ErrShareWithOwner    error = errors.New("cannot share with the owner")
+	ErrNotTodoOwner      error = errors.New("only the owner can move or reorder a todo")
+
+	ErrInvalidLinkID     error = errors.New("invalid share link ID")
+	ErrInvalidLinkToken  error = errors.New("share link is invalid, expired or revoked")
+	ErrInvalidLinkTarget error = errors.New("share link needs either a todo ID or a filter")
+	ErrInvalidLinkExpiry error = errors.New("share link expiry must be in the future and within the allowed lifetime")
+
+	ErrInvalidOrgID    error = errors.New("invalid organization ID")
+	ErrNotOrgMember    error = errors.New("not a member of this organization")
+	ErrOrgForbidden    error = errors.New("only organization owners and admins can manage members")
+	ErrLastOrgOwner    error = errors.New("organization must keep at least one owner")
+	ErrInvalidMemberID error = errors.New("invalid member ID")
+
+	ErrInvalidInvitationID error = errors.New("invalid invitation ID")
+	ErrInvalidInvitation   error = errors.New("invitation is invalid, expired or already used")
+	ErrAlreadyOrgMember    error = errors.New("user is already a member of this organization")
+	ErrAccountRequired     error = errors.New("name and password are required to create an account")
+
+	ErrInvalidCommentID error = errors.New("invalid comment ID")
+	ErrCommentDeleted   error = errors.New("comment has been deleted")
+	ErrNotCommentAuthor error = errors.New("only the author can change a comment")
+
+	ErrInvalidRecurrence error = errors.New("invalid recurrence rule")
+
+	ErrInvalidPosition error = errors.New("position requires a before or after todo other than the moved one")
+	ErrInvalidNeighbor error = errors.New("invalid neighbor todo ID")
+
+	ErrInvalidRevisionID     error = errors.New("invalid revision ID")
+	ErrRevisionNotRevertible error = errors.New("revision cannot be reverted")
 )
@@ -10,6 +10,7 @@ import (
 type AuthUseCases interface {
 	Register(ctx context.Context, userRequest *dto.UserRegisterRequest) error
 	Login(ctx context.Context, userRequest *dto.UserLoginRequest) (*dto.AuthResponse, error)
+	SwitchOrganization(ctx context.Context, orgID uuid.UUID) (*dto.AuthResponse, error)
 }
 
 type UserUseCases interface {
@@ -24,8 +25,132 @@ type UserUseCases interface {
 type TodoUseCases interface {
 	CreateTodo(ctx context.Context, todoRequest *dto.TodoCreateRequest) error
 	GetTodo(ctx context.Context, todoID uuid.UUID) (*dto.TodoResponse, error)
-	GetTodos(ctx context.Context) ([]*dto.TodoResponse, error)
+	GetTodoTree(ctx context.Context, todoID uuid.UUID) (*dto.TodoResponse, error)
+	GetTodos(ctx context.Context, listRequest *dto.TodoListRequest) (*dto.TodoListResponse, error)
+	SearchTodos(ctx context.Context, searchRequest *dto.TodoSearchRequest) ([]*dto.TodoSearchResponse, error)
+	GetBoard(ctx context.Context, boardRequest *dto.BoardRequest) (*dto.BoardResponse, error)
 	ChangeContent(ctx context.Context, changeContentRequest *dto.TodoContentChangeRequest) error
 	ChangeStatus(ctx context.Context, changeStatusRequest *dto.TodoStatusChangeRequest) error
+	ChangeSchedule(ctx context.Context, changeScheduleRequest *dto.TodoScheduleChangeRequest) error
+	ChangeProject(ctx context.Context, changeProjectRequest *dto.TodoProjectChangeRequest) error
+	ChangeParent(ctx context.Context, changeParentRequest *dto.TodoParentChangeRequest) error
+	ChangeAssignee(ctx context.Context, changeAssigneeRequest *dto.TodoAssigneeChangeRequest) error
+	ChangeRecurrence(ctx context.Context, changeRecurrenceRequest *dto.TodoRecurrenceChangeRequest) error
+	GetOccurrences(ctx context.Context, todoID uuid.UUID) ([]*dto.TodoOccurrenceResponse, error)
+	GetHistory(ctx context.Context, todoID uuid.UUID) ([]*dto.TodoRevisionResponse, error)
+	RevertTodo(ctx context.Context, todoID, revisionID uuid.UUID) error
+	GetTrash(ctx context.Context) ([]*dto.TodoResponse, error)
+	GetSharedTodos(ctx context.Context) ([]*dto.TodoResponse, error)
+	GetAssignedTodos(ctx context.Context) ([]*dto.TodoResponse, error)
+	RestoreTodo(ctx context.Context, todoID uuid.UUID) error
 	DeleteTodo(ctx context.Context, todoID uuid.UUID) error
+	BatchTodos(ctx context.Context, batchRequest *dto.TodoBatchRequest) (*dto.TodoBatchResponse, error)
+	MoveTodo(ctx context.Context, moveRequest *dto.TodoPositionChangeRequest) error
+	AddBlocker(ctx context.Context, dependencyRequest *dto.TodoDependencyRequest) error
+	RemoveBlocker(ctx context.Context, todoID, blockerID uuid.UUID) error
+	InstantiateTemplate(ctx context.Context,
+		instantiateRequest *dto.TemplateInstantiateRequest) (*dto.TemplateInstantiateResponse, error)
+}
+
+type LabelUseCases interface {
+	CreateLabel(ctx context.Context, labelRequest *dto.LabelCreateRequest) error
+	GetLabel(ctx context.Context, labelID uuid.UUID) (*dto.LabelResponse, error)
+	GetLabels(ctx context.Context) ([]*dto.LabelResponse, error)
+	GetTodoLabels(ctx context.Context, todoID uuid.UUID) ([]*dto.LabelResponse, error)
+	UpdateLabel(ctx context.Context, labelRequest *dto.LabelUpdateRequest) error
+	DeleteLabel(ctx context.Context, labelID uuid.UUID) error
+	AttachLabel(ctx context.Context, attachRequest *dto.LabelAttachRequest) error
+	DetachLabel(ctx context.Context, todoID, labelID uuid.UUID) error
+}
+
+type ProjectUseCases interface {
+	CreateProject(ctx context.Context, projectRequest *dto.ProjectCreateRequest) error
+	GetProject(ctx context.Context, projectID uuid.UUID) (*dto.ProjectResponse, error)
+	GetProjects(ctx context.Context, includeArchived bool) ([]*dto.ProjectResponse, error)
+	UpdateProject(ctx context.Context, projectRequest *dto.ProjectUpdateRequest) error
+	ArchiveProject(ctx context.Context, archiveRequest *dto.ProjectArchiveRequest) error
+	DeleteProject(ctx context.Context, projectID uuid.UUID) error
+}
+
+type TemplateUseCases interface {
+	CreateTemplate(ctx context.Context, templateRequest *dto.TemplateCreateRequest) error
+	GetTemplate(ctx context.Context, templateID uuid.UUID) (*dto.TemplateResponse, error)
+	GetTemplates(ctx context.Context) ([]*dto.TemplateResponse, error)
+	UpdateTemplate(ctx context.Context, templateRequest *dto.TemplateUpdateRequest) error
+	DeleteTemplate(ctx context.Context, templateID uuid.UUID) error
+}
+
+type ShareUseCases interface {
+	ShareTodo(ctx context.Context, shareRequest *dto.ShareRequest) error
+	ShareProject(ctx context.Context, shareRequest *dto.ShareRequest) error
+	GetTodoCollaborators(ctx context.Context, todoID uuid.UUID) ([]*dto.CollaboratorResponse, error)
+	GetProjectCollaborators(ctx context.Context, projectID uuid.UUID) ([]*dto.CollaboratorResponse, error)
+	RevokeTodo(ctx context.Context, todoID, collaboratorID uuid.UUID) error
+	RevokeProject(ctx context.Context, projectID, collaboratorID uuid.UUID) error
+}
+
+type LinkUseCases interface {
+	CreateLink(ctx context.Context, linkRequest *dto.LinkCreateRequest) (*dto.LinkResponse, error)
+	GetLinks(ctx context.Context) ([]*dto.LinkResponse, error)
+	RevokeLink(ctx context.Context, linkID uuid.UUID) error
+	OpenLink(ctx context.Context, publicRequest *dto.PublicTodosRequest) (*dto.PublicTodosResponse, error)
+}
+
+type OrganizationUseCases interface {
+	CreateOrganization(ctx context.Context, organizationRequest *dto.OrganizationCreateRequest) (*dto.OrganizationResponse, error)
+	GetOrganizations(ctx context.Context) ([]*dto.OrganizationResponse, error)
+	GetMembers(ctx context.Context, orgID uuid.UUID) ([]*dto.MemberResponse, error)
+	ChangeMemberRole(ctx context.Context, roleRequest *dto.MemberRoleChangeRequest) error
+	RemoveMember(ctx context.Context, orgID, memberID uuid.UUID) error
+	CheckMembership(ctx context.Context, orgID, userID uuid.UUID) error
+}
+
+type InvitationUseCases interface {
+	Invite(ctx context.Context, invitationRequest *dto.InvitationCreateRequest) (*dto.InvitationResponse, error)
+	GetInvitations(ctx context.Context, orgID uuid.UUID) ([]*dto.InvitationResponse, error)
+	CancelInvitation(ctx context.Context, orgID, invitationID uuid.UUID) error
+	AcceptInvitation(ctx context.Context, acceptRequest *dto.InvitationAcceptRequest) (*dto.InvitationAcceptResponse, error)
+}
+
+type CommentUseCases interface {
+	CreateComment(ctx context.Context, commentRequest *dto.CommentCreateRequest) error
+	GetComments(ctx context.Context, listRequest *dto.CommentListRequest) (*dto.CommentListResponse, error)
+	UpdateComment(ctx context.Context, commentRequest *dto.CommentUpdateRequest) error
+	DeleteComment(ctx context.Context, todoID, commentID uuid.UUID) error
+	GetCommentHistory(ctx context.Context, todoID, commentID uuid.UUID) ([]*dto.CommentEditResponse, error)
+}
+
+type TimeEntryUseCases interface {
+	StartTimer(ctx context.Context, startRequest *dto.TimerStartRequest) (*dto.TimeEntryResponse, error)
+	StopTimer(ctx context.Context) (*dto.TimeEntryResponse, error)
+	GetRunningTimer(ctx context.Context) (*dto.TimeEntryResponse, error)
+	CreateTimeEntry(ctx context.Context, entryRequest *dto.TimeEntryCreateRequest) error
+	GetTodoTime(ctx context.Context, todoID uuid.UUID) (*dto.TodoTimeResponse, error)
+	GetTimeTotals(ctx context.Context, totalsRequest *dto.TimeTotalsRequest) ([]*dto.TimeTotalResponse, error)
+}
+
+type ReminderUseCases interface {
+	Run(ctx context.Context)
+	DispatchDue(ctx context.Context) (int, error)
+}
+
+type PurgeUseCases interface {
+	Run(ctx context.Context)
+	Purge(ctx context.Context) (int64, error)
+}
+
+type RebalanceUseCases interface {
+	Run(ctx context.Context)
+	Rebalance(ctx context.Context) (int, error)
+}
+
+type IdempotencyUseCases interface {
+	Run(ctx context.Context)
+	Begin(ctx context.Context, scope, key, fingerprint string) (*dto.IdempotentResponse, error)
+	Complete(ctx context.Context, scope, key string, response *dto.IdempotentResponse) error
+	Release(ctx context.Context, scope, key string) error
+}
+
+type WorkflowUseCases interface {
+	GetWorkflow(ctx context.Context) *dto.WorkflowResponse
 }
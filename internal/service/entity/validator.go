@@ -38,12 +38,8 @@ func (v *Validator) UserChangePasswordRequestValidate(userChangeReguest *dto.Cha
 }
 
 func (v *Validator) TodoCreateRequestValidate(todoRequest *dto.TodoCreateRequest) error {
-	if todoRequest.Status == "done" {
-		return ErrInvalidTodoStatus
-	}
-
-	if todoRequest.Status == "" {
-		todoRequest.Status = "todo"
+	if todoRequest.DueAt != nil && todoRequest.RemindAt != nil && todoRequest.RemindAt.After(*todoRequest.DueAt) {
+		return ErrInvalidReminder
 	}
 
 	return v.Validator.Struct(todoRequest)
@@ -56,3 +52,136 @@ func (v *Validator) TodoContentChangeRequest(todoChangeRequest *dto.TodoContentC
 func (v *Validator) TodoStatusChangeRequest(todoChangeRequest *dto.TodoStatusChangeRequest) error {
 	return v.Validator.Struct(todoChangeRequest)
 }
+
+func (v *Validator) TodoBatchRequestValidate(batchRequest *dto.TodoBatchRequest) error {
+	return v.Validator.Struct(batchRequest)
+}
+
+func (v *Validator) TodoListRequestValidate(listRequest *dto.TodoListRequest) error {
+	return v.Validator.Struct(listRequest)
+}
+
+func (v *Validator) BoardRequestValidate(boardRequest *dto.BoardRequest) error {
+	return v.Validator.Struct(boardRequest)
+}
+
+func (v *Validator) TodoSearchRequestValidate(searchRequest *dto.TodoSearchRequest) error {
+	return v.Validator.Struct(searchRequest)
+}
+
+func (v *Validator) TodoScheduleChangeRequest(todoChangeRequest *dto.TodoScheduleChangeRequest) error {
+	if todoChangeRequest.DueAt != nil && todoChangeRequest.RemindAt != nil &&
+		todoChangeRequest.RemindAt.After(*todoChangeRequest.DueAt) {
+		return ErrInvalidReminder
+	}
+
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) LabelCreateRequestValidate(labelRequest *dto.LabelCreateRequest) error {
+	return v.Validator.Struct(labelRequest)
+}
+
+func (v *Validator) LabelUpdateRequestValidate(labelRequest *dto.LabelUpdateRequest) error {
+	return v.Validator.Struct(labelRequest)
+}
+
+func (v *Validator) LabelAttachRequestValidate(labelRequest *dto.LabelAttachRequest) error {
+	return v.Validator.Struct(labelRequest)
+}
+
+func (v *Validator) ProjectCreateRequestValidate(projectRequest *dto.ProjectCreateRequest) error {
+	return v.Validator.Struct(projectRequest)
+}
+
+func (v *Validator) ProjectUpdateRequestValidate(projectRequest *dto.ProjectUpdateRequest) error {
+	return v.Validator.Struct(projectRequest)
+}
+
+func (v *Validator) TodoProjectChangeRequest(todoChangeRequest *dto.TodoProjectChangeRequest) error {
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) TodoAssigneeChangeRequest(todoChangeRequest *dto.TodoAssigneeChangeRequest) error {
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) TodoParentChangeRequest(todoChangeRequest *dto.TodoParentChangeRequest) error {
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) TodoPositionChangeRequest(todoChangeRequest *dto.TodoPositionChangeRequest) error {
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) TodoDependencyRequest(dependencyRequest *dto.TodoDependencyRequest) error {
+	return v.Validator.Struct(dependencyRequest)
+}
+
+func (v *Validator) TodoRecurrenceChangeRequest(todoChangeRequest *dto.TodoRecurrenceChangeRequest) error {
+	return v.Validator.Struct(todoChangeRequest)
+}
+
+func (v *Validator) TimerStartRequestValidate(startRequest *dto.TimerStartRequest) error {
+	return v.Validator.Struct(startRequest)
+}
+
+func (v *Validator) TimeEntryCreateRequestValidate(entryRequest *dto.TimeEntryCreateRequest) error {
+	return v.Validator.Struct(entryRequest)
+}
+
+func (v *Validator) TimeTotalsRequestValidate(totalsRequest *dto.TimeTotalsRequest) error {
+	return v.Validator.Struct(totalsRequest)
+}
+
+func (v *Validator) TemplateCreateRequestValidate(templateRequest *dto.TemplateCreateRequest) error {
+	return v.Validator.Struct(templateRequest)
+}
+
+func (v *Validator) TemplateUpdateRequestValidate(templateRequest *dto.TemplateUpdateRequest) error {
+	return v.Validator.Struct(templateRequest)
+}
+
+func (v *Validator) TemplateInstantiateRequestValidate(instantiateRequest *dto.TemplateInstantiateRequest) error {
+	return v.Validator.Struct(instantiateRequest)
+}
+
+func (v *Validator) CommentCreateRequestValidate(commentRequest *dto.CommentCreateRequest) error {
+	return v.Validator.Struct(commentRequest)
+}
+
+func (v *Validator) CommentUpdateRequestValidate(commentRequest *dto.CommentUpdateRequest) error {
+	return v.Validator.Struct(commentRequest)
+}
+
+func (v *Validator) CommentListRequestValidate(listRequest *dto.CommentListRequest) error {
+	return v.Validator.Struct(listRequest)
+}
+
+func (v *Validator) ShareRequestValidate(shareRequest *dto.ShareRequest) error {
+	return v.Validator.Struct(shareRequest)
+}
+
+func (v *Validator) LinkCreateRequestValidate(linkRequest *dto.LinkCreateRequest) error {
+	return v.Validator.Struct(linkRequest)
+}
+
+func (v *Validator) PublicTodosRequestValidate(publicRequest *dto.PublicTodosRequest) error {
+	return v.Validator.Struct(publicRequest)
+}
+
+func (v *Validator) OrganizationCreateRequestValidate(organizationRequest *dto.OrganizationCreateRequest) error {
+	return v.Validator.Struct(organizationRequest)
+}
+
+func (v *Validator) MemberRoleChangeRequestValidate(roleRequest *dto.MemberRoleChangeRequest) error {
+	return v.Validator.Struct(roleRequest)
+}
+
+func (v *Validator) InvitationCreateRequestValidate(invitationRequest *dto.InvitationCreateRequest) error {
+	return v.Validator.Struct(invitationRequest)
+}
+
+func (v *Validator) InvitationAcceptRequestValidate(acceptRequest *dto.InvitationAcceptRequest) error {
+	return v.Validator.Struct(acceptRequest)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/logger"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type idempotencyService struct {
+	idempotencyRepo psql.IdempotencyRepository
+	logger          *logger.Logger
+	interval        time.Duration
+	ttl             time.Duration
+}
+
+func NewIdempotencyService(ir psql.IdempotencyRepository, logger *logger.Logger,
+	cfg *config.IdempotencyConfig) se.IdempotencyUseCases {
+	return &idempotencyService{
+		idempotencyRepo: ir,
+		logger:          logger,
+		interval:        cfg.Interval,
+		ttl:             cfg.TTL,
+	}
+}
+
+func (is *idempotencyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(is.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := is.idempotencyRepo.DeleteExpired(ctx, time.Now().Add(-is.ttl)); err != nil {
+			is.logger.Logger.Error("failed to delete expired idempotency keys",
+				"operation", "delete expired idempotency keys",
+				"error", err.Error(),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (is *idempotencyService) Begin(ctx context.Context, scope, key, fingerprint string) (*dto.IdempotentResponse, error) {
+	record := &re.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+
+	err := is.idempotencyRepo.Create(ctx, record)
+	if err == nil {
+		return nil, nil
+	}
+
+	if !errors.Is(err, psql.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	stored, err := is.idempotencyRepo.Get(ctx, scope, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.CreatedAt.Before(time.Now().Add(-is.ttl)) {
+		if err := is.idempotencyRepo.Delete(ctx, scope, key); err != nil {
+			return nil, err
+		}
+
+		if err := is.idempotencyRepo.Create(ctx, record); err != nil {
+			if errors.Is(err, psql.ErrAlreadyExists) {
+				return nil, se.ErrIdempotencyInProgress
+			}
+
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, se.ErrIdempotencyKeyReused
+	}
+
+	if stored.CompletedAt == nil || stored.StatusCode == nil {
+		return nil, se.ErrIdempotencyInProgress
+	}
+
+	response := &dto.IdempotentResponse{
+		StatusCode: *stored.StatusCode,
+		Header:     http.Header{},
+		Body:       stored.ResponseBody,
+	}
+
+	if stored.ResponseHeaders != nil {
+		response.Header = http.Header(*stored.ResponseHeaders)
+	}
+
+	return response, nil
+}
+
+func (is *idempotencyService) Complete(ctx context.Context, scope, key string, response *dto.IdempotentResponse) error {
+	record := &re.IdempotencyKey{
+		Scope:        scope,
+		Key:          key,
+		StatusCode:   &response.StatusCode,
+		ResponseBody: response.Body,
+	}
+
+	if len(response.Header) > 0 {
+		headers := re.ResponseHeaders(response.Header)
+		record.ResponseHeaders = &headers
+	}
+
+	return is.idempotencyRepo.Complete(ctx, record)
+}
+
+func (is *idempotencyService) Release(ctx context.Context, scope, key string) error {
+	return is.idempotencyRepo.Delete(ctx, scope, key)
+}
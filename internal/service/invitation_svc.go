@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/mailer"
+)
+
+const invitationTokenBytes int = 32
+
+type invitationService struct {
+	invitationRepo   psql.InvitationRepository
+	organizationRepo psql.OrganizationRepository
+	userRepo         psql.UserRepository
+	authService      se.AuthUseCases
+	mailer           mailer.Mailer
+	transactor       psql.Transactor
+	validator        *se.Validator
+	cfg              *config.InvitationsConfig
+}
+
+func NewInvitationService(ir psql.InvitationRepository, or psql.OrganizationRepository, ur psql.UserRepository,
+	as se.AuthUseCases, m mailer.Mailer, tx psql.Transactor, cfg *config.InvitationsConfig) se.InvitationUseCases {
+	v := se.InitValidator()
+
+	return &invitationService{
+		invitationRepo:   ir,
+		organizationRepo: or,
+		userRepo:         ur,
+		authService:      as,
+		mailer:           m,
+		transactor:       tx,
+		validator:        v,
+		cfg:              cfg,
+	}
+}
+
+func (is *invitationService) Invite(ctx context.Context,
+	invitationRequest *dto.InvitationCreateRequest) (*dto.InvitationResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := is.validator.InvitationCreateRequestValidate(invitationRequest); err != nil {
+		return nil, err
+	}
+
+	if err := is.checkManager(ctx, invitationRequest.OrgID, userID); err != nil {
+		return nil, err
+	}
+
+	if user, err := is.userRepo.GetByEmail(ctx, invitationRequest.Email); err == nil {
+		if _, err := is.organizationRepo.GetMembership(ctx, invitationRequest.OrgID, user.ID); err == nil {
+			return nil, se.ErrAlreadyOrgMember
+		}
+	}
+
+	organization, err := is.organizationRepo.GetByID(ctx, invitationRequest.OrgID)
+	if err != nil {
+		return nil, se.ErrInvalidOrgID
+	}
+
+	token, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := &re.Invitation{
+		ID:        uuid.New(),
+		OrgID:     invitationRequest.OrgID,
+		Email:     invitationRequest.Email,
+		Role:      invitationRequest.Role,
+		TokenHash: hashInvitationToken(token),
+		InvitedBy: &userID,
+		ExpiresAt: time.Now().Add(is.cfg.TTL),
+	}
+
+	if err := is.invitationRepo.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	sendErr := is.mailer.Send(ctx, is.invitationMessage(invitation, organization, token))
+	if sendErr != nil {
+		if err := is.invitationRepo.Delete(context.WithoutCancel(ctx), invitation.ID, invitation.OrgID); err != nil {
+			return nil, err
+		}
+
+		return nil, sendErr
+	}
+
+	return invitationToResponse(invitation), nil
+}
+
+func (is *invitationService) GetInvitations(ctx context.Context, orgID uuid.UUID) ([]*dto.InvitationResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return nil, se.ErrInvalidOrgID
+	}
+
+	if err := is.checkManager(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := is.invitationRepo.GetPendingByOrgID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		response = append(response, invitationToResponse(invitation))
+	}
+
+	return response, nil
+}
+
+func (is *invitationService) CancelInvitation(ctx context.Context, orgID, invitationID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return se.ErrInvalidOrgID
+	}
+
+	if invitationID == uuid.Nil {
+		return se.ErrInvalidInvitationID
+	}
+
+	if err := is.checkManager(ctx, orgID, userID); err != nil {
+		return err
+	}
+
+	if err := is.invitationRepo.Delete(ctx, invitationID, orgID); err != nil {
+		return se.ErrInvalidInvitationID
+	}
+
+	return nil
+}
+
+func (is *invitationService) AcceptInvitation(ctx context.Context,
+	acceptRequest *dto.InvitationAcceptRequest) (*dto.InvitationAcceptResponse, error) {
+	if err := is.validator.InvitationAcceptRequestValidate(acceptRequest); err != nil {
+		return nil, err
+	}
+
+	response := &dto.InvitationAcceptResponse{}
+
+	err := is.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		invitation, err := is.invitationRepo.GetPendingForUpdate(ctx, hashInvitationToken(acceptRequest.Token))
+		if err != nil {
+			return se.ErrInvalidInvitation
+		}
+
+		user, err := is.userRepo.GetByEmail(ctx, invitation.Email)
+		if err != nil {
+			if acceptRequest.Name == "" || acceptRequest.Password == "" {
+				return se.ErrAccountRequired
+			}
+
+			err := is.authService.Register(ctx, &dto.UserRegisterRequest{
+				Name:     acceptRequest.Name,
+				Email:    invitation.Email,
+				Password: acceptRequest.Password,
+			})
+			if err != nil {
+				return err
+			}
+
+			user, err = is.userRepo.GetByEmail(ctx, invitation.Email)
+			if err != nil {
+				return err
+			}
+
+			response.Registered = true
+		}
+
+		response.OrgID = invitation.OrgID
+		response.Email = invitation.Email
+		response.Role = invitation.Role
+
+		if membership, err := is.organizationRepo.GetMembership(ctx, invitation.OrgID, user.ID); err == nil {
+			response.Role = membership.Role
+		} else {
+			err = is.organizationRepo.AddMember(ctx, &re.Membership{
+				OrgID:  invitation.OrgID,
+				UserID: user.ID,
+				Role:   invitation.Role,
+			})
+			if errors.Is(err, psql.ErrAlreadyExists) {
+				return se.ErrAlreadyOrgMember
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := is.invitationRepo.Accept(ctx, invitation.ID); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (is *invitationService) checkManager(ctx context.Context, orgID, userID uuid.UUID) error {
+	membership, err := is.organizationRepo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return se.ErrNotOrgMember
+	}
+
+	if !psql.MemberRole(membership.Role).CanManage() {
+		return se.ErrOrgForbidden
+	}
+
+	return nil
+}
+
+func (is *invitationService) invitationMessage(invitation *re.Invitation, organization *re.Organization,
+	token string) *mailer.Message {
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi,\n\nyou have been invited to join %s as %s.\n", organization.Name, invitation.Role)
+	fmt.Fprintf(&body, "Accept the invitation: %s%s\n", is.cfg.AcceptURL, token)
+	fmt.Fprintf(&body, "The invitation expires at %s.\n", invitation.ExpiresAt.Format(time.RFC1123Z))
+
+	return &mailer.Message{
+		To:      []string{invitation.Email},
+		Subject: mailer.HeaderText("Invitation to " + organization.Name),
+		Body:    body.String(),
+	}
+}
+
+func invitationToResponse(invitation *re.Invitation) *dto.InvitationResponse {
+	return &dto.InvitationResponse{
+		ID:        invitation.ID,
+		OrgID:     invitation.OrgID,
+		Email:     invitation.Email,
+		Role:      invitation.Role,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
+
+func newInvitationToken() (string, error) {
+	raw := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("generate invitation token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
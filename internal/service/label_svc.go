@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type labelService struct {
+	labelRepo psql.LabelRepository
+	todoRepo  psql.TodoRepository
+	validator *se.Validator
+}
+
+func NewLabelService(lr psql.LabelRepository, tr psql.TodoRepository) se.LabelUseCases {
+	v := se.InitValidator()
+
+	return &labelService{
+		labelRepo: lr,
+		todoRepo:  tr,
+		validator: v,
+	}
+}
+
+func (ls *labelService) CreateLabel(ctx context.Context, labelRequest *dto.LabelCreateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ls.validator.LabelCreateRequestValidate(labelRequest); err != nil {
+		return err
+	}
+
+	label := &re.Label{
+		ID:     uuid.New(),
+		UserID: userID,
+		Name:   labelRequest.Name,
+		Color:  labelRequest.Color,
+	}
+
+	if err := ls.labelRepo.Create(ctx, label); err != nil {
+		if errors.Is(err, psql.ErrAlreadyExists) {
+			return se.ErrLabelExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (ls *labelService) GetLabel(ctx context.Context, labelID uuid.UUID) (*dto.LabelResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if labelID == uuid.Nil {
+		return nil, se.ErrInvalidLabelID
+	}
+
+	label, err := ls.labelRepo.GetByID(ctx, labelID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls.labelToResponse(label), nil
+}
+
+func (ls *labelService) GetLabels(ctx context.Context) ([]*dto.LabelResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	labels, err := ls.labelRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls.labelsToResponse(labels), nil
+}
+
+func (ls *labelService) GetTodoLabels(ctx context.Context, todoID uuid.UUID) ([]*dto.LabelResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	labels, err := ls.labelRepo.GetByTodoID(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls.labelsToResponse(labels), nil
+}
+
+func (ls *labelService) labelsToResponse(labels []*re.Label) []*dto.LabelResponse {
+	response := make([]*dto.LabelResponse, 0, len(labels))
+	for _, label := range labels {
+		response = append(response, ls.labelToResponse(label))
+	}
+
+	return response
+}
+
+func (ls *labelService) labelToResponse(label *re.Label) *dto.LabelResponse {
+	return &dto.LabelResponse{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: label.CreatedAt,
+		UpdatedAt: label.UpdatedAt,
+	}
+}
+
+func (ls *labelService) UpdateLabel(ctx context.Context, labelRequest *dto.LabelUpdateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ls.validator.LabelUpdateRequestValidate(labelRequest); err != nil {
+		return err
+	}
+
+	label := &re.Label{
+		ID:     labelRequest.LabelID,
+		UserID: userID,
+		Name:   labelRequest.Name,
+		Color:  labelRequest.Color,
+	}
+
+	if err := ls.labelRepo.Update(ctx, label); err != nil {
+		if errors.Is(err, psql.ErrAlreadyExists) {
+			return se.ErrLabelExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (ls *labelService) DeleteLabel(ctx context.Context, labelID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if labelID == uuid.Nil {
+		return se.ErrInvalidLabelID
+	}
+
+	return ls.labelRepo.Delete(ctx, labelID, userID)
+}
+
+func (ls *labelService) AttachLabel(ctx context.Context, attachRequest *dto.LabelAttachRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ls.validator.LabelAttachRequestValidate(attachRequest); err != nil {
+		return err
+	}
+
+	if _, err := ls.todoRepo.GetTodoByUserID(ctx, attachRequest.TodoID, userID); err != nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if _, err := ls.labelRepo.GetByID(ctx, attachRequest.LabelID, userID); err != nil {
+		return se.ErrInvalidLabelID
+	}
+
+	return ls.labelRepo.Attach(ctx, attachRequest.TodoID, attachRequest.LabelID, userID)
+}
+
+func (ls *labelService) DetachLabel(ctx context.Context, todoID, labelID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if labelID == uuid.Nil {
+		return se.ErrInvalidLabelID
+	}
+
+	return ls.labelRepo.Detach(ctx, todoID, labelID, userID)
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/jwtoken"
+)
+
+const (
+	linkScopeTodo string = "todo"
+	linkScopeList string = "list"
+
+	publicTodosPath string = "/public/todos/"
+)
+
+type linkService struct {
+	linkRepo       psql.LinkRepository
+	todoRepo       psql.TodoRepository
+	grantRepo      psql.GrantRepository
+	todoService    se.TodoUseCases
+	validator      *se.Validator
+	tokenValidator jwtoken.TokenValidator
+	cfg            *config.PublicLinksConfig
+}
+
+func NewLinkService(lr psql.LinkRepository, tr psql.TodoRepository, gr psql.GrantRepository, ts se.TodoUseCases,
+	cfg *config.PublicLinksConfig) se.LinkUseCases {
+	v := se.InitValidator()
+
+	return &linkService{
+		linkRepo:       lr,
+		todoRepo:       tr,
+		grantRepo:      gr,
+		todoService:    ts,
+		validator:      v,
+		tokenValidator: jwtoken.NewTokenValidator(cfg.Secret),
+		cfg:            cfg,
+	}
+}
+
+func (ls *linkService) CreateLink(ctx context.Context, linkRequest *dto.LinkCreateRequest) (*dto.LinkResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := ls.validator.LinkCreateRequestValidate(linkRequest); err != nil {
+		return nil, err
+	}
+
+	if (linkRequest.TodoID == nil) == (linkRequest.Filter == nil) {
+		return nil, se.ErrInvalidLinkTarget
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ls.cfg.DefaultTTL)
+	if linkRequest.ExpiresAt != nil {
+		if !linkRequest.ExpiresAt.After(now) || linkRequest.ExpiresAt.After(now.Add(ls.cfg.MaxTTL)) {
+			return nil, se.ErrInvalidLinkExpiry
+		}
+
+		expiresAt = *linkRequest.ExpiresAt
+	}
+
+	link := &re.ShareLink{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TodoID:    linkRequest.TodoID,
+		ExpiresAt: expiresAt,
+	}
+
+	if link.TodoID != nil {
+		if _, err := manageTodo(ctx, ls.todoRepo, ls.grantRepo, *link.TodoID, userID); err != nil {
+			return nil, err
+		}
+	} else {
+		link.Filter = &re.LinkFilter{
+			ProjectID:       linkRequest.Filter.ProjectID,
+			IncludeArchived: linkRequest.Filter.IncludeArchived,
+			Statuses:        linkRequest.Filter.Statuses,
+			Overdue:         linkRequest.Filter.Overdue,
+			LabelIDs:        linkRequest.Filter.LabelIDs,
+			LabelsMode:      linkRequest.Filter.LabelsMode,
+			Sort:            linkRequest.Filter.Sort,
+		}
+	}
+
+	if err := ls.linkRepo.Create(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return ls.linkToResponse(link)
+}
+
+func (ls *linkService) GetLinks(ctx context.Context) ([]*dto.LinkResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	links, err := ls.linkRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.LinkResponse, 0, len(links))
+	for _, link := range links {
+		linkResponse, err := ls.linkToResponse(link)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, linkResponse)
+	}
+
+	return response, nil
+}
+
+func (ls *linkService) RevokeLink(ctx context.Context, linkID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if linkID == uuid.Nil {
+		return se.ErrInvalidLinkID
+	}
+
+	if err := ls.linkRepo.Revoke(ctx, linkID, userID); err != nil {
+		return se.ErrInvalidLinkID
+	}
+
+	return nil
+}
+
+func (ls *linkService) OpenLink(ctx context.Context,
+	publicRequest *dto.PublicTodosRequest) (*dto.PublicTodosResponse, error) {
+	if err := ls.validator.PublicTodosRequestValidate(publicRequest); err != nil {
+		return nil, err
+	}
+
+	claims, err := ls.tokenValidator.ValidateTokenWithClaims(publicRequest.Token)
+	if err != nil {
+		return nil, se.ErrInvalidLinkToken
+	}
+
+	rawLinkID, _ := claims["linkID"].(string)
+	scope, _ := claims["scope"].(string)
+
+	linkID, err := uuid.Parse(rawLinkID)
+	if err != nil {
+		return nil, se.ErrInvalidLinkToken
+	}
+
+	link, err := ls.linkRepo.GetActive(ctx, linkID)
+	if err != nil {
+		return nil, se.ErrInvalidLinkToken
+	}
+
+	if linkScope(link) != scope {
+		return nil, se.ErrInvalidLinkToken
+	}
+
+	ctx = context.WithValue(ctx, "userID", link.UserID.String())
+	if link.OrgID != nil {
+		ctx = context.WithValue(ctx, "orgID", link.OrgID.String())
+	}
+
+	response := &dto.PublicTodosResponse{
+		Scope:     scope,
+		ExpiresAt: link.ExpiresAt,
+	}
+
+	if link.TodoID != nil {
+		todo, err := ls.todoService.GetTodoTree(ctx, *link.TodoID)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Todo = todo
+
+		return response, nil
+	}
+
+	list, err := ls.todoService.GetTodos(ctx, &dto.TodoListRequest{
+		ProjectID:       link.Filter.ProjectID,
+		IncludeArchived: link.Filter.IncludeArchived,
+		Statuses:        link.Filter.Statuses,
+		Overdue:         link.Filter.Overdue,
+		LabelIDs:        link.Filter.LabelIDs,
+		LabelsMode:      link.Filter.LabelsMode,
+		Sort:            link.Filter.Sort,
+		Cursor:          publicRequest.Cursor,
+		Limit:           publicRequest.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Items = list.Items
+	response.NextCursor = list.NextCursor
+
+	return response, nil
+}
+
+func (ls *linkService) linkToResponse(link *re.ShareLink) (*dto.LinkResponse, error) {
+	response := &dto.LinkResponse{
+		ID:        link.ID,
+		Scope:     linkScope(link),
+		TodoID:    link.TodoID,
+		ExpiresAt: link.ExpiresAt,
+		RevokedAt: link.RevokedAt,
+		CreatedAt: link.CreatedAt,
+	}
+
+	if link.Filter != nil {
+		response.Filter = &dto.LinkFilter{
+			ProjectID:       link.Filter.ProjectID,
+			IncludeArchived: link.Filter.IncludeArchived,
+			Statuses:        link.Filter.Statuses,
+			Overdue:         link.Filter.Overdue,
+			LabelIDs:        link.Filter.LabelIDs,
+			LabelsMode:      link.Filter.LabelsMode,
+			Sort:            link.Filter.Sort,
+		}
+	}
+
+	if link.RevokedAt != nil || !link.ExpiresAt.After(time.Now()) {
+		return response, nil
+	}
+
+	token, err := ls.signLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Token = token
+	response.URL = strings.TrimRight(ls.cfg.BaseURL, "/") + publicTodosPath + token
+
+	return response, nil
+}
+
+func (ls *linkService) signLink(link *re.ShareLink) (string, error) {
+	claims := jwt.MapClaims{
+		"linkID": link.ID.String(),
+		"scope":  linkScope(link),
+		"exp":    link.ExpiresAt.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	tokenString, err := token.SignedString([]byte(ls.cfg.Secret))
+	if err != nil {
+		return "", fmt.Errorf("sign link: %w", err)
+	}
+
+	return tokenString, nil
+}
+
+func linkScope(link *re.ShareLink) string {
+	if link.TodoID != nil {
+		return linkScopeTodo
+	}
+
+	return linkScopeList
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type organizationService struct {
+	organizationRepo psql.OrganizationRepository
+	transactor       psql.Transactor
+	validator        *se.Validator
+}
+
+func NewOrganizationService(or psql.OrganizationRepository, tx psql.Transactor) se.OrganizationUseCases {
+	v := se.InitValidator()
+
+	return &organizationService{
+		organizationRepo: or,
+		transactor:       tx,
+		validator:        v,
+	}
+}
+
+func (os *organizationService) CreateOrganization(ctx context.Context,
+	organizationRequest *dto.OrganizationCreateRequest) (*dto.OrganizationResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := os.validator.OrganizationCreateRequestValidate(organizationRequest); err != nil {
+		return nil, err
+	}
+
+	organization := &re.Organization{
+		ID:   uuid.New(),
+		Name: organizationRequest.Name,
+	}
+
+	membership := &re.Membership{
+		OrgID:  organization.ID,
+		UserID: userID,
+		Role:   string(psql.MemberOwner),
+	}
+
+	err := os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := os.organizationRepo.Create(ctx, organization); err != nil {
+			return err
+		}
+
+		return os.organizationRepo.AddMember(ctx, membership)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Role:      membership.Role,
+		CreatedAt: organization.CreatedAt,
+	}
+
+	return response, nil
+}
+
+func (os *organizationService) GetOrganizations(ctx context.Context) ([]*dto.OrganizationResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	organizations, err := os.organizationRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeOrgID := ctxOrgID(ctx)
+
+	response := make([]*dto.OrganizationResponse, 0, len(organizations))
+	for _, organization := range organizations {
+		response = append(response, &dto.OrganizationResponse{
+			ID:        organization.OrgID,
+			Name:      organization.Name,
+			Role:      organization.Role,
+			Active:    organization.OrgID.String() == activeOrgID,
+			CreatedAt: organization.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (os *organizationService) GetMembers(ctx context.Context, orgID uuid.UUID) ([]*dto.MemberResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return nil, se.ErrInvalidOrgID
+	}
+
+	if _, err := os.organizationRepo.GetMembership(ctx, orgID, userID); err != nil {
+		return nil, se.ErrNotOrgMember
+	}
+
+	members, err := os.organizationRepo.GetMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.MemberResponse, 0, len(members))
+	for _, member := range members {
+		response = append(response, &dto.MemberResponse{
+			UserID:   member.UserID,
+			Name:     member.Name,
+			Email:    member.Email,
+			Role:     member.Role,
+			JoinedAt: member.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (os *organizationService) ChangeMemberRole(ctx context.Context, roleRequest *dto.MemberRoleChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := os.validator.MemberRoleChangeRequestValidate(roleRequest); err != nil {
+		return err
+	}
+
+	return os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		memberships, err := os.organizationRepo.GetMembershipsForUpdate(ctx, roleRequest.OrgID)
+		if err != nil {
+			return err
+		}
+
+		actor, target, owners := findMemberships(memberships, userID, roleRequest.MemberID)
+		if actor == nil {
+			return se.ErrNotOrgMember
+		}
+
+		if target == nil {
+			return se.ErrInvalidMemberID
+		}
+
+		if !psql.MemberRole(actor.Role).CanManage() {
+			return se.ErrOrgForbidden
+		}
+
+		promotesOwner := target.Role == string(psql.MemberOwner) || roleRequest.Role == string(psql.MemberOwner)
+		if promotesOwner && actor.Role != string(psql.MemberOwner) {
+			return se.ErrOrgForbidden
+		}
+
+		if target.Role == string(psql.MemberOwner) && roleRequest.Role != string(psql.MemberOwner) && owners == 1 {
+			return se.ErrLastOrgOwner
+		}
+
+		if target.Role == roleRequest.Role {
+			return nil
+		}
+
+		return os.organizationRepo.UpdateRole(ctx, roleRequest.OrgID, roleRequest.MemberID, roleRequest.Role)
+	})
+}
+
+func (os *organizationService) RemoveMember(ctx context.Context, orgID, memberID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return se.ErrInvalidOrgID
+	}
+
+	if memberID == uuid.Nil {
+		return se.ErrInvalidMemberID
+	}
+
+	return os.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		memberships, err := os.organizationRepo.GetMembershipsForUpdate(ctx, orgID)
+		if err != nil {
+			return err
+		}
+
+		actor, target, owners := findMemberships(memberships, userID, memberID)
+		if actor == nil {
+			return se.ErrNotOrgMember
+		}
+
+		if target == nil {
+			return se.ErrInvalidMemberID
+		}
+
+		if memberID != userID {
+			if !psql.MemberRole(actor.Role).CanManage() {
+				return se.ErrOrgForbidden
+			}
+
+			if target.Role == string(psql.MemberOwner) && actor.Role != string(psql.MemberOwner) {
+				return se.ErrOrgForbidden
+			}
+		}
+
+		if target.Role == string(psql.MemberOwner) && owners == 1 {
+			return se.ErrLastOrgOwner
+		}
+
+		return os.organizationRepo.RemoveMember(ctx, orgID, memberID)
+	})
+}
+
+func (os *organizationService) CheckMembership(ctx context.Context, orgID, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if orgID == uuid.Nil {
+		return se.ErrInvalidOrgID
+	}
+
+	if _, err := os.organizationRepo.GetMembership(ctx, orgID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return se.ErrNotOrgMember
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func findMemberships(memberships []*re.Membership, userID, memberID uuid.UUID) (*re.Membership, *re.Membership, int) {
+	var actor, target *re.Membership
+	owners := 0
+
+	for _, membership := range memberships {
+		if membership.UserID == userID {
+			actor = membership
+		}
+
+		if membership.UserID == memberID {
+			target = membership
+		}
+
+		if membership.Role == string(psql.MemberOwner) {
+			owners++
+		}
+	}
+
+	return actor, target, owners
+}
+
+func ctxOrgID(ctx context.Context) string {
+	orgID, _ := ctx.Value("orgID").(string)
+
+	return orgID
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type projectService struct {
+	projectRepo psql.ProjectRepository
+	todoRepo    psql.TodoRepository
+	transactor  psql.Transactor
+	validator   *se.Validator
+}
+
+func NewProjectService(pr psql.ProjectRepository, tr psql.TodoRepository, tx psql.Transactor) se.ProjectUseCases {
+	v := se.InitValidator()
+
+	return &projectService{
+		projectRepo: pr,
+		todoRepo:    tr,
+		transactor:  tx,
+		validator:   v,
+	}
+}
+
+func (ps *projectService) CreateProject(ctx context.Context, projectRequest *dto.ProjectCreateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ps.validator.ProjectCreateRequestValidate(projectRequest); err != nil {
+		return err
+	}
+
+	project := &re.Project{
+		ID:       uuid.New(),
+		UserID:   userID,
+		Name:     projectRequest.Name,
+		Color:    projectRequest.Color,
+		Position: projectRequest.Position,
+	}
+
+	return ps.projectRepo.Create(ctx, project)
+}
+
+func (ps *projectService) GetProject(ctx context.Context, projectID uuid.UUID) (*dto.ProjectResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if projectID == uuid.Nil {
+		return nil, se.ErrInvalidProjectID
+	}
+
+	project, err := ps.projectRepo.GetByID(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ps.projectToResponse(project), nil
+}
+
+func (ps *projectService) GetProjects(ctx context.Context, includeArchived bool) ([]*dto.ProjectResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	projects, err := ps.projectRepo.GetByUserID(ctx, userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.ProjectResponse, 0, len(projects))
+	for _, project := range projects {
+		response = append(response, ps.projectToResponse(project))
+	}
+
+	return response, nil
+}
+
+func (ps *projectService) projectToResponse(project *re.Project) *dto.ProjectResponse {
+	return &dto.ProjectResponse{
+		ID:        project.ID,
+		Name:      project.Name,
+		Color:     project.Color,
+		Archived:  project.Archived,
+		Position:  project.Position,
+		CreatedAt: project.CreatedAt,
+		UpdatedAt: project.UpdatedAt,
+	}
+}
+
+func (ps *projectService) UpdateProject(ctx context.Context, projectRequest *dto.ProjectUpdateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ps.validator.ProjectUpdateRequestValidate(projectRequest); err != nil {
+		return err
+	}
+
+	project, err := ps.projectRepo.GetByID(ctx, projectRequest.ProjectID, userID)
+	if err != nil {
+		return err
+	}
+
+	if projectRequest.Name != nil {
+		project.Name = *projectRequest.Name
+	}
+
+	if projectRequest.Color != nil {
+		project.Color = *projectRequest.Color
+	}
+
+	if projectRequest.Position != nil {
+		project.Position = *projectRequest.Position
+	}
+
+	return ps.projectRepo.Update(ctx, project)
+}
+
+func (ps *projectService) ArchiveProject(ctx context.Context, archiveRequest *dto.ProjectArchiveRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if archiveRequest.ProjectID == uuid.Nil {
+		return se.ErrInvalidProjectID
+	}
+
+	return ps.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := ps.projectRepo.SetArchived(ctx, archiveRequest.Archived, archiveRequest.ProjectID, userID); err != nil {
+			return err
+		}
+
+		return ps.todoRepo.SetArchivedByProject(ctx, archiveRequest.Archived, archiveRequest.ProjectID, userID)
+	})
+}
+
+func (ps *projectService) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if projectID == uuid.Nil {
+		return se.ErrInvalidProjectID
+	}
+
+	return ps.projectRepo.Delete(ctx, projectID, userID)
+}
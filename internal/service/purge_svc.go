@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type purgeService struct {
+	todoRepo  psql.TodoRepository
+	userRepo  psql.UserRepository
+	logger    *logger.Logger
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewPurgeService(tr psql.TodoRepository, ur psql.UserRepository, logger *logger.Logger,
+	cfg *config.TrashConfig) se.PurgeUseCases {
+	return &purgeService{
+		todoRepo:  tr,
+		userRepo:  ur,
+		logger:    logger,
+		interval:  cfg.Interval,
+		retention: cfg.Retention,
+	}
+}
+
+func (ps *purgeService) Run(ctx context.Context) {
+	ticker := time.NewTicker(ps.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := ps.Purge(ctx); err != nil {
+			ps.logger.Logger.Error("failed to purge trash",
+				"operation", "purge trash",
+				"error", err.Error(),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ps *purgeService) Purge(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-ps.retention)
+
+	todos, err := ps.todoRepo.Purge(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	users, err := ps.userRepo.Purge(ctx, before)
+	if err != nil {
+		return todos, err
+	}
+
+	if todos+users > 0 {
+		ps.logger.Logger.Info("trash purged",
+			"operation", "purge trash",
+			"todos", todos,
+			"users", users,
+		)
+	}
+
+	return todos + users, nil
+}
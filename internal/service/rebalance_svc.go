@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/rank"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+const rebalanceChunkSize int = 1000
+
+type rebalanceService struct {
+	todoRepo   psql.TodoRepository
+	transactor psql.Transactor
+	logger     *logger.Logger
+	interval   time.Duration
+	maxLength  int
+	batchSize  uint64
+}
+
+func NewRebalanceService(tr psql.TodoRepository, tx psql.Transactor, logger *logger.Logger,
+	cfg *config.PositionsConfig) se.RebalanceUseCases {
+	return &rebalanceService{
+		todoRepo:   tr,
+		transactor: tx,
+		logger:     logger,
+		interval:   cfg.Interval,
+		maxLength:  cfg.MaxLength,
+		batchSize:  cfg.BatchSize,
+	}
+}
+
+func (rs *rebalanceService) Run(ctx context.Context) {
+	ticker := time.NewTicker(rs.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := rs.Rebalance(ctx); err != nil {
+			rs.logger.Logger.Error("failed to rebalance positions",
+				"operation", "rebalance positions",
+				"error", err.Error(),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rs *rebalanceService) Rebalance(ctx context.Context) (int, error) {
+	userIDs, err := rs.todoRepo.GetUsersWithLongPositions(ctx, rs.maxLength, rs.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, userID := range userIDs {
+		if err := rs.rebalanceUser(ctx, userID); err != nil {
+			return i, err
+		}
+	}
+
+	if len(userIDs) > 0 {
+		rs.logger.Logger.Info("positions rebalanced",
+			"operation", "rebalance positions",
+			"users", len(userIDs),
+		)
+	}
+
+	return len(userIDs), nil
+}
+
+func (rs *rebalanceService) rebalanceUser(ctx context.Context, userID uuid.UUID) error {
+	return rs.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todos, err := rs.todoRepo.GetPositionsForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if len(todos) == 0 {
+			return nil
+		}
+
+		for i, position := range rank.Spread(len(todos)) {
+			todos[i].Position = position
+		}
+
+		for start := 0; start < len(todos); start += rebalanceChunkSize {
+			end := min(start+rebalanceChunkSize, len(todos))
+			if err := rs.todoRepo.UpdatePositions(ctx, todos[start:end], userID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/teambition/rrule-go"
+)
+
+func parseRecurrence(rule string, start time.Time) (*rrule.RRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+
+	option, err := rrule.StrToROption(rule)
+	if err != nil {
+		return nil, se.ErrInvalidRecurrence
+	}
+
+	option.Dtstart = start
+
+	recurrence, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, se.ErrInvalidRecurrence
+	}
+
+	return recurrence, nil
+}
+
+func nextOccurrence(recurrence *rrule.RRule, dueAt *time.Time, now time.Time) *time.Time {
+	after := now
+	if dueAt != nil && dueAt.After(now) {
+		after = *dueAt
+	}
+
+	next := recurrence.After(after, false)
+	if next.IsZero() {
+		return nil
+	}
+
+	return &next
+}
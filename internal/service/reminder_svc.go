@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/logger"
+	"github.com/identicalaffiliation/app/internal/notify"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type reminderService struct {
+	todoRepo  psql.TodoRepository
+	notifier  notify.Notifier
+	logger    *logger.Logger
+	interval  time.Duration
+	batchSize uint64
+}
+
+func NewReminderService(tr psql.TodoRepository, n notify.Notifier, logger *logger.Logger,
+	cfg *config.RemindersConfig) se.ReminderUseCases {
+	return &reminderService{
+		todoRepo:  tr,
+		notifier:  n,
+		logger:    logger,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+func (rs *reminderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(rs.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := rs.DispatchDue(ctx); err != nil {
+			rs.logger.Logger.Error("failed to dispatch reminders",
+				"operation", "dispatch reminders",
+				"error", err.Error(),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rs *reminderService) DispatchDue(ctx context.Context) (int, error) {
+	reminders, err := rs.todoRepo.ClaimDueReminders(ctx, time.Now(), rs.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		notification := &notify.Notification{
+			TodoID:    reminder.TodoID,
+			UserID:    reminder.UserID,
+			UserEmail: reminder.UserEmail,
+			UserName:  reminder.UserName,
+			Content:   reminder.Content,
+			DueAt:     reminder.DueAt,
+			RemindAt:  reminder.RemindAt,
+		}
+
+		if err := rs.notifier.Notify(ctx, notification); err != nil {
+			rs.logger.Logger.Error("failed to send reminder",
+				"operation", "dispatch reminders",
+				"todo_id", reminder.TodoID.String(),
+				"error", err.Error(),
+			)
+
+			if err := rs.todoRepo.ReleaseReminder(ctx, reminder.TodoID); err != nil {
+				return sent, err
+			}
+
+			continue
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+)
+
+const (
+	revisionContent    string = "content"
+	revisionStatus     string = "status"
+	revisionDueAt      string = "due_at"
+	revisionRemindAt   string = "remind_at"
+	revisionProject    string = "project_id"
+	revisionParent     string = "parent_id"
+	revisionRecurrence string = "recurrence"
+	revisionAssignee   string = "assignee_id"
+)
+
+func (ts *todoService) recordRevision(ctx context.Context, todoID, actorID uuid.UUID, field string,
+	oldValue, newValue *string) error {
+	if oldValue == nil && newValue == nil || oldValue != nil && newValue != nil && *oldValue == *newValue {
+		return nil
+	}
+
+	revision := &re.TodoRevision{
+		ID:       uuid.New(),
+		TodoID:   todoID,
+		ActorID:  &actorID,
+		Field:    field,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+
+	return ts.revisionRepo.Create(ctx, revision)
+}
+
+func timeValue(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	value := t.UTC().Format(time.RFC3339Nano)
+
+	return &value
+}
+
+func uuidValue(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+
+	return &value
+}
+
+func parseTimeValue(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, *value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func parseUUIDValue(value *string) (*uuid.UUID, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(*value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
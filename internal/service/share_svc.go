@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+const ownerScope string = "owner"
+
+type shareService struct {
+	grantRepo   psql.GrantRepository
+	todoRepo    psql.TodoRepository
+	projectRepo psql.ProjectRepository
+	userRepo    psql.UserRepository
+	orgRepo     psql.OrganizationRepository
+	validator   *se.Validator
+}
+
+func NewShareService(gr psql.GrantRepository, tr psql.TodoRepository, pr psql.ProjectRepository,
+	ur psql.UserRepository, or psql.OrganizationRepository) se.ShareUseCases {
+	v := se.InitValidator()
+
+	return &shareService{
+		grantRepo:   gr,
+		todoRepo:    tr,
+		projectRepo: pr,
+		userRepo:    ur,
+		orgRepo:     or,
+		validator:   v,
+	}
+}
+
+func (ss *shareService) ShareTodo(ctx context.Context, shareRequest *dto.ShareRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ss.validator.ShareRequestValidate(shareRequest); err != nil {
+		return err
+	}
+
+	ownerID, err := manageTodo(ctx, ss.todoRepo, ss.grantRepo, shareRequest.TargetID, userID)
+	if err != nil {
+		return err
+	}
+
+	return ss.share(ctx, &psql.GrantTarget{TodoID: &shareRequest.TargetID}, ownerID, userID, shareRequest)
+}
+
+func (ss *shareService) ShareProject(ctx context.Context, shareRequest *dto.ShareRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ss.validator.ShareRequestValidate(shareRequest); err != nil {
+		return err
+	}
+
+	if _, err := ss.projectRepo.GetByID(ctx, shareRequest.TargetID, userID); err != nil {
+		return se.ErrInvalidProjectID
+	}
+
+	return ss.share(ctx, &psql.GrantTarget{ProjectID: &shareRequest.TargetID}, userID, userID, shareRequest)
+}
+
+func (ss *shareService) share(ctx context.Context, target *psql.GrantTarget, ownerID, userID uuid.UUID,
+	shareRequest *dto.ShareRequest) error {
+	collaborator, err := ss.userRepo.GetByEmail(ctx, shareRequest.Email)
+	if err != nil {
+		return se.ErrShareUserNotFound
+	}
+
+	if collaborator.ID == ownerID {
+		return se.ErrShareWithOwner
+	}
+
+	if orgID, err := uuid.Parse(ctxOrgID(ctx)); err == nil {
+		if _, err := ss.orgRepo.GetMembership(ctx, orgID, collaborator.ID); err != nil {
+			return se.ErrNotOrgMember
+		}
+	}
+
+	grant := &re.Grant{
+		ID:        uuid.New(),
+		UserID:    collaborator.ID,
+		TodoID:    target.TodoID,
+		ProjectID: target.ProjectID,
+		Role:      shareRequest.Role,
+		GrantedBy: &userID,
+	}
+
+	return ss.grantRepo.Upsert(ctx, grant)
+}
+
+func (ss *shareService) GetTodoCollaborators(ctx context.Context, todoID uuid.UUID) ([]*dto.CollaboratorResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	todo, err := ss.todoRepo.GetTodoByUserID(ctx, todoID, userID)
+	if err != nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	return ss.collaborators(ctx, &psql.GrantTarget{TodoID: &todoID}, todo.UserID)
+}
+
+func (ss *shareService) GetProjectCollaborators(ctx context.Context,
+	projectID uuid.UUID) ([]*dto.CollaboratorResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if projectID == uuid.Nil {
+		return nil, se.ErrInvalidProjectID
+	}
+
+	if _, err := ss.projectRepo.GetByID(ctx, projectID, userID); err != nil {
+		return nil, se.ErrInvalidProjectID
+	}
+
+	return ss.collaborators(ctx, &psql.GrantTarget{ProjectID: &projectID}, userID)
+}
+
+func (ss *shareService) collaborators(ctx context.Context, target *psql.GrantTarget,
+	ownerID uuid.UUID) ([]*dto.CollaboratorResponse, error) {
+	owner, err := ss.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborators, err := ss.grantRepo.GetCollaborators(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.CollaboratorResponse, 0, len(collaborators)+1)
+	response = append(response, &dto.CollaboratorResponse{
+		UserID: owner.ID,
+		Name:   owner.Name,
+		Email:  owner.Email,
+		Role:   string(psql.RoleOwner),
+		Scope:  ownerScope,
+	})
+
+	for _, collaborator := range collaborators {
+		response = append(response, &dto.CollaboratorResponse{
+			UserID:    collaborator.UserID,
+			Name:      collaborator.Name,
+			Email:     collaborator.Email,
+			Role:      collaborator.Role,
+			Scope:     collaborator.Scope,
+			CreatedAt: &collaborator.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (ss *shareService) RevokeTodo(ctx context.Context, todoID, collaboratorID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if collaboratorID != userID {
+		if _, err := manageTodo(ctx, ss.todoRepo, ss.grantRepo, todoID, userID); err != nil {
+			return err
+		}
+	}
+
+	return ss.grantRepo.Delete(ctx, &psql.GrantTarget{TodoID: &todoID}, collaboratorID)
+}
+
+func (ss *shareService) RevokeProject(ctx context.Context, projectID, collaboratorID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if projectID == uuid.Nil {
+		return se.ErrInvalidProjectID
+	}
+
+	if collaboratorID != userID {
+		if _, err := ss.projectRepo.GetByID(ctx, projectID, userID); err != nil {
+			return se.ErrInvalidProjectID
+		}
+	}
+
+	return ss.grantRepo.Delete(ctx, &psql.GrantTarget{ProjectID: &projectID}, collaboratorID)
+}
+
+func manageTodo(ctx context.Context, todoRepo psql.TodoRepository, grantRepo psql.GrantRepository,
+	todoID, userID uuid.UUID) (uuid.UUID, error) {
+	todo, err := todoRepo.GetTodoByUserID(ctx, todoID, userID)
+	if err != nil {
+		return uuid.Nil, se.ErrInvalidTodoID
+	}
+
+	if todo.UserID == userID {
+		return todo.UserID, nil
+	}
+
+	roles, err := grantRepo.GetRoles(ctx, &psql.GrantTarget{TodoID: &todoID}, userID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	for _, role := range roles {
+		if role.Includes(psql.RoleOwner) {
+			return todo.UserID, nil
+		}
+	}
+
+	return uuid.Nil, se.ErrShareForbidden
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+const (
+	maxTemplateItems int = 200
+	maxTemplateDepth int = 8
+)
+
+var templateVariable = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type templateService struct {
+	templateRepo psql.TemplateRepository
+	validator    *se.Validator
+}
+
+func NewTemplateService(tr psql.TemplateRepository) se.TemplateUseCases {
+	v := se.InitValidator()
+
+	return &templateService{
+		templateRepo: tr,
+		validator:    v,
+	}
+}
+
+func (tpl *templateService) CreateTemplate(ctx context.Context, templateRequest *dto.TemplateCreateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := tpl.validator.TemplateCreateRequestValidate(templateRequest); err != nil {
+		return err
+	}
+
+	items, err := templateItemsToEntity(templateRequest.Items)
+	if err != nil {
+		return err
+	}
+
+	template := &re.TodoTemplate{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        templateRequest.Name,
+		Description: templateRequest.Description,
+		Items:       items,
+	}
+
+	if err := tpl.templateRepo.Create(ctx, template); err != nil {
+		if errors.Is(err, psql.ErrAlreadyExists) {
+			return se.ErrTemplateExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (tpl *templateService) GetTemplate(ctx context.Context, templateID uuid.UUID) (*dto.TemplateResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if templateID == uuid.Nil {
+		return nil, se.ErrInvalidTemplateID
+	}
+
+	template, err := tpl.templateRepo.GetByID(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return templateToResponse(template), nil
+}
+
+func (tpl *templateService) GetTemplates(ctx context.Context) ([]*dto.TemplateResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	templates, err := tpl.templateRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TemplateResponse, 0, len(templates))
+	for _, template := range templates {
+		response = append(response, templateToResponse(template))
+	}
+
+	return response, nil
+}
+
+func (tpl *templateService) UpdateTemplate(ctx context.Context, templateRequest *dto.TemplateUpdateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := tpl.validator.TemplateUpdateRequestValidate(templateRequest); err != nil {
+		return err
+	}
+
+	items, err := templateItemsToEntity(templateRequest.Items)
+	if err != nil {
+		return err
+	}
+
+	template := &re.TodoTemplate{
+		ID:          templateRequest.TemplateID,
+		UserID:      userID,
+		Name:        templateRequest.Name,
+		Description: templateRequest.Description,
+		Items:       items,
+	}
+
+	if err := tpl.templateRepo.Update(ctx, template); err != nil {
+		if errors.Is(err, psql.ErrAlreadyExists) {
+			return se.ErrTemplateExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (tpl *templateService) DeleteTemplate(ctx context.Context, templateID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if templateID == uuid.Nil {
+		return se.ErrInvalidTemplateID
+	}
+
+	return tpl.templateRepo.Delete(ctx, templateID, userID)
+}
+
+func templateItemsToEntity(items []*dto.TemplateItem) (re.TemplateItems, error) {
+	count := 0
+
+	var convert func(items []*dto.TemplateItem, depth int) ([]*re.TemplateItem, error)
+	convert = func(items []*dto.TemplateItem, depth int) ([]*re.TemplateItem, error) {
+		if depth > maxTemplateDepth {
+			return nil, se.ErrTemplateTooLarge
+		}
+
+		result := make([]*re.TemplateItem, 0, len(items))
+		for _, item := range items {
+			if count++; count > maxTemplateItems {
+				return nil, se.ErrTemplateTooLarge
+			}
+
+			children, err := convert(item.Children, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, &re.TemplateItem{
+				Content:   item.Content,
+				DueInDays: item.DueInDays,
+				Children:  children,
+			})
+		}
+
+		return result, nil
+	}
+
+	return convert(items, 1)
+}
+
+func templateItemsToResponse(items []*re.TemplateItem) []*dto.TemplateItem {
+	response := make([]*dto.TemplateItem, 0, len(items))
+	for _, item := range items {
+		response = append(response, &dto.TemplateItem{
+			Content:   item.Content,
+			DueInDays: item.DueInDays,
+			Children:  templateItemsToResponse(item.Children),
+		})
+	}
+
+	return response
+}
+
+func templateToResponse(template *re.TodoTemplate) *dto.TemplateResponse {
+	return &dto.TemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Variables:   templateVariables(template.Items),
+		Items:       templateItemsToResponse(template.Items),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
+
+func templateVariables(items []*re.TemplateItem) []string {
+	seen := make(map[string]struct{})
+
+	var collect func(items []*re.TemplateItem)
+	collect = func(items []*re.TemplateItem) {
+		for _, item := range items {
+			for _, match := range templateVariable.FindAllStringSubmatch(item.Content, -1) {
+				seen[match[1]] = struct{}{}
+			}
+
+			collect(item.Children)
+		}
+	}
+	collect(items)
+
+	variables := make([]string, 0, len(seen))
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+
+	return variables
+}
+
+func renderTemplate(content string, variables map[string]string) (string, error) {
+	var missing string
+	rendered := templateVariable.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := templateVariable.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+
+		return value
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("%w: %s", se.ErrMissingVariable, missing)
+	}
+
+	return rendered, nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+type timeEntryService struct {
+	entryRepo   psql.TimeEntryRepository
+	todoRepo    psql.TodoRepository
+	todoService se.TodoUseCases
+	transactor  psql.Transactor
+	validator   *se.Validator
+}
+
+func NewTimeEntryService(er psql.TimeEntryRepository, tr psql.TodoRepository, ts se.TodoUseCases,
+	tx psql.Transactor) se.TimeEntryUseCases {
+	v := se.InitValidator()
+
+	return &timeEntryService{
+		entryRepo:   er,
+		todoRepo:    tr,
+		todoService: ts,
+		transactor:  tx,
+		validator:   v,
+	}
+}
+
+func (es *timeEntryService) StartTimer(ctx context.Context, startRequest *dto.TimerStartRequest) (*dto.TimeEntryResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := es.validator.TimerStartRequestValidate(startRequest); err != nil {
+		return nil, err
+	}
+
+	entry := &re.TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TodoID:    startRequest.TodoID,
+		StartedAt: time.Now(),
+		Note:      startRequest.Note,
+	}
+
+	err := es.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := es.todoRepo.GetTodoByUserID(ctx, startRequest.TodoID, userID)
+		if err != nil {
+			return se.ErrInvalidTodoID
+		}
+
+		if err := es.entryRepo.Create(ctx, entry); err != nil {
+			if errors.Is(err, psql.ErrAlreadyExists) {
+				return se.ErrTimerRunning
+			}
+
+			return err
+		}
+
+		if startRequest.Status == nil || *startRequest.Status == todo.Status {
+			return nil
+		}
+
+		return es.todoService.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{
+			TodoID:    todo.ID,
+			NewStatus: *startRequest.Status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entryToResponse(entry), nil
+}
+
+func (es *timeEntryService) StopTimer(ctx context.Context) (*dto.TimeEntryResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	entry, err := es.entryRepo.Stop(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, se.ErrNoRunningTimer
+		}
+
+		return nil, err
+	}
+
+	return entryToResponse(entry), nil
+}
+
+func (es *timeEntryService) GetRunningTimer(ctx context.Context) (*dto.TimeEntryResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	entry, err := es.entryRepo.GetRunning(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, se.ErrNoRunningTimer
+		}
+
+		return nil, err
+	}
+
+	return entryToResponse(entry), nil
+}
+
+func (es *timeEntryService) CreateTimeEntry(ctx context.Context, entryRequest *dto.TimeEntryCreateRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := es.validator.TimeEntryCreateRequestValidate(entryRequest); err != nil {
+		return err
+	}
+
+	if !entryRequest.StoppedAt.After(entryRequest.StartedAt) || entryRequest.StoppedAt.After(time.Now()) {
+		return se.ErrInvalidTimeRange
+	}
+
+	if _, err := es.todoRepo.GetTodoByUserID(ctx, entryRequest.TodoID, userID); err != nil {
+		return se.ErrInvalidTodoID
+	}
+
+	entry := &re.TimeEntry{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TodoID:    entryRequest.TodoID,
+		StartedAt: entryRequest.StartedAt,
+		StoppedAt: &entryRequest.StoppedAt,
+		Note:      entryRequest.Note,
+	}
+
+	return es.entryRepo.Create(ctx, entry)
+}
+
+func (es *timeEntryService) GetTodoTime(ctx context.Context, todoID uuid.UUID) (*dto.TodoTimeResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	if _, err := es.todoRepo.GetTodoByUserID(ctx, todoID, userID); err != nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	entries, err := es.entryRepo.GetByTodoID(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TodoTimeResponse{Entries: make([]*dto.TimeEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		item := entryToResponse(entry)
+		response.TotalSeconds += item.Seconds
+		response.Entries = append(response.Entries, item)
+	}
+
+	return response, nil
+}
+
+func (es *timeEntryService) GetTimeTotals(ctx context.Context,
+	totalsRequest *dto.TimeTotalsRequest) ([]*dto.TimeTotalResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := es.validator.TimeTotalsRequestValidate(totalsRequest); err != nil {
+		return nil, err
+	}
+
+	filter := &psql.TimeTotalsFilter{
+		GroupBy: psql.GroupByTodo,
+		From:    totalsRequest.From,
+		To:      totalsRequest.To,
+	}
+
+	if totalsRequest.GroupBy != "" {
+		filter.GroupBy = psql.TimeGroup(totalsRequest.GroupBy)
+	}
+
+	totals, err := es.entryRepo.GetTotals(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TimeTotalResponse, 0, len(totals))
+	for _, total := range totals {
+		response = append(response, &dto.TimeTotalResponse{
+			TodoID:  total.TodoID,
+			Period:  total.Period,
+			Seconds: total.Seconds,
+		})
+	}
+
+	return response, nil
+}
+
+func entryToResponse(entry *re.TimeEntry) *dto.TimeEntryResponse {
+	stoppedAt := time.Now()
+	if entry.StoppedAt != nil {
+		stoppedAt = *entry.StoppedAt
+	}
+
+	return &dto.TimeEntryResponse{
+		ID:        entry.ID,
+		TodoID:    entry.TodoID,
+		StartedAt: entry.StartedAt,
+		StoppedAt: entry.StoppedAt,
+		Running:   entry.StoppedAt == nil,
+		Seconds:   int64(stoppedAt.Sub(entry.StartedAt).Seconds()),
+		Note:      entry.Note,
+		CreatedAt: entry.CreatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+func (ts *todoService) ChangeAssignee(ctx context.Context, changeAssigneeRequest *dto.TodoAssigneeChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if changeAssigneeRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoAssigneeChangeRequest(changeAssigneeRequest); err != nil {
+		return err
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeAssigneeRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		if assigneeID := changeAssigneeRequest.AssigneeID; assigneeID != nil && *assigneeID != todo.UserID {
+			allowed, err := ts.todoRepo.CanAccess(ctx, todo.ID, *assigneeID, psql.RoleEditor)
+			if err != nil {
+				return err
+			}
+
+			if !allowed {
+				return se.ErrInvalidAssignee
+			}
+		}
+
+		if err := ts.todoRepo.UpdateAssignee(ctx, changeAssigneeRequest.AssigneeID, todo.ID, userID); err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionAssignee, uuidValue(todo.AssigneeID),
+			uuidValue(changeAssigneeRequest.AssigneeID))
+	})
+}
+
+func (ts *todoService) GetAssignedTodos(ctx context.Context) ([]*dto.TodoResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	todos, err := ts.todoRepo.GetAssigned(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.todosToResponse(todos), nil
+}
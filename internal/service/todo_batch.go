@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/internal/workflow"
+)
+
+const (
+	batchCreate string = "create"
+	batchUpdate string = "update"
+	batchDelete string = "delete"
+)
+
+type todoBatch struct {
+	creates     []*re.Todo
+	contents    []*re.Todo
+	transitions []*workflow.Transition
+	deletes     []uuid.UUID
+	locked      map[uuid.UUID]*re.Todo
+	seen        map[uuid.UUID]bool
+	resolved    map[uuid.UUID]bool
+	counts      map[string]int
+}
+
+func (ts *todoService) BatchTodos(ctx context.Context, batchRequest *dto.TodoBatchRequest) (*dto.TodoBatchResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := ts.validator.TodoBatchRequestValidate(batchRequest); err != nil {
+		return nil, err
+	}
+
+	response := &dto.TodoBatchResponse{Results: make([]*dto.TodoBatchResult, 0, len(batchRequest.Operations))}
+
+	err := ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		batch, err := ts.lockBatch(ctx, batchRequest.Operations, userID)
+		if err != nil {
+			return err
+		}
+
+		failed := false
+		for i, operation := range batchRequest.Operations {
+			result := &dto.TodoBatchResult{Index: i}
+			if operation != nil {
+				result.Op = operation.Op
+			}
+
+			todoID, err := ts.planBatchOperation(ctx, batch, operation, userID)
+			if err != nil {
+				result.Error = err.Error()
+				failed = true
+			} else {
+				result.ID = &todoID
+			}
+
+			response.Results = append(response.Results, result)
+		}
+
+		if failed {
+			return se.ErrBatchFailed
+		}
+
+		return ts.execBatch(ctx, batch, userID)
+	})
+	if err != nil {
+		if errors.Is(err, se.ErrBatchFailed) {
+			return response, err
+		}
+
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) lockBatch(ctx context.Context, operations []*dto.TodoBatchOperation,
+	userID uuid.UUID) (*todoBatch, error) {
+	batch := &todoBatch{
+		locked:   make(map[uuid.UUID]*re.Todo),
+		seen:     make(map[uuid.UUID]bool),
+		resolved: make(map[uuid.UUID]bool),
+	}
+
+	todoIDs := make([]uuid.UUID, 0, len(operations))
+	for _, operation := range operations {
+		if operation != nil && operation.Op != batchCreate && operation.TodoID != uuid.Nil {
+			todoIDs = append(todoIDs, operation.TodoID)
+		}
+	}
+
+	if len(todoIDs) == 0 {
+		return batch, nil
+	}
+
+	todos, err := ts.todoRepo.GetTodosForUpdate(ctx, uniqueIDs(todoIDs), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, todo := range todos {
+		batch.locked[todo.ID] = todo
+	}
+
+	return batch, nil
+}
+
+func (ts *todoService) planBatchOperation(ctx context.Context, batch *todoBatch, operation *dto.TodoBatchOperation,
+	userID uuid.UUID) (uuid.UUID, error) {
+	if operation == nil {
+		return uuid.Nil, se.ErrInvalidBatchOp
+	}
+
+	if operation.Op == batchCreate {
+		if operation.Todo == nil {
+			return uuid.Nil, se.ErrInvalidBatchOp
+		}
+
+		todo, err := ts.newTodo(ctx, operation.Todo, userID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		if err := ts.reserveBatchWIP(ctx, batch, userID, "", todo.Status); err != nil {
+			return uuid.Nil, err
+		}
+
+		batch.creates = append(batch.creates, todo)
+
+		return todo.ID, nil
+	}
+
+	if operation.Op != batchUpdate && operation.Op != batchDelete {
+		return uuid.Nil, se.ErrInvalidBatchOp
+	}
+
+	todo, ok := batch.locked[operation.TodoID]
+	if !ok {
+		return uuid.Nil, se.ErrInvalidTodoID
+	}
+
+	if batch.seen[todo.ID] {
+		return uuid.Nil, se.ErrDuplicateBatchTodo
+	}
+	batch.seen[todo.ID] = true
+
+	if operation.Version != nil && *operation.Version != todo.Version {
+		return uuid.Nil, se.ErrVersionMismatch
+	}
+
+	counted := todo.Status
+	if todo.Archived {
+		counted = ""
+	}
+
+	if operation.Op == batchDelete {
+		if err := ts.reserveBatchWIP(ctx, batch, userID, counted, ""); err != nil {
+			return uuid.Nil, err
+		}
+
+		batch.deletes = append(batch.deletes, todo.ID)
+
+		return todo.ID, nil
+	}
+
+	if operation.Content == nil && operation.Status == nil {
+		return uuid.Nil, se.ErrEmptyBatchUpdate
+	}
+
+	if operation.Content != nil {
+		request := &dto.TodoContentChangeRequest{TodoID: todo.ID, NewContent: *operation.Content}
+		if err := ts.validator.TodoContentChangeRequest(request); err != nil {
+			return uuid.Nil, err
+		}
+
+		batch.contents = append(batch.contents, &re.Todo{ID: todo.ID, Content: *operation.Content})
+	}
+
+	if operation.Status != nil {
+		newStatus := *operation.Status
+		if !ts.workflow.Has(newStatus) {
+			return uuid.Nil, fmt.Errorf("%w: %s", workflow.ErrUnknownState, newStatus)
+		}
+
+		if todo.Status != newStatus && !ts.workflow.CanTransition(todo.Status, newStatus) {
+			return uuid.Nil, fmt.Errorf("%w: %s -> %s", workflow.ErrTransitionNotAllowed, todo.Status, newStatus)
+		}
+
+		if todo.Status != newStatus {
+			if err := ts.checkBlockers(ctx, todo, newStatus, batch.resolved); err != nil {
+				return uuid.Nil, err
+			}
+
+			if err := ts.reserveBatchWIP(ctx, batch, userID, counted, newStatus); err != nil {
+				return uuid.Nil, err
+			}
+
+			batch.resolved[todo.ID] = ts.workflow.IsTerminal(newStatus)
+		}
+
+		batch.transitions = append(batch.transitions, &workflow.Transition{
+			TodoID: todo.ID,
+			UserID: userID,
+			From:   todo.Status,
+			To:     newStatus,
+		})
+	}
+
+	return todo.ID, nil
+}
+
+func (ts *todoService) reserveBatchWIP(ctx context.Context, batch *todoBatch, userID uuid.UUID, from, to string) error {
+	if batch.counts == nil {
+		if !ts.workflow.HasWIPLimits() {
+			return nil
+		}
+
+		if _, err := ts.userRepo.GetByIDForUpdate(ctx, userID); err != nil {
+			return err
+		}
+
+		counts, err := ts.todoRepo.CountByStatus(ctx, userID, nil)
+		if err != nil {
+			return err
+		}
+
+		batch.counts = counts
+	}
+
+	if to != "" {
+		if limit := ts.workflow.WIPLimit(to); limit > 0 && batch.counts[to] >= limit {
+			return fmt.Errorf("%w: %s allows %d", se.ErrWIPLimitReached, to, limit)
+		}
+
+		batch.counts[to]++
+	}
+
+	if from != "" {
+		batch.counts[from]--
+	}
+
+	return nil
+}
+
+func (ts *todoService) execBatch(ctx context.Context, batch *todoBatch, userID uuid.UUID) error {
+	if len(batch.creates) > 0 {
+		if err := ts.appendPositions(ctx, userID, batch.creates...); err != nil {
+			return err
+		}
+
+		if err := ts.todoRepo.CreateBatch(ctx, batch.creates); err != nil {
+			return err
+		}
+	}
+
+	if len(batch.contents) > 0 {
+		if err := ts.todoRepo.UpdateContentBatch(ctx, batch.contents, userID); err != nil {
+			return err
+		}
+
+		for _, updated := range batch.contents {
+			todo := batch.locked[updated.ID]
+			err := ts.recordRevision(ctx, todo.ID, userID, revisionContent, &todo.Content, &updated.Content)
+			if err != nil {
+				return err
+			}
+
+			todo.Content = updated.Content
+		}
+	}
+
+	if err := ts.execBatchTransitions(ctx, batch, userID); err != nil {
+		return err
+	}
+
+	if len(batch.deletes) > 0 {
+		return ts.todoRepo.DeleteBatch(ctx, batch.deletes, userID)
+	}
+
+	return nil
+}
+
+func (ts *todoService) execBatchTransitions(ctx context.Context, batch *todoBatch, userID uuid.UUID) error {
+	for _, transition := range batch.transitions {
+		err := ts.workflow.Apply(ctx, transition, func(ctx context.Context) error {
+			return ts.todoRepo.UpdateStatus(ctx, psql.TodoStatus(transition.To), transition.TodoID, userID)
+		})
+		if err != nil {
+			return err
+		}
+
+		todo := batch.locked[transition.TodoID]
+		if err := ts.recordRevision(ctx, todo.ID, userID, revisionStatus, &transition.From, &transition.To); err != nil {
+			return err
+		}
+
+		if !ts.workflow.IsTerminal(transition.To) || ts.workflow.IsTerminal(transition.From) || todo.Recurrence == nil {
+			continue
+		}
+
+		if err := ts.spawnNextOccurrence(ctx, todo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+func (ts *todoService) AddBlocker(ctx context.Context, dependencyRequest *dto.TodoDependencyRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if err := ts.validator.TodoDependencyRequest(dependencyRequest); err != nil {
+		return err
+	}
+
+	if dependencyRequest.TodoID == dependencyRequest.BlockerID {
+		return se.ErrDependencyCycle
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todoIDs := []uuid.UUID{dependencyRequest.TodoID, dependencyRequest.BlockerID}
+		sort.Slice(todoIDs, func(i, j int) bool {
+			return bytes.Compare(todoIDs[i][:], todoIDs[j][:]) < 0
+		})
+
+		locked := make(map[uuid.UUID]*re.Todo, len(todoIDs))
+		for _, todoID := range todoIDs {
+			todo, err := ts.todoRepo.GetTodoForUpdate(ctx, todoID, userID)
+			if err != nil {
+				if todoID == dependencyRequest.TodoID {
+					return se.ErrInvalidTodoID
+				}
+
+				return se.ErrInvalidBlockerID
+			}
+
+			locked[todoID] = todo
+		}
+
+		owner := locked[dependencyRequest.TodoID].UserID
+		if locked[dependencyRequest.BlockerID].UserID != owner {
+			return se.ErrInvalidBlockerID
+		}
+
+		if _, err := ts.userRepo.GetByIDForUpdate(ctx, owner); err != nil {
+			return err
+		}
+
+		cycle, err := ts.dependencyRepo.Reaches(ctx, dependencyRequest.TodoID, dependencyRequest.BlockerID)
+		if err != nil {
+			return err
+		}
+
+		if cycle {
+			return se.ErrDependencyCycle
+		}
+
+		err = ts.dependencyRepo.Create(ctx, &re.TodoDependency{
+			BlockerID: dependencyRequest.BlockerID,
+			BlockedID: dependencyRequest.TodoID,
+		})
+		if errors.Is(err, psql.ErrAlreadyExists) {
+			return se.ErrDependencyExists
+		}
+
+		return err
+	})
+}
+
+func (ts *todoService) RemoveBlocker(ctx context.Context, todoID, blockerID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if blockerID == uuid.Nil {
+		return se.ErrInvalidBlockerID
+	}
+
+	return ts.dependencyRepo.Delete(ctx, blockerID, todoID, userID)
+}
+
+func (ts *todoService) checkBlockers(ctx context.Context, todo *re.Todo, newStatus string,
+	resolved map[uuid.UUID]bool) error {
+	if !ts.workflow.IsTerminal(newStatus) || ts.workflow.IsTerminal(todo.Status) {
+		return nil
+	}
+
+	blockerIDs, err := ts.dependencyRepo.GetOpenBlockers(ctx, todo.ID, ts.workflow.TerminalStates())
+	if err != nil {
+		return err
+	}
+
+	open := 0
+	for _, blockerID := range blockerIDs {
+		if !resolved[blockerID] {
+			open++
+		}
+	}
+
+	if open > 0 {
+		return fmt.Errorf("%w: %d remaining", se.ErrTodoBlocked, open)
+	}
+
+	return nil
+}
+
+func (ts *todoService) attachDependencies(ctx context.Context, userID uuid.UUID, todos ...*dto.TodoResponse) error {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*dto.TodoResponse, len(todos))
+	todoIDs := make([]uuid.UUID, 0, len(todos))
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+		todoIDs = append(todoIDs, todo.ID)
+	}
+
+	dependencies, err := ts.dependencyRepo.GetByTodoIDs(ctx, todoIDs, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range dependencies {
+		if blocked, ok := byID[dependency.BlockedID]; ok {
+			blocked.BlockedBy = append(blocked.BlockedBy, dependency.BlockerID)
+		}
+
+		if blocker, ok := byID[dependency.BlockerID]; ok {
+			blocker.Blocks = append(blocker.Blocks, dependency.BlockedID)
+		}
+	}
+
+	return nil
+}
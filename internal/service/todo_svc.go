@@ -2,28 +2,52 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/rank"
 	re "github.com/identicalaffiliation/app/internal/repository/entity"
 	"github.com/identicalaffiliation/app/internal/repository/psql"
 	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/internal/workflow"
 )
 
+const defaultTodoPageSize uint64 = 20
+
 type todoService struct {
-	userRepo  psql.UserRepository
-	todoRepo  psql.TodoRepository
-	validator *se.Validator
+	userRepo       psql.UserRepository
+	todoRepo       psql.TodoRepository
+	projectRepo    psql.ProjectRepository
+	occurrenceRepo psql.OccurrenceRepository
+	revisionRepo   psql.RevisionRepository
+	dependencyRepo psql.DependencyRepository
+	templateRepo   psql.TemplateRepository
+	transactor     psql.Transactor
+	workflow       *workflow.Workflow
+	validator      *se.Validator
 }
 
-func NewTodoService(ur psql.UserRepository, tr psql.TodoRepository) se.TodoUseCases {
+func NewTodoService(ur psql.UserRepository, tr psql.TodoRepository, pr psql.ProjectRepository,
+	or psql.OccurrenceRepository, rr psql.RevisionRepository, dr psql.DependencyRepository,
+	mr psql.TemplateRepository, tx psql.Transactor, wf *workflow.Workflow) se.TodoUseCases {
 	v := se.InitValidator()
 
 	return &todoService{
-		userRepo:  ur,
-		todoRepo:  tr,
-		validator: v,
+		userRepo:       ur,
+		todoRepo:       tr,
+		projectRepo:    pr,
+		occurrenceRepo: or,
+		revisionRepo:   rr,
+		dependencyRepo: dr,
+		templateRepo:   mr,
+		transactor:     tx,
+		workflow:       wf,
+		validator:      v,
 	}
 }
 
@@ -33,20 +57,170 @@ func (ts *todoService) CreateTodo(ctx context.Context, todoRequest *dto.TodoCrea
 		return se.ErrInvalidUserID
 	}
 
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.newTodo(ctx, todoRequest, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := ts.checkWIPLimit(ctx, userID, todo.Status); err != nil {
+			return err
+		}
+
+		if err := ts.appendPositions(ctx, userID, todo); err != nil {
+			return err
+		}
+
+		return ts.todoRepo.Create(ctx, todo)
+	})
+}
+
+func (ts *todoService) checkWIPLimit(ctx context.Context, userID uuid.UUID, status string) error {
+	limit := ts.workflow.WIPLimit(status)
+	if limit == 0 {
+		return nil
+	}
+
+	if _, err := ts.userRepo.GetByIDForUpdate(ctx, userID); err != nil {
+		return err
+	}
+
+	counts, err := ts.todoRepo.CountByStatus(ctx, userID, nil)
+	if err != nil {
+		return err
+	}
+
+	if counts[status] >= limit {
+		return fmt.Errorf("%w: %s allows %d", se.ErrWIPLimitReached, status, limit)
+	}
+
+	return nil
+}
+
+func (ts *todoService) appendPositions(ctx context.Context, userID uuid.UUID, todos ...*re.Todo) error {
+	last, err := ts.todoRepo.GetLastPosition(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, todo := range todos {
+		if todo.Position, err = rank.After(last); err != nil {
+			return err
+		}
+
+		last = todo.Position
+	}
+
+	return nil
+}
+
+func (ts *todoService) lockParent(ctx context.Context, parentID, ownerID, userID uuid.UUID) (*re.Todo, error) {
+	parent, err := ts.todoRepo.GetTodoForUpdate(ctx, parentID, userID)
+	if err != nil || parent.UserID != ownerID {
+		return nil, se.ErrInvalidParentID
+	}
+
+	return parent, nil
+}
+
+func (ts *todoService) newTodo(ctx context.Context, todoRequest *dto.TodoCreateRequest, userID uuid.UUID) (*re.Todo, error) {
+	if todoRequest.Status == "" {
+		todoRequest.Status = ts.workflow.Initial()
+	}
+
 	if err := ts.validator.TodoCreateRequestValidate(todoRequest); err != nil {
-		return fmt.Errorf("todo validate: %w", err)
+		return nil, fmt.Errorf("todo validate: %w", err)
+	}
+
+	if !ts.workflow.Has(todoRequest.Status) {
+		return nil, fmt.Errorf("%w: %s", workflow.ErrUnknownState, todoRequest.Status)
+	}
+
+	if ts.workflow.IsTerminal(todoRequest.Status) {
+		return nil, se.ErrInvalidTodoStatus
+	}
+
+	if todoRequest.ParentID != nil {
+		parent, err := ts.lockParent(ctx, *todoRequest.ParentID, userID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if todoRequest.ProjectID == nil {
+			todoRequest.ProjectID = parent.ProjectID
+		}
+	}
+
+	if err := ts.checkProject(ctx, todoRequest.ProjectID, userID); err != nil {
+		return nil, err
 	}
 
 	todoID := uuid.New()
 
 	todo := &re.Todo{
-		ID:      todoID,
-		UserID:  userID,
-		Content: todoRequest.Content,
-		Status:  todoRequest.Status,
+		ID:        todoID,
+		UserID:    userID,
+		ProjectID: todoRequest.ProjectID,
+		ParentID:  todoRequest.ParentID,
+		Content:   todoRequest.Content,
+		Status:    todoRequest.Status,
+		DueAt:     todoRequest.DueAt,
+		RemindAt:  todoRequest.RemindAt,
+	}
+
+	if todoRequest.Recurrence != "" {
+		if err := ts.applyRecurrence(todo, todoRequest.Recurrence); err != nil {
+			return nil, err
+		}
+
+		if todo.DueAt == nil {
+			recurrence, _ := parseRecurrence(*todo.Recurrence, *todo.RecurrenceStart)
+			if first := recurrence.After(*todo.RecurrenceStart, true); !first.IsZero() {
+				todo.DueAt = &first
+			}
+		}
+	}
+
+	return todo, nil
+}
+
+func (ts *todoService) applyRecurrence(todo *re.Todo, rule string) error {
+	start := time.Now()
+	if todo.DueAt != nil {
+		start = *todo.DueAt
+	}
+
+	if _, err := parseRecurrence(rule, start); err != nil {
+		return err
+	}
+
+	seriesID := todo.ID
+	if todo.SeriesID != nil {
+		seriesID = *todo.SeriesID
+	}
+
+	todo.Recurrence = &rule
+	todo.RecurrenceStart = &start
+	todo.SeriesID = &seriesID
+
+	return nil
+}
+
+func (ts *todoService) checkProject(ctx context.Context, projectID *uuid.UUID, userID uuid.UUID) error {
+	if projectID == nil {
+		return nil
+	}
+
+	project, err := ts.projectRepo.GetByID(ctx, *projectID, userID)
+	if err != nil {
+		return se.ErrInvalidProjectID
+	}
+
+	if project.Archived {
+		return se.ErrProjectArchived
 	}
 
-	return ts.todoRepo.Create(ctx, todo)
+	return nil
 }
 
 func (ts *todoService) GetTodo(ctx context.Context, todoID uuid.UUID) (*dto.TodoResponse, error) {
@@ -64,26 +238,241 @@ func (ts *todoService) GetTodo(ctx context.Context, todoID uuid.UUID) (*dto.Todo
 		return nil, err
 	}
 
-	return &dto.TodoResponse{
-		Content:   todo.Content,
-		Status:    todo.Status,
-		CreatedAt: todo.CreatedAt,
-		UpdatedAt: todo.UpdatedAt,
-	}, nil
+	response := ts.todoToResponse(todo)
+	if err := ts.attachDependencies(ctx, todo.UserID, response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) GetTodoTree(ctx context.Context, todoID uuid.UUID) (*dto.TodoResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	todos, err := ts.todoRepo.GetTree(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(todos) == 0 {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	nodes := make(map[uuid.UUID]*dto.TodoResponse, len(todos))
+	responses := make([]*dto.TodoResponse, 0, len(todos))
+	root := ts.todoToResponse(todos[0])
+	nodes[root.ID] = root
+	responses = append(responses, root)
+	for _, todo := range todos[1:] {
+		node := ts.todoToResponse(todo)
+		nodes[node.ID] = node
+		responses = append(responses, node)
+
+		if parent, ok := nodes[*todo.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	if err := ts.attachDependencies(ctx, todos[0].UserID, responses...); err != nil {
+		return nil, err
+	}
+
+	return root, nil
 }
 
-func (ts *todoService) GetTodos(ctx context.Context) ([]*dto.TodoResponse, error) {
+func (ts *todoService) GetTodos(ctx context.Context, listRequest *dto.TodoListRequest) (*dto.TodoListResponse, error) {
 	userID, _ := uuid.Parse(ctx.Value("userID").(string))
 	if userID == uuid.Nil {
 		return nil, se.ErrInvalidUserID
 	}
 
-	todos, err := ts.todoRepo.GetTodosByUserID(ctx, userID)
+	if err := ts.validator.TodoListRequestValidate(listRequest); err != nil {
+		return nil, err
+	}
+
+	filter, err := ts.listRequestToFilter(listRequest)
 	if err != nil {
 		return nil, err
 	}
 
-	response := ts.todosToResponse(todos)
+	limit := filter.Limit
+	filter.Limit++
+
+	todos, err := ts.todoRepo.GetTodosByUserID(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.TodoListResponse{}
+	if uint64(len(todos)) > limit {
+		todos = todos[:limit]
+		response.NextCursor = psql.EncodeTodoCursor(todos[len(todos)-1], filter.SortBy)
+	}
+
+	response.Items = ts.todosToResponse(todos)
+	if err := ts.attachDependencies(ctx, userID, response.Items...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) GetBoard(ctx context.Context, boardRequest *dto.BoardRequest) (*dto.BoardResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := ts.validator.BoardRequestValidate(boardRequest); err != nil {
+		return nil, err
+	}
+
+	limit := defaultTodoPageSize
+	if boardRequest.Limit > 0 {
+		limit = uint64(boardRequest.Limit)
+	}
+
+	counts, err := ts.todoRepo.CountByStatus(ctx, userID, boardRequest.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.BoardResponse{Columns: make([]*dto.BoardColumn, 0, len(ts.workflow.States()))}
+	items := make([]*dto.TodoResponse, 0)
+	for _, state := range ts.workflow.States() {
+		column := &dto.BoardColumn{
+			Status:   state.Name,
+			Label:    state.Label,
+			Terminal: state.Terminal,
+			WIPLimit: ts.workflow.WIPLimit(state.Name),
+			Count:    counts[state.Name],
+			Items:    []*dto.TodoResponse{},
+		}
+
+		if column.Count > 0 {
+			todos, err := ts.todoRepo.GetTodosByUserID(ctx, userID, &psql.TodoFilter{
+				ProjectID:       boardRequest.ProjectID,
+				IncludeArchived: boardRequest.ProjectID != nil,
+				Statuses:        []string{state.Name},
+				SortBy:          psql.SortByPosition,
+				Limit:           limit + 1,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if uint64(len(todos)) > limit {
+				todos = todos[:limit]
+				column.NextCursor = psql.EncodeTodoCursor(todos[len(todos)-1], psql.SortByPosition)
+			}
+
+			column.Items = ts.todosToResponse(todos)
+			items = append(items, column.Items...)
+		}
+
+		response.Columns = append(response.Columns, column)
+	}
+
+	if err := ts.attachDependencies(ctx, userID, items...); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) listRequestToFilter(listRequest *dto.TodoListRequest) (*psql.TodoFilter, error) {
+	filter := &psql.TodoFilter{
+		ProjectID:       listRequest.ProjectID,
+		IncludeArchived: listRequest.IncludeArchived || listRequest.ProjectID != nil,
+		Statuses:        listRequest.Statuses,
+		CreatedAfter:    listRequest.CreatedAfter,
+		CreatedBefore:   listRequest.CreatedBefore,
+		UpdatedAfter:    listRequest.UpdatedAfter,
+		UpdatedBefore:   listRequest.UpdatedBefore,
+		DueAfter:        listRequest.DueAfter,
+		DueBefore:       listRequest.DueBefore,
+		Overdue:         listRequest.Overdue,
+		LabelIDs:        uniqueIDs(listRequest.LabelIDs),
+		AllLabels:       listRequest.LabelsMode == "all",
+		SortBy:          psql.SortByPosition,
+		Limit:           defaultTodoPageSize,
+	}
+
+	if listRequest.Sort != "" {
+		filter.Descending = strings.HasPrefix(listRequest.Sort, "-")
+		filter.SortBy = psql.TodoSortField(strings.TrimPrefix(listRequest.Sort, "-"))
+	}
+
+	if listRequest.Limit > 0 {
+		filter.Limit = uint64(listRequest.Limit)
+	}
+
+	if listRequest.Cursor != "" {
+		cursor, err := psql.DecodeTodoCursor(listRequest.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.SortBy != filter.SortBy {
+			return nil, se.ErrInvalidCursor
+		}
+
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	return unique
+}
+
+func (ts *todoService) SearchTodos(ctx context.Context, searchRequest *dto.TodoSearchRequest) ([]*dto.TodoSearchResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := ts.validator.TodoSearchRequestValidate(searchRequest); err != nil {
+		return nil, err
+	}
+
+	limit := defaultTodoPageSize
+	if searchRequest.Limit > 0 {
+		limit = uint64(searchRequest.Limit)
+	}
+
+	results, err := ts.todoRepo.Search(ctx, userID, searchRequest.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TodoSearchResponse, 0, len(results))
+	for _, result := range results {
+		response = append(response, &dto.TodoSearchResponse{
+			TodoResponse: ts.todoToResponse(&result.Todo),
+			Rank:         result.Rank,
+			Snippet:      result.Snippet,
+		})
+	}
 
 	return response, nil
 }
@@ -91,17 +480,47 @@ func (ts *todoService) GetTodos(ctx context.Context) ([]*dto.TodoResponse, error
 func (ts *todoService) todosToResponse(todos []*re.Todo) []*dto.TodoResponse {
 	respone := make([]*dto.TodoResponse, 0, len(todos))
 	for _, todo := range todos {
-		respone = append(respone, &dto.TodoResponse{
-			Content:   todo.Content,
-			Status:    todo.Status,
-			CreatedAt: todo.CreatedAt,
-			UpdatedAt: todo.UpdatedAt,
-		})
+		respone = append(respone, ts.todoToResponse(todo))
 	}
 
 	return respone
 }
 
+func (ts *todoService) todoToResponse(todo *re.Todo) *dto.TodoResponse {
+	return &dto.TodoResponse{
+		ID:         todo.ID,
+		ProjectID:  todo.ProjectID,
+		ParentID:   todo.ParentID,
+		SeriesID:   todo.SeriesID,
+		AssigneeID: todo.AssigneeID,
+		Content:    todo.Content,
+		Status:     todo.Status,
+		DueAt:      todo.DueAt,
+		RemindAt:   todo.RemindAt,
+		Overdue:    todo.DueAt != nil && todo.DueAt.Before(time.Now()) && !ts.workflow.IsTerminal(todo.Status),
+		Archived:   todo.Archived,
+		Version:    todo.Version,
+		Position:   todo.Position,
+		Recurrence: todo.Recurrence,
+		Progress:   todoProgress(todo),
+		CreatedAt:  todo.CreatedAt,
+		UpdatedAt:  todo.UpdatedAt,
+		DeletedAt:  todo.DeletedAt,
+	}
+}
+
+func todoProgress(todo *re.Todo) *dto.TodoProgress {
+	if todo.ChildrenTotal == 0 {
+		return nil
+	}
+
+	return &dto.TodoProgress{
+		Done:    todo.ChildrenDone,
+		Total:   todo.ChildrenTotal,
+		Percent: float64(todo.ChildrenDone) / float64(todo.ChildrenTotal) * 100,
+	}
+}
+
 func (ts *todoService) ChangeContent(ctx context.Context, changeContentRequest *dto.TodoContentChangeRequest) error {
 	userID, _ := uuid.Parse(ctx.Value("userID").(string))
 	if userID == uuid.Nil {
@@ -116,7 +535,23 @@ func (ts *todoService) ChangeContent(ctx context.Context, changeContentRequest *
 		return err
 	}
 
-	return ts.todoRepo.UpdateContent(ctx, changeContentRequest.NewContent, changeContentRequest.TodoID, userID)
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeContentRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		if err := ts.todoRepo.UpdateContent(ctx, changeContentRequest.NewContent, todo.ID, userID); err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionContent, &todo.Content,
+			&changeContentRequest.NewContent)
+	})
 }
 
 func (ts *todoService) ChangeStatus(ctx context.Context, changeStatusRequest *dto.TodoStatusChangeRequest) error {
@@ -133,18 +568,655 @@ func (ts *todoService) ChangeStatus(ctx context.Context, changeStatusRequest *dt
 		return err
 	}
 
-	return ts.todoRepo.UpdateStatus(ctx, psql.TodoStatus(changeStatusRequest.NewStatus), changeStatusRequest.TodoID, userID)
+	newStatus := changeStatusRequest.NewStatus
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeStatusRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		if todo.Status != newStatus {
+			if err := ts.checkBlockers(ctx, todo, newStatus, nil); err != nil {
+				return err
+			}
+
+			if err := ts.checkWIPLimit(ctx, todo.UserID, newStatus); err != nil {
+				return err
+			}
+		}
+
+		transition := &workflow.Transition{
+			TodoID: todo.ID,
+			UserID: userID,
+			From:   todo.Status,
+			To:     newStatus,
+		}
+
+		err = ts.workflow.Apply(ctx, transition, func(ctx context.Context) error {
+			return ts.todoRepo.UpdateStatus(ctx, psql.TodoStatus(newStatus), todo.ID, userID)
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := ts.recordRevision(ctx, todo.ID, userID, revisionStatus, &todo.Status, &newStatus); err != nil {
+			return err
+		}
+
+		if !ts.workflow.IsTerminal(newStatus) || ts.workflow.IsTerminal(todo.Status) || todo.Recurrence == nil {
+			return nil
+		}
+
+		return ts.spawnNextOccurrence(ctx, todo)
+	})
 }
 
-func (ts *todoService) DeleteTodo(ctx context.Context, todoID uuid.UUID) error {
+func (ts *todoService) spawnNextOccurrence(ctx context.Context, todo *re.Todo) error {
+	start := todo.CreatedAt
+	if todo.RecurrenceStart != nil {
+		start = *todo.RecurrenceStart
+	}
+
+	seriesID := todo.ID
+	if todo.SeriesID != nil {
+		seriesID = *todo.SeriesID
+	}
+
+	recurrence, err := parseRecurrence(*todo.Recurrence, start)
+	if err != nil {
+		return err
+	}
+
+	occurrence := &re.TodoOccurrence{
+		ID:       uuid.New(),
+		SeriesID: seriesID,
+		UserID:   todo.UserID,
+		TodoID:   &todo.ID,
+		Content:  todo.Content,
+		DueAt:    todo.DueAt,
+	}
+
+	if next := nextOccurrence(recurrence, todo.DueAt, time.Now()); next != nil {
+		nextTodo := &re.Todo{
+			ID:              uuid.New(),
+			UserID:          todo.UserID,
+			ProjectID:       todo.ProjectID,
+			ParentID:        todo.ParentID,
+			Content:         todo.Content,
+			Status:          ts.workflow.Initial(),
+			DueAt:           next,
+			Recurrence:      todo.Recurrence,
+			RecurrenceStart: &start,
+			SeriesID:        &seriesID,
+		}
+
+		if todo.DueAt != nil && todo.RemindAt != nil {
+			remindAt := next.Add(todo.RemindAt.Sub(*todo.DueAt))
+			nextTodo.RemindAt = &remindAt
+		}
+
+		following, err := ts.todoRepo.GetNeighborPosition(ctx, todo.Position, true, todo.ID, todo.UserID)
+		if err != nil {
+			return err
+		}
+
+		if nextTodo.Position, err = rank.Between(todo.Position, following); err != nil {
+			return err
+		}
+
+		if err := ts.todoRepo.Create(ctx, nextTodo); err != nil {
+			return err
+		}
+
+		occurrence.NextTodoID = &nextTodo.ID
+	}
+
+	previous := todo.Recurrence
+	todo.Recurrence = nil
+	todo.SeriesID = &seriesID
+	if err := ts.todoRepo.UpdateRecurrence(ctx, todo); err != nil {
+		return err
+	}
+
+	if err := ts.recordRevision(ctx, todo.ID, todo.UserID, revisionRecurrence, previous, nil); err != nil {
+		return err
+	}
+
+	return ts.occurrenceRepo.Create(ctx, occurrence)
+}
+
+func (ts *todoService) ChangeRecurrence(ctx context.Context, changeRecurrenceRequest *dto.TodoRecurrenceChangeRequest) error {
 	userID, _ := uuid.Parse(ctx.Value("userID").(string))
 	if userID == uuid.Nil {
 		return se.ErrInvalidUserID
 	}
 
+	if changeRecurrenceRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoRecurrenceChangeRequest(changeRecurrenceRequest); err != nil {
+		return err
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeRecurrenceRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		previous := todo.Recurrence
+		if changeRecurrenceRequest.Recurrence == "" {
+			todo.Recurrence = nil
+		} else if err := ts.applyRecurrence(todo, changeRecurrenceRequest.Recurrence); err != nil {
+			return err
+		}
+
+		if err := ts.todoRepo.UpdateRecurrence(ctx, todo); err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionRecurrence, previous, todo.Recurrence)
+	})
+}
+
+func (ts *todoService) GetOccurrences(ctx context.Context, todoID uuid.UUID) ([]*dto.TodoOccurrenceResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	todo, err := ts.todoRepo.GetTodoByUserID(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TodoOccurrenceResponse, 0)
+	if todo.SeriesID == nil {
+		return response, nil
+	}
+
+	occurrences, err := ts.occurrenceRepo.GetBySeriesID(ctx, *todo.SeriesID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, occurrence := range occurrences {
+		response = append(response, &dto.TodoOccurrenceResponse{
+			ID:          occurrence.ID,
+			TodoID:      occurrence.TodoID,
+			NextTodoID:  occurrence.NextTodoID,
+			Content:     occurrence.Content,
+			DueAt:       occurrence.DueAt,
+			CompletedAt: occurrence.CompletedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) ChangeSchedule(ctx context.Context, changeScheduleRequest *dto.TodoScheduleChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if changeScheduleRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoScheduleChangeRequest(changeScheduleRequest); err != nil {
+		return err
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeScheduleRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		err = ts.todoRepo.UpdateSchedule(ctx, changeScheduleRequest.DueAt, changeScheduleRequest.RemindAt, todo.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		err = ts.recordRevision(ctx, todo.ID, userID, revisionDueAt, timeValue(todo.DueAt),
+			timeValue(changeScheduleRequest.DueAt))
+		if err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionRemindAt, timeValue(todo.RemindAt),
+			timeValue(changeScheduleRequest.RemindAt))
+	})
+}
+
+func (ts *todoService) ChangeProject(ctx context.Context, changeProjectRequest *dto.TodoProjectChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if changeProjectRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoProjectChangeRequest(changeProjectRequest); err != nil {
+		return err
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeProjectRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if todo.UserID != userID {
+			return se.ErrNotTodoOwner
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		if err := ts.checkProject(ctx, changeProjectRequest.ProjectID, userID); err != nil {
+			return err
+		}
+
+		if err := ts.todoRepo.UpdateProject(ctx, changeProjectRequest.ProjectID, todo.ID, userID); err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionProject, uuidValue(todo.ProjectID),
+			uuidValue(changeProjectRequest.ProjectID))
+	})
+}
+
+func (ts *todoService) ChangeParent(ctx context.Context, changeParentRequest *dto.TodoParentChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if changeParentRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoParentChangeRequest(changeParentRequest); err != nil {
+		return err
+	}
+
+	if changeParentRequest.ParentID != nil && *changeParentRequest.ParentID == changeParentRequest.TodoID {
+		return se.ErrTodoCycle
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, changeParentRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if todo.UserID != userID {
+			return se.ErrNotTodoOwner
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		if changeParentRequest.ParentID != nil {
+			if _, err := ts.lockParent(ctx, *changeParentRequest.ParentID, todo.UserID, userID); err != nil {
+				return err
+			}
+
+			if _, err := ts.userRepo.GetByIDForUpdate(ctx, todo.UserID); err != nil {
+				return err
+			}
+
+			cycle, err := ts.todoRepo.IsAncestor(ctx, todo.ID, *changeParentRequest.ParentID)
+			if err != nil {
+				return err
+			}
+
+			if cycle {
+				return se.ErrTodoCycle
+			}
+		}
+
+		if err := ts.todoRepo.UpdateParent(ctx, changeParentRequest.ParentID, todo.ID, userID); err != nil {
+			return err
+		}
+
+		return ts.recordRevision(ctx, todo.ID, userID, revisionParent, uuidValue(todo.ParentID),
+			uuidValue(changeParentRequest.ParentID))
+	})
+}
+
+func (ts *todoService) MoveTodo(ctx context.Context, moveRequest *dto.TodoPositionChangeRequest) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if moveRequest.TodoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if err := ts.validator.TodoPositionChangeRequest(moveRequest); err != nil {
+		return err
+	}
+
+	if moveRequest.Before == nil && moveRequest.After == nil {
+		return se.ErrInvalidPosition
+	}
+
+	for _, neighborID := range []*uuid.UUID{moveRequest.Before, moveRequest.After} {
+		if neighborID != nil && *neighborID == moveRequest.TodoID {
+			return se.ErrInvalidPosition
+		}
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, moveRequest.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if todo.UserID != userID {
+			return se.ErrNotTodoOwner
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		var lower, upper string
+		if moveRequest.After != nil {
+			after, err := ts.todoRepo.GetTodoForUpdate(ctx, *moveRequest.After, userID)
+			if err != nil || after.UserID != todo.UserID {
+				return se.ErrInvalidNeighbor
+			}
+
+			lower = after.Position
+		}
+
+		if moveRequest.Before != nil {
+			before, err := ts.todoRepo.GetTodoForUpdate(ctx, *moveRequest.Before, userID)
+			if err != nil || before.UserID != todo.UserID {
+				return se.ErrInvalidNeighbor
+			}
+
+			upper = before.Position
+		} else if upper, err = ts.todoRepo.GetNeighborPosition(ctx, lower, true, todo.ID, userID); err != nil {
+			return err
+		}
+
+		if moveRequest.After == nil {
+			if lower, err = ts.todoRepo.GetNeighborPosition(ctx, upper, false, todo.ID, userID); err != nil {
+				return err
+			}
+		}
+
+		position, err := rank.Between(lower, upper)
+		if err != nil {
+			return fmt.Errorf("%w: %w", se.ErrInvalidNeighbor, err)
+		}
+
+		return ts.todoRepo.UpdatePosition(ctx, position, todo.ID, userID)
+	})
+}
+
+func (ts *todoService) GetHistory(ctx context.Context, todoID uuid.UUID) ([]*dto.TodoRevisionResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
 	if todoID == uuid.Nil {
+		return nil, se.ErrInvalidTodoID
+	}
+
+	revisions, err := ts.revisionRepo.GetByTodoID(ctx, todoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TodoRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		response = append(response, &dto.TodoRevisionResponse{
+			ID:        revision.ID,
+			TodoID:    revision.TodoID,
+			ActorID:   revision.ActorID,
+			Field:     revision.Field,
+			OldValue:  revision.OldValue,
+			NewValue:  revision.NewValue,
+			CreatedAt: revision.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) RevertTodo(ctx context.Context, todoID, revisionID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
 		return se.ErrInvalidUserID
 	}
 
-	return ts.todoRepo.Delete(ctx, todoID, userID)
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	if revisionID == uuid.Nil {
+		return se.ErrInvalidRevisionID
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		revision, err := ts.revisionRepo.GetByID(ctx, revisionID, todoID, userID)
+		if err != nil {
+			return se.ErrInvalidRevisionID
+		}
+
+		return ts.revert(ctx, revision, userID)
+	})
+}
+
+func (ts *todoService) revert(ctx context.Context, revision *re.TodoRevision, userID uuid.UUID) error {
+	switch revision.Field {
+	case revisionContent:
+		if revision.OldValue == nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		return ts.ChangeContent(ctx, &dto.TodoContentChangeRequest{
+			TodoID:     revision.TodoID,
+			NewContent: *revision.OldValue,
+		})
+	case revisionStatus:
+		if revision.OldValue == nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		return ts.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{
+			TodoID:    revision.TodoID,
+			NewStatus: *revision.OldValue,
+		})
+	case revisionDueAt, revisionRemindAt:
+		value, err := parseTimeValue(revision.OldValue)
+		if err != nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		todo, err := ts.todoRepo.GetTodoByUserID(ctx, revision.TodoID, userID)
+		if err != nil {
+			return err
+		}
+
+		request := &dto.TodoScheduleChangeRequest{
+			TodoID:   revision.TodoID,
+			DueAt:    todo.DueAt,
+			RemindAt: todo.RemindAt,
+		}
+
+		if revision.Field == revisionDueAt {
+			request.DueAt = value
+		} else {
+			request.RemindAt = value
+		}
+
+		return ts.ChangeSchedule(ctx, request)
+	case revisionProject:
+		projectID, err := parseUUIDValue(revision.OldValue)
+		if err != nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		return ts.ChangeProject(ctx, &dto.TodoProjectChangeRequest{
+			TodoID:    revision.TodoID,
+			ProjectID: projectID,
+		})
+	case revisionParent:
+		parentID, err := parseUUIDValue(revision.OldValue)
+		if err != nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		return ts.ChangeParent(ctx, &dto.TodoParentChangeRequest{
+			TodoID:   revision.TodoID,
+			ParentID: parentID,
+		})
+	case revisionRecurrence:
+		request := &dto.TodoRecurrenceChangeRequest{TodoID: revision.TodoID}
+		if revision.OldValue != nil {
+			request.Recurrence = *revision.OldValue
+		}
+
+		return ts.ChangeRecurrence(ctx, request)
+	case revisionAssignee:
+		assigneeID, err := parseUUIDValue(revision.OldValue)
+		if err != nil {
+			return se.ErrRevisionNotRevertible
+		}
+
+		return ts.ChangeAssignee(ctx, &dto.TodoAssigneeChangeRequest{
+			TodoID:     revision.TodoID,
+			AssigneeID: assigneeID,
+		})
+	}
+
+	return se.ErrRevisionNotRevertible
+}
+
+func (ts *todoService) GetTrash(ctx context.Context) ([]*dto.TodoResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	todos, err := ts.todoRepo.GetTrash(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]*dto.TodoResponse, 0, len(todos))
+	for _, todo := range todos {
+		response = append(response, ts.todoToResponse(todo))
+	}
+
+	return response, nil
+}
+
+func (ts *todoService) GetSharedTodos(ctx context.Context) ([]*dto.TodoResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	todos, err := ts.todoRepo.GetShared(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ts.todosToResponse(todos), nil
+}
+
+func (ts *todoService) RestoreTodo(ctx context.Context, todoID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := ts.todoRepo.Restore(ctx, todoID, userID); err != nil {
+			return err
+		}
+
+		todo, err := ts.todoRepo.GetTodoByUserID(ctx, todoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if todo.ParentID == nil {
+			return nil
+		}
+
+		if _, err := ts.todoRepo.GetTodoByUserID(ctx, *todo.ParentID, userID); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+
+			if err := ts.todoRepo.UpdateParent(ctx, nil, todoID, userID); err != nil {
+				return err
+			}
+
+			return ts.recordRevision(ctx, todoID, userID, revisionParent, uuidValue(todo.ParentID), nil)
+		}
+
+		return nil
+	})
+}
+
+func (ts *todoService) DeleteTodo(ctx context.Context, todoID uuid.UUID) error {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return se.ErrInvalidUserID
+	}
+
+	if todoID == uuid.Nil {
+		return se.ErrInvalidTodoID
+	}
+
+	return ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		todo, err := ts.todoRepo.GetTodoForUpdate(ctx, todoID, userID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkVersion(ctx, todo.Version); err != nil {
+			return err
+		}
+
+		return ts.todoRepo.Delete(ctx, todo.ID, userID)
+	})
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+const templateDateVariable string = "date"
+
+func (ts *todoService) InstantiateTemplate(ctx context.Context,
+	instantiateRequest *dto.TemplateInstantiateRequest) (*dto.TemplateInstantiateResponse, error) {
+	userID, _ := uuid.Parse(ctx.Value("userID").(string))
+	if userID == uuid.Nil {
+		return nil, se.ErrInvalidUserID
+	}
+
+	if err := ts.validator.TemplateInstantiateRequestValidate(instantiateRequest); err != nil {
+		return nil, err
+	}
+
+	template, err := ts.templateRepo.GetByID(ctx, instantiateRequest.TemplateID, userID)
+	if err != nil {
+		return nil, se.ErrInvalidTemplateID
+	}
+
+	now := time.Now()
+	variables := map[string]string{templateDateVariable: now.UTC().Format(time.DateOnly)}
+	for name, value := range instantiateRequest.Variables {
+		variables[name] = value
+	}
+
+	response := &dto.TemplateInstantiateResponse{TodoIDs: make([]uuid.UUID, 0)}
+
+	err = ts.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		projectID := instantiateRequest.ProjectID
+		if instantiateRequest.ParentID != nil {
+			parent, err := ts.lockParent(ctx, *instantiateRequest.ParentID, userID, userID)
+			if err != nil {
+				return err
+			}
+
+			if projectID == nil {
+				projectID = parent.ProjectID
+			}
+		}
+
+		if err := ts.checkProject(ctx, projectID, userID); err != nil {
+			return err
+		}
+
+		todos := make([]*re.Todo, 0)
+
+		var build func(items []*re.TemplateItem, parentID *uuid.UUID) error
+		build = func(items []*re.TemplateItem, parentID *uuid.UUID) error {
+			for _, item := range items {
+				content, err := renderTemplate(item.Content, variables)
+				if err != nil {
+					return err
+				}
+
+				todo := &re.Todo{
+					ID:        uuid.New(),
+					UserID:    userID,
+					ProjectID: projectID,
+					ParentID:  parentID,
+					Content:   content,
+					Status:    ts.workflow.Initial(),
+				}
+
+				if item.DueInDays != nil {
+					dueAt := now.AddDate(0, 0, *item.DueInDays)
+					todo.DueAt = &dueAt
+				}
+
+				todos = append(todos, todo)
+				if err := build(item.Children, &todo.ID); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		if err := build(template.Items, instantiateRequest.ParentID); err != nil {
+			return err
+		}
+
+		batch := &todoBatch{}
+		for _, todo := range todos {
+			if err := ts.reserveBatchWIP(ctx, batch, userID, "", todo.Status); err != nil {
+				return err
+			}
+		}
+
+		if err := ts.appendPositions(ctx, userID, todos...); err != nil {
+			return err
+		}
+
+		for _, todo := range todos {
+			if err := ts.todoRepo.Create(ctx, todo); err != nil {
+				return err
+			}
+
+			response.TodoIDs = append(response.TodoIDs, todo.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
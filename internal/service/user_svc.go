@@ -13,19 +13,21 @@ import (
 )
 
 type userService struct {
-	userRepo  psql.UserRepository
-	validator *se.Validator
-	hasher    hash.Hasher
+	userRepo   psql.UserRepository
+	transactor psql.Transactor
+	validator  *se.Validator
+	hasher     hash.Hasher
 }
 
-func NewUserService(ur psql.UserRepository) se.UserUseCases {
+func NewUserService(ur psql.UserRepository, tx psql.Transactor) se.UserUseCases {
 	v := se.InitValidator()
 	h := hash.NewHasher()
 
 	return &userService{
-		userRepo:  ur,
-		validator: v,
-		hasher:    h,
+		userRepo:   ur,
+		transactor: tx,
+		validator:  v,
+		hasher:     h,
 	}
 }
 
@@ -36,9 +38,10 @@ func (us *userService) GetUser(ctx context.Context, userID uuid.UUID) (*dto.User
 	}
 
 	response := &dto.UserResponse{
-		ID:    user.ID,
-		Email: user.Email,
-		Name:  user.Name,
+		ID:      user.ID,
+		Email:   user.Email,
+		Name:    user.Name,
+		Version: user.Version,
 	}
 
 	return response, nil
@@ -59,9 +62,10 @@ func (us *userService) usersToResponse(users []*entity.User) []*dto.UserResponse
 	response := make([]*dto.UserResponse, 0, len(users))
 	for _, user := range users {
 		response = append(response, &dto.UserResponse{
-			ID:    user.ID,
-			Email: user.Email,
-			Name:  user.Name,
+			ID:      user.ID,
+			Email:   user.Email,
+			Name:    user.Name,
+			Version: user.Version,
 		})
 	}
 
@@ -73,16 +77,22 @@ func (us *userService) ChangeName(ctx context.Context, changeNameRequest *dto.Ch
 		return err
 	}
 
-	user, err := us.userRepo.GetByID(ctx, changeNameRequest.ID)
-	if err != nil {
-		return se.ErrInvalidUserID
-	}
+	return us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := us.userRepo.GetByIDForUpdate(ctx, changeNameRequest.ID)
+		if err != nil {
+			return se.ErrInvalidUserID
+		}
 
-	if err := us.hasher.CompareHashAndPassword(user.Password, changeNameRequest.Password); err != nil {
-		return se.ErrInvalidPassword
-	}
+		if err := checkVersion(ctx, user.Version); err != nil {
+			return err
+		}
+
+		if err := us.hasher.CompareHashAndPassword(user.Password, changeNameRequest.Password); err != nil {
+			return se.ErrInvalidPassword
+		}
 
-	return us.userRepo.ChangeName(ctx, changeNameRequest.Name, changeNameRequest.ID)
+		return us.userRepo.ChangeName(ctx, changeNameRequest.Name, changeNameRequest.ID)
+	})
 }
 
 func (us *userService) ChangeEmail(ctx context.Context, changeEmailRequest *dto.ChangeUserEmailRequest) error {
@@ -90,16 +100,22 @@ func (us *userService) ChangeEmail(ctx context.Context, changeEmailRequest *dto.
 		return err
 	}
 
-	user, err := us.userRepo.GetByID(ctx, changeEmailRequest.ID)
-	if err != nil {
-		return se.ErrInvalidUserID
-	}
+	return us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := us.userRepo.GetByIDForUpdate(ctx, changeEmailRequest.ID)
+		if err != nil {
+			return se.ErrInvalidUserID
+		}
 
-	if err := us.hasher.CompareHashAndPassword(user.Password, changeEmailRequest.Password); err != nil {
-		return se.ErrInvalidPassword
-	}
+		if err := checkVersion(ctx, user.Version); err != nil {
+			return err
+		}
 
-	return us.userRepo.ChangeEmail(ctx, changeEmailRequest.Email, changeEmailRequest.ID)
+		if err := us.hasher.CompareHashAndPassword(user.Password, changeEmailRequest.Password); err != nil {
+			return se.ErrInvalidPassword
+		}
+
+		return us.userRepo.ChangeEmail(ctx, changeEmailRequest.Email, changeEmailRequest.ID)
+	})
 }
 
 func (us *userService) ChangePassword(ctx context.Context, changePasswordRequest *dto.ChangeUserPasswordRequest) error {
@@ -107,21 +123,27 @@ func (us *userService) ChangePassword(ctx context.Context, changePasswordRequest
 		return err
 	}
 
-	user, err := us.userRepo.GetByID(ctx, changePasswordRequest.ID)
-	if err != nil {
-		return se.ErrInvalidUserID
-	}
+	return us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := us.userRepo.GetByIDForUpdate(ctx, changePasswordRequest.ID)
+		if err != nil {
+			return se.ErrInvalidUserID
+		}
 
-	if err := us.hasher.CompareHashAndPassword(user.Password, changePasswordRequest.OldPassword); err != nil {
-		return se.ErrInvalidPassword
-	}
+		if err := checkVersion(ctx, user.Version); err != nil {
+			return err
+		}
 
-	hashedPassword, err := us.hasher.HashPassword(changePasswordRequest.NewPassword)
-	if err != nil {
-		return err
-	}
+		if err := us.hasher.CompareHashAndPassword(user.Password, changePasswordRequest.OldPassword); err != nil {
+			return se.ErrInvalidPassword
+		}
 
-	return us.userRepo.ChangePassword(ctx, hashedPassword, changePasswordRequest.ID)
+		hashedPassword, err := us.hasher.HashPassword(changePasswordRequest.NewPassword)
+		if err != nil {
+			return err
+		}
+
+		return us.userRepo.ChangePassword(ctx, hashedPassword, changePasswordRequest.ID)
+	})
 }
 
 func (us *userService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
@@ -129,10 +151,16 @@ func (us *userService) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 		return se.ErrInvalidUserID
 	}
 
-	_, err := us.userRepo.GetByID(ctx, userID)
-	if err != nil {
-		return se.ErrInvalidUserID
-	}
+	return us.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := us.userRepo.GetByIDForUpdate(ctx, userID)
+		if err != nil {
+			return se.ErrInvalidUserID
+		}
+
+		if err := checkVersion(ctx, user.Version); err != nil {
+			return err
+		}
 
-	return us.userRepo.Delete(ctx, userID)
+		return us.userRepo.Delete(ctx, userID)
+	})
 }
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"context"
+
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+)
+
+func checkVersion(ctx context.Context, current int) error {
+	expected, ok := ctx.Value("ifMatch").(int)
+	if !ok {
+		return nil
+	}
+
+	if expected != current {
+		return se.ErrVersionMismatch
+	}
+
+	return nil
+}
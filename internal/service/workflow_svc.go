@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/internal/workflow"
+)
+
+type workflowService struct {
+	workflow *workflow.Workflow
+}
+
+func NewWorkflowService(wf *workflow.Workflow) se.WorkflowUseCases {
+	return &workflowService{workflow: wf}
+}
+
+func (ws *workflowService) GetWorkflow(ctx context.Context) *dto.WorkflowResponse {
+	states := make([]*dto.WorkflowStateResponse, 0, len(ws.workflow.States()))
+	for _, state := range ws.workflow.States() {
+		transitions := state.Transitions
+		if transitions == nil {
+			transitions = []string{}
+		}
+
+		states = append(states, &dto.WorkflowStateResponse{
+			Name:        state.Name,
+			Label:       state.Label,
+			Terminal:    state.Terminal,
+			Transitions: transitions,
+			WIPLimit:    ws.workflow.WIPLimit(state.Name),
+		})
+	}
+
+	return &dto.WorkflowResponse{
+		Initial: ws.workflow.Initial(),
+		States:  states,
+	}
+}
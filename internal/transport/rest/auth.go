@@ -2,9 +2,11 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
+	"github.com/google/uuid"
 	"github.com/identicalaffiliation/app/internal/dto"
 	se "github.com/identicalaffiliation/app/internal/service/entity"
 	"github.com/identicalaffiliation/app/pkg/network"
@@ -91,3 +93,40 @@ func (ah *authHandler) SignIn(w http.ResponseWriter, r *http.Request) {
 
 	ah.nw.AuthResponse(w, authData)
 }
+
+func (ah *authHandler) SwitchOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		ah.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		ah.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := ah.authService.SwitchOrganization(r.Context(), orgID)
+	if err != nil {
+		if errors.Is(err, se.ErrNotOrgMember) {
+			ah.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+			return
+		}
+
+		ah.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	authData, err := json.Marshal(response)
+	if err != nil {
+		ah.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ah.nw.AuthResponse(w, authData)
+}
@@ -0,0 +1,248 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type commentHandler struct {
+	commentService se.CommentUseCases
+	nw             network.NetworkWriter
+}
+
+func NewCommentHandler(cs se.CommentUseCases) CommentHandler {
+	nw := network.NewNetworkWriter()
+
+	return &commentHandler{
+		commentService: cs,
+		nw:             nw,
+	}
+}
+
+func (ch *commentHandler) NewComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.CommentCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ch.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := ch.commentService.CreateComment(r.Context(), &request); err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ch.nw.CreatedResponse(w)
+}
+
+func (ch *commentHandler) TodoComments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ch.comments(w, r, &dto.CommentListRequest{TodoID: todoID})
+}
+
+func (ch *commentHandler) CommentReplies(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ch.comments(w, r, &dto.CommentListRequest{TodoID: todoID, ParentID: &commentID})
+}
+
+func (ch *commentHandler) ChangeComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.CommentUpdateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ch.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	request.CommentID = commentID
+	if err := ch.commentService.UpdateComment(r.Context(), &request); err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ch.nw.Response(w)
+}
+
+func (ch *commentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := ch.commentService.DeleteComment(r.Context(), todoID, commentID); err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ch.nw.Response(w)
+}
+
+func (ch *commentHandler) CommentHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ch.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	commentID, err := uuid.Parse(r.PathValue("commentID"))
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := ch.commentService.GetCommentHistory(r.Context(), todoID, commentID)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	historyData, err := json.Marshal(response)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ch.nw.JSONResponse(w, historyData)
+}
+
+func (ch *commentHandler) comments(w http.ResponseWriter, r *http.Request, request *dto.CommentListRequest) {
+	values := r.URL.Query()
+	request.Cursor = values.Get("cursor")
+
+	limit, err := queryInt(values, "limit")
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	request.Limit = limit
+	response, err := ch.commentService.GetComments(r.Context(), request)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	commentData, err := json.Marshal(response)
+	if err != nil {
+		ch.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ch.nw.JSONResponse(w, commentData)
+}
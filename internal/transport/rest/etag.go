@@ -0,0 +1,22 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func formatETag(version int) string {
+	return fmt.Sprintf("%q", strconv.Itoa(version))
+}
+
+func parseETag(value string) (int, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(unquoted)
+}
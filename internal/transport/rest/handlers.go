@@ -7,6 +7,7 @@ import (
 type AuthHandler interface {
 	SignUp(w http.ResponseWriter, r *http.Request)
 	SignIn(w http.ResponseWriter, r *http.Request)
+	SwitchOrganization(w http.ResponseWriter, r *http.Request)
 }
 
 type UserHandler interface {
@@ -19,8 +20,109 @@ type UserHandler interface {
 type TodoHandler interface {
 	NewTodo(w http.ResponseWriter, r *http.Request)
 	MyTodo(w http.ResponseWriter, r *http.Request)
+	MyTodoTree(w http.ResponseWriter, r *http.Request)
+	TodoOccurrences(w http.ResponseWriter, r *http.Request)
 	MyTodos(w http.ResponseWriter, r *http.Request)
+	SearchTodos(w http.ResponseWriter, r *http.Request)
+	TodoBoard(w http.ResponseWriter, r *http.Request)
 	ChangeTodoContent(w http.ResponseWriter, r *http.Request)
 	ChangeTodoStatus(w http.ResponseWriter, r *http.Request)
+	ChangeTodoSchedule(w http.ResponseWriter, r *http.Request)
+	ChangeTodoProject(w http.ResponseWriter, r *http.Request)
+	ChangeTodoAssignee(w http.ResponseWriter, r *http.Request)
+	ChangeTodoParent(w http.ResponseWriter, r *http.Request)
+	ChangeTodoRecurrence(w http.ResponseWriter, r *http.Request)
+	ChangeTodoPosition(w http.ResponseWriter, r *http.Request)
+	AddTodoBlocker(w http.ResponseWriter, r *http.Request)
+	RemoveTodoBlocker(w http.ResponseWriter, r *http.Request)
+	InstantiateTemplate(w http.ResponseWriter, r *http.Request)
+	TodoHistory(w http.ResponseWriter, r *http.Request)
+	RevertTodo(w http.ResponseWriter, r *http.Request)
+	TrashTodos(w http.ResponseWriter, r *http.Request)
+	SharedTodos(w http.ResponseWriter, r *http.Request)
+	AssignedTodos(w http.ResponseWriter, r *http.Request)
+	RestoreTodo(w http.ResponseWriter, r *http.Request)
 	DeleteTodo(w http.ResponseWriter, r *http.Request)
+	BatchTodos(w http.ResponseWriter, r *http.Request)
+}
+
+type LabelHandler interface {
+	NewLabel(w http.ResponseWriter, r *http.Request)
+	MyLabel(w http.ResponseWriter, r *http.Request)
+	MyLabels(w http.ResponseWriter, r *http.Request)
+	ChangeLabel(w http.ResponseWriter, r *http.Request)
+	DeleteLabel(w http.ResponseWriter, r *http.Request)
+	TodoLabels(w http.ResponseWriter, r *http.Request)
+	AttachLabel(w http.ResponseWriter, r *http.Request)
+	DetachLabel(w http.ResponseWriter, r *http.Request)
+}
+
+type ProjectHandler interface {
+	NewProject(w http.ResponseWriter, r *http.Request)
+	MyProject(w http.ResponseWriter, r *http.Request)
+	MyProjects(w http.ResponseWriter, r *http.Request)
+	ChangeProject(w http.ResponseWriter, r *http.Request)
+	ArchiveProject(w http.ResponseWriter, r *http.Request)
+	DeleteProject(w http.ResponseWriter, r *http.Request)
+}
+
+type ShareHandler interface {
+	ShareTodo(w http.ResponseWriter, r *http.Request)
+	ShareProject(w http.ResponseWriter, r *http.Request)
+	TodoCollaborators(w http.ResponseWriter, r *http.Request)
+	ProjectCollaborators(w http.ResponseWriter, r *http.Request)
+	RevokeTodoShare(w http.ResponseWriter, r *http.Request)
+	RevokeProjectShare(w http.ResponseWriter, r *http.Request)
+}
+
+type LinkHandler interface {
+	NewLink(w http.ResponseWriter, r *http.Request)
+	MyLinks(w http.ResponseWriter, r *http.Request)
+	RevokeLink(w http.ResponseWriter, r *http.Request)
+	PublicTodos(w http.ResponseWriter, r *http.Request)
+}
+
+type OrganizationHandler interface {
+	NewOrganization(w http.ResponseWriter, r *http.Request)
+	MyOrganizations(w http.ResponseWriter, r *http.Request)
+	OrganizationMembers(w http.ResponseWriter, r *http.Request)
+	ChangeMemberRole(w http.ResponseWriter, r *http.Request)
+	RemoveMember(w http.ResponseWriter, r *http.Request)
+}
+
+type InvitationHandler interface {
+	NewInvitation(w http.ResponseWriter, r *http.Request)
+	OrganizationInvitations(w http.ResponseWriter, r *http.Request)
+	CancelInvitation(w http.ResponseWriter, r *http.Request)
+	AcceptInvitation(w http.ResponseWriter, r *http.Request)
+}
+
+type CommentHandler interface {
+	NewComment(w http.ResponseWriter, r *http.Request)
+	TodoComments(w http.ResponseWriter, r *http.Request)
+	CommentReplies(w http.ResponseWriter, r *http.Request)
+	ChangeComment(w http.ResponseWriter, r *http.Request)
+	DeleteComment(w http.ResponseWriter, r *http.Request)
+	CommentHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type TemplateHandler interface {
+	NewTemplate(w http.ResponseWriter, r *http.Request)
+	MyTemplate(w http.ResponseWriter, r *http.Request)
+	MyTemplates(w http.ResponseWriter, r *http.Request)
+	ChangeTemplate(w http.ResponseWriter, r *http.Request)
+	DeleteTemplate(w http.ResponseWriter, r *http.Request)
+}
+
+type TimeEntryHandler interface {
+	StartTimer(w http.ResponseWriter, r *http.Request)
+	StopTimer(w http.ResponseWriter, r *http.Request)
+	RunningTimer(w http.ResponseWriter, r *http.Request)
+	NewTimeEntry(w http.ResponseWriter, r *http.Request)
+	TodoTime(w http.ResponseWriter, r *http.Request)
+	TimeTotals(w http.ResponseWriter, r *http.Request)
+}
+
+type WorkflowHandler interface {
+	Workflow(w http.ResponseWriter, r *http.Request)
 }
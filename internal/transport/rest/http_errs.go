@@ -5,4 +5,9 @@ import "errors"
 var (
 	ErrInvalidMethod   error = errors.New("invalid http method")
 	ErrInvalidJSONBody error = errors.New("invalid JSON body")
+
+	ErrInvalidQueryParam error = errors.New("invalid query parameter")
+	ErrInvalidIfMatch    error = errors.New("invalid If-Match header")
+
+	ErrInvalidIdempotencyKey error = errors.New("invalid Idempotency-Key header")
 )
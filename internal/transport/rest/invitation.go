@@ -0,0 +1,199 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type invitationHandler struct {
+	invitationService se.InvitationUseCases
+	nw                network.NetworkWriter
+}
+
+func NewInvitationHandler(is se.InvitationUseCases) InvitationHandler {
+	nw := network.NewNetworkWriter()
+
+	return &invitationHandler{
+		invitationService: is,
+		nw:                nw,
+	}
+}
+
+func (ih *invitationHandler) NewInvitation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		ih.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.InvitationCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ih.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.OrgID = orgID
+
+	response, err := ih.invitationService.Invite(r.Context(), &request)
+	if err != nil {
+		ih.errorResponse(w, err)
+
+		return
+	}
+
+	invitationData, err := json.Marshal(response)
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ih.nw.JSONResponse(w, invitationData)
+}
+
+func (ih *invitationHandler) OrganizationInvitations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ih.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := ih.invitationService.GetInvitations(r.Context(), orgID)
+	if err != nil {
+		ih.errorResponse(w, err)
+
+		return
+	}
+
+	invitationData, err := json.Marshal(response)
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ih.nw.JSONResponse(w, invitationData)
+}
+
+func (ih *invitationHandler) CancelInvitation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		ih.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	invitationID, err := uuid.Parse(r.PathValue("invitationID"))
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := ih.invitationService.CancelInvitation(r.Context(), orgID, invitationID); err != nil {
+		ih.errorResponse(w, err)
+
+		return
+	}
+
+	ih.nw.Response(w)
+}
+
+func (ih *invitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		ih.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.InvitationAcceptRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			ih.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	request.Token = r.PathValue("token")
+
+	response, err := ih.invitationService.AcceptInvitation(r.Context(), &request)
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidInvitation) {
+			ih.nw.ErrorResponse(w, err, http.StatusNotFound)
+
+			return
+		}
+
+		ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	acceptData, err := json.Marshal(response)
+	if err != nil {
+		ih.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ih.nw.JSONResponse(w, acceptData)
+}
+
+func (ih *invitationHandler) errorResponse(w http.ResponseWriter, err error) {
+	if errors.Is(err, se.ErrNotOrgMember) || errors.Is(err, se.ErrOrgForbidden) {
+		ih.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+		return
+	}
+
+	if errors.Is(err, se.ErrAlreadyOrgMember) {
+		ih.nw.ErrorResponse(w, err, http.StatusConflict)
+
+		return
+	}
+
+	ih.nw.ErrorResponse(w, err, http.StatusBadRequest)
+}
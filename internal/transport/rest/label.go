@@ -0,0 +1,271 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type labelHandler struct {
+	labelService se.LabelUseCases
+	nw           network.NetworkWriter
+}
+
+func NewLabelHandler(ls se.LabelUseCases) LabelHandler {
+	nw := network.NewNetworkWriter()
+
+	return &labelHandler{
+		labelService: ls,
+		nw:           nw,
+	}
+}
+
+func (lh *labelHandler) NewLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.LabelCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		lh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := lh.labelService.CreateLabel(r.Context(), &request); err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	lh.nw.CreatedResponse(w)
+}
+
+func (lh *labelHandler) MyLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	labelID, err := uuid.Parse(r.PathValue("labelID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := lh.labelService.GetLabel(r.Context(), labelID)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	labelData, err := json.Marshal(response)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	lh.nw.LabelFoundResponse(w, labelData)
+}
+
+func (lh *labelHandler) MyLabels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := lh.labelService.GetLabels(r.Context())
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	labelData, err := json.Marshal(response)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	lh.nw.LabelFoundResponse(w, labelData)
+}
+
+func (lh *labelHandler) ChangeLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	labelID, err := uuid.Parse(r.PathValue("labelID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.LabelUpdateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		lh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.LabelID = labelID
+	if err := lh.labelService.UpdateLabel(r.Context(), &request); err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	lh.nw.Response(w)
+}
+
+func (lh *labelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	labelID, err := uuid.Parse(r.PathValue("labelID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := lh.labelService.DeleteLabel(r.Context(), labelID); err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	lh.nw.Response(w)
+}
+
+func (lh *labelHandler) TodoLabels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := lh.labelService.GetTodoLabels(r.Context(), todoID)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	labelData, err := json.Marshal(response)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	lh.nw.LabelFoundResponse(w, labelData)
+}
+
+func (lh *labelHandler) AttachLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.LabelAttachRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		lh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := lh.labelService.AttachLabel(r.Context(), &request); err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	lh.nw.Response(w)
+}
+
+func (lh *labelHandler) DetachLabel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		lh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	labelID, err := uuid.Parse(r.PathValue("labelID"))
+	if err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := lh.labelService.DetachLabel(r.Context(), todoID, labelID); err != nil {
+		lh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	lh.nw.Response(w)
+}
@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type linkHandler struct {
+	linkService se.LinkUseCases
+	nw          network.NetworkWriter
+}
+
+func NewLinkHandler(ls se.LinkUseCases) LinkHandler {
+	nw := network.NewNetworkWriter()
+
+	return &linkHandler{
+		linkService: ls,
+		nw:          nw,
+	}
+}
+
+func (kh *linkHandler) NewLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		kh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.LinkCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		kh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := kh.linkService.CreateLink(r.Context(), &request)
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	linkData, err := json.Marshal(response)
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	kh.nw.JSONResponse(w, linkData)
+}
+
+func (kh *linkHandler) MyLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		kh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := kh.linkService.GetLinks(r.Context())
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	linkData, err := json.Marshal(response)
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	kh.nw.JSONResponse(w, linkData)
+}
+
+func (kh *linkHandler) RevokeLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		kh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	linkID, err := uuid.Parse(r.PathValue("linkID"))
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := kh.linkService.RevokeLink(r.Context(), linkID); err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	kh.nw.Response(w)
+}
+
+func (kh *linkHandler) PublicTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		kh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	limit, err := queryInt(r.URL.Query(), "limit")
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	request := &dto.PublicTodosRequest{
+		Token:  r.PathValue("token"),
+		Cursor: r.URL.Query().Get("cursor"),
+		Limit:  limit,
+	}
+
+	response, err := kh.linkService.OpenLink(r.Context(), request)
+	if err != nil {
+		if errors.Is(err, se.ErrInvalidLinkToken) {
+			kh.nw.ErrorResponse(w, err, http.StatusNotFound)
+
+			return
+		}
+
+		kh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		kh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	kh.nw.JSONResponse(w, todoData)
+}
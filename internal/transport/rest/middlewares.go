@@ -1,15 +1,25 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io"
+	"net"
 	"net/http"
 	"strings"
 
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
 	"github.com/identicalaffiliation/app/pkg/jwtoken"
 )
 
-func authMiddleware(tokenValidator jwtoken.TokenValidator) func(http.Handler) http.Handler {
+const maxIdempotencyKeyLength int = 255
+
+func authMiddleware(tokenValidator jwtoken.TokenValidator, os se.OrganizationUseCases) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -45,11 +55,174 @@ func authMiddleware(tokenValidator jwtoken.TokenValidator) func(http.Handler) ht
 				return
 			}
 
+			claimUserID, _ := claims["userID"].(string)
+			claimOrgID, _ := claims["orgID"].(string)
+			userID, _ := uuid.Parse(claimUserID)
+			orgID, _ := uuid.Parse(claimOrgID)
+			if err := os.CheckMembership(r.Context(), orgID, userID); err != nil {
+				switch {
+				case errors.Is(err, se.ErrNotOrgMember), errors.Is(err, se.ErrInvalidOrgID),
+					errors.Is(err, se.ErrInvalidUserID):
+					http.Error(w, err.Error(), http.StatusUnauthorized)
+				default:
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+				}
+
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), "userID", claims["userID"])
 			ctx = context.WithValue(ctx, "email", claims["email"])
+			ctx = context.WithValue(ctx, "orgID", claims["orgID"])
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+func ifMatchMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("If-Match")
+		if header == "" || header == "*" || r.Method != http.MethodPatch && r.Method != http.MethodDelete {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		version, err := parseETag(header)
+		if err != nil {
+			http.Error(w, ErrInvalidIfMatch.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "ifMatch", version)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func idempotencyMiddleware(is se.IdempotencyUseCases) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get("Idempotency-Key")
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead ||
+				r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				http.Error(w, ErrInvalidIdempotencyKey.Error(), http.StatusBadRequest)
+
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			hash := sha256.New()
+			hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			hash.Write(body)
+			fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+			scope := idempotencyScope(r)
+			stored, err := is.Begin(r.Context(), scope, key, fingerprint)
+			switch {
+			case errors.Is(err, se.ErrIdempotencyKeyReused):
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+
+				return
+			case errors.Is(err, se.ErrIdempotencyInProgress):
+				http.Error(w, err.Error(), http.StatusConflict)
+
+				return
+			case err != nil:
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			case stored != nil:
+				for name, values := range stored.Header {
+					w.Header()[name] = values
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.Body)
+
+				return
+			}
+
+			completed := false
+			defer func() {
+				if !completed {
+					is.Release(context.WithoutCancel(r.Context()), scope, key)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status >= http.StatusInternalServerError {
+				return
+			}
+
+			response := &dto.IdempotentResponse{
+				StatusCode: recorder.status,
+				Header:     w.Header().Clone(),
+				Body:       recorder.body.Bytes(),
+			}
+
+			if err := is.Complete(context.WithoutCancel(r.Context()), scope, key, response); err != nil {
+				return
+			}
+
+			completed = true
+		})
+	}
+}
+
+func idempotencyScope(r *http.Request) string {
+	if userID, ok := r.Context().Value("userID").(string); ok && userID != "" {
+		orgID, _ := r.Context().Value("orgID").(string)
+
+		return "user:" + userID + ":org:" + orgID
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	client := sha256.Sum256([]byte(host))
+
+	return "anonymous:" + hex.EncodeToString(client[:])
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(status int) {
+	if !rr.wroteHeader {
+		rr.status = status
+		rr.wroteHeader = true
+	}
+
+	rr.ResponseWriter.WriteHeader(status)
+}
+
+func (rr *responseRecorder) Write(data []byte) (int, error) {
+	rr.wroteHeader = true
+	rr.body.Write(data)
+
+	return rr.ResponseWriter.Write(data)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/identicalaffiliation/app/internal/config"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
 	"github.com/identicalaffiliation/app/pkg/jwtoken"
 )
 
@@ -12,38 +13,177 @@ type Router struct {
 	mux *chi.Mux
 }
 
-func NewRouter(cfg *config.AppConfig, ah AuthHandler, uh UserHandler, th TodoHandler) *Router {
+func NewRouter(cfg *config.AppConfig, ah AuthHandler, uh UserHandler, th TodoHandler, lh LabelHandler,
+	ph ProjectHandler, sh ShareHandler, kh LinkHandler, oh OrganizationHandler, ih InvitationHandler,
+	ch CommentHandler, mh TemplateHandler, eh TimeEntryHandler, wh WorkflowHandler,
+	os se.OrganizationUseCases, is se.IdempotencyUseCases) *Router {
 	mux := chi.NewRouter()
 	tokenValidator := jwtoken.NewTokenValidator(cfg.JWTSecret)
 
 	mux.Use(middleware.Logger)
 	mux.Use(middleware.Recoverer)
 
+	mux.Post("/api/login", ah.SignIn)
+
 	mux.Group(func(r chi.Router) {
+		r.Use(idempotencyMiddleware(is))
+
 		r.Post("/api/register", ah.SignUp)
-		r.Post("/api/login", ah.SignIn)
+		r.Post("/api/invitations/{token}/accept", ih.AcceptInvitation)
 	})
 
+	mux.Get("/public/todos/{token}", kh.PublicTodos)
+
 	mux.Group(func(r chi.Router) {
-		r.Use(authMiddleware(tokenValidator))
+		r.Use(authMiddleware(tokenValidator, os))
+		r.Use(idempotencyMiddleware(is))
+
+		r.Get("/api/workflow", wh.Workflow)
+
+		r.Route("/api/orgs", func(r chi.Router) {
+			r.Post("/", oh.NewOrganization)
+			r.Get("/", oh.MyOrganizations)
+
+			r.Route("/{orgID}", func(r chi.Router) {
+				r.Post("/token", ah.SwitchOrganization)
+				r.Get("/members", oh.OrganizationMembers)
+				r.Patch("/members/{memberID}", oh.ChangeMemberRole)
+				r.Delete("/members/{memberID}", oh.RemoveMember)
+
+				r.Route("/invitations", func(r chi.Router) {
+					r.Post("/", ih.NewInvitation)
+					r.Get("/", ih.OrganizationInvitations)
+					r.Delete("/{invitationID}", ih.CancelInvitation)
+				})
+			})
+		})
 
 		r.Route("/api/users", func(r chi.Router) {
 
 			r.Route("/me", func(r chi.Router) {
 				r.Get("/", uh.MyProfile)
-				r.Patch("/name", uh.ChangeMyName)
-				r.Patch("/email", uh.ChangeMyEmail)
-				r.Patch("/password", uh.ChangeMyPassword)
 
+				r.Group(func(r chi.Router) {
+					r.Use(ifMatchMiddleware)
+
+					r.Patch("/name", uh.ChangeMyName)
+					r.Patch("/email", uh.ChangeMyEmail)
+					r.Patch("/password", uh.ChangeMyPassword)
+				})
+
+				r.Get("/board", th.TodoBoard)
+				r.Get("/assigned", th.AssignedTodos)
+				r.Get("/time", eh.TimeTotals)
+				r.Get("/timer", eh.RunningTimer)
+				r.Post("/timer/stop", eh.StopTimer)
+				r.Post("/todos:batch", th.BatchTodos)
 				r.Route("/todos", func(r chi.Router) {
 					r.Post("/", th.NewTodo)
 					r.Get("/", th.MyTodos)
+					r.Get("/search", th.SearchTodos)
+					r.Get("/trash", th.TrashTodos)
+					r.Get("/shared", th.SharedTodos)
 
 					r.Route("/{todoID}", func(r chi.Router) {
+						r.Use(ifMatchMiddleware)
+
 						r.Get("/", th.MyTodo)
+						r.Get("/tree", th.MyTodoTree)
+						r.Get("/occurrences", th.TodoOccurrences)
+						r.Get("/history", th.TodoHistory)
 						r.Patch("/content", th.ChangeTodoContent)
 						r.Patch("/status", th.ChangeTodoStatus)
+						r.Patch("/due", th.ChangeTodoSchedule)
+						r.Patch("/project", th.ChangeTodoProject)
+						r.Patch("/assignee", th.ChangeTodoAssignee)
+						r.Patch("/parent", th.ChangeTodoParent)
+						r.Patch("/recurrence", th.ChangeTodoRecurrence)
+						r.Patch("/position", th.ChangeTodoPosition)
+						r.Post("/restore", th.RestoreTodo)
+						r.Post("/revert/{revisionID}", th.RevertTodo)
 						r.Delete("/", th.DeleteTodo)
+
+						r.Route("/labels", func(r chi.Router) {
+							r.Get("/", lh.TodoLabels)
+							r.Post("/", lh.AttachLabel)
+							r.Delete("/{labelID}", lh.DetachLabel)
+						})
+
+						r.Post("/timer", eh.StartTimer)
+						r.Get("/time", eh.TodoTime)
+						r.Post("/time", eh.NewTimeEntry)
+
+						r.Route("/blockers", func(r chi.Router) {
+							r.Post("/", th.AddTodoBlocker)
+							r.Delete("/{blockerID}", th.RemoveTodoBlocker)
+						})
+
+						r.Route("/shares", func(r chi.Router) {
+							r.Post("/", sh.ShareTodo)
+							r.Get("/", sh.TodoCollaborators)
+							r.Delete("/{collaboratorID}", sh.RevokeTodoShare)
+						})
+
+						r.Route("/comments", func(r chi.Router) {
+							r.Post("/", ch.NewComment)
+							r.Get("/", ch.TodoComments)
+
+							r.Route("/{commentID}", func(r chi.Router) {
+								r.Get("/replies", ch.CommentReplies)
+								r.Get("/history", ch.CommentHistory)
+								r.Patch("/", ch.ChangeComment)
+								r.Delete("/", ch.DeleteComment)
+							})
+						})
+					})
+				})
+
+				r.Route("/labels", func(r chi.Router) {
+					r.Post("/", lh.NewLabel)
+					r.Get("/", lh.MyLabels)
+
+					r.Route("/{labelID}", func(r chi.Router) {
+						r.Get("/", lh.MyLabel)
+						r.Patch("/", lh.ChangeLabel)
+						r.Delete("/", lh.DeleteLabel)
+					})
+				})
+
+				r.Route("/links", func(r chi.Router) {
+					r.Post("/", kh.NewLink)
+					r.Get("/", kh.MyLinks)
+					r.Delete("/{linkID}", kh.RevokeLink)
+				})
+
+				r.Route("/templates", func(r chi.Router) {
+					r.Post("/", mh.NewTemplate)
+					r.Get("/", mh.MyTemplates)
+
+					r.Route("/{templateID}", func(r chi.Router) {
+						r.Get("/", mh.MyTemplate)
+						r.Put("/", mh.ChangeTemplate)
+						r.Delete("/", mh.DeleteTemplate)
+						r.Post("/instantiate", th.InstantiateTemplate)
+					})
+				})
+
+				r.Route("/projects", func(r chi.Router) {
+					r.Post("/", ph.NewProject)
+					r.Get("/", ph.MyProjects)
+
+					r.Route("/{projectID}", func(r chi.Router) {
+						r.Get("/", ph.MyProject)
+						r.Patch("/", ph.ChangeProject)
+						r.Patch("/archive", ph.ArchiveProject)
+						r.Get("/todos", th.MyTodos)
+						r.Get("/board", th.TodoBoard)
+						r.Delete("/", ph.DeleteProject)
+
+						r.Route("/shares", func(r chi.Router) {
+							r.Post("/", sh.ShareProject)
+							r.Get("/", sh.ProjectCollaborators)
+							r.Delete("/{collaboratorID}", sh.RevokeProjectShare)
+						})
 					})
 				})
 			})
@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type organizationHandler struct {
+	organizationService se.OrganizationUseCases
+	nw                  network.NetworkWriter
+}
+
+func NewOrganizationHandler(os se.OrganizationUseCases) OrganizationHandler {
+	nw := network.NewNetworkWriter()
+
+	return &organizationHandler{
+		organizationService: os,
+		nw:                  nw,
+	}
+}
+
+func (oh *organizationHandler) NewOrganization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		oh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.OrganizationCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		oh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := oh.organizationService.CreateOrganization(r.Context(), &request)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	organizationData, err := json.Marshal(response)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	oh.nw.JSONResponse(w, organizationData)
+}
+
+func (oh *organizationHandler) MyOrganizations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		oh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := oh.organizationService.GetOrganizations(r.Context())
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	organizationData, err := json.Marshal(response)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	oh.nw.JSONResponse(w, organizationData)
+}
+
+func (oh *organizationHandler) OrganizationMembers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		oh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := oh.organizationService.GetMembers(r.Context(), orgID)
+	if err != nil {
+		oh.errorResponse(w, err)
+
+		return
+	}
+
+	memberData, err := json.Marshal(response)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	oh.nw.JSONResponse(w, memberData)
+}
+
+func (oh *organizationHandler) ChangeMemberRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		oh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	memberID, err := uuid.Parse(r.PathValue("memberID"))
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.MemberRoleChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		oh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.OrgID = orgID
+	request.MemberID = memberID
+
+	if err := oh.organizationService.ChangeMemberRole(r.Context(), &request); err != nil {
+		oh.errorResponse(w, err)
+
+		return
+	}
+
+	oh.nw.Response(w)
+}
+
+func (oh *organizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		oh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	orgID, err := uuid.Parse(r.PathValue("orgID"))
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	memberID, err := uuid.Parse(r.PathValue("memberID"))
+	if err != nil {
+		oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := oh.organizationService.RemoveMember(r.Context(), orgID, memberID); err != nil {
+		oh.errorResponse(w, err)
+
+		return
+	}
+
+	oh.nw.Response(w)
+}
+
+func (oh *organizationHandler) errorResponse(w http.ResponseWriter, err error) {
+	if errors.Is(err, se.ErrNotOrgMember) || errors.Is(err, se.ErrOrgForbidden) {
+		oh.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+		return
+	}
+
+	oh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+}
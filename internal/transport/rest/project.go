@@ -0,0 +1,217 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type projectHandler struct {
+	projectService se.ProjectUseCases
+	nw             network.NetworkWriter
+}
+
+func NewProjectHandler(ps se.ProjectUseCases) ProjectHandler {
+	nw := network.NewNetworkWriter()
+
+	return &projectHandler{
+		projectService: ps,
+		nw:             nw,
+	}
+}
+
+func (ph *projectHandler) NewProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.ProjectCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ph.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := ph.projectService.CreateProject(r.Context(), &request); err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ph.nw.CreatedResponse(w)
+}
+
+func (ph *projectHandler) MyProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	projectID, err := uuid.Parse(r.PathValue("projectID"))
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := ph.projectService.GetProject(r.Context(), projectID)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	projectData, err := json.Marshal(response)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ph.nw.ProjectFoundResponse(w, projectData)
+}
+
+func (ph *projectHandler) MyProjects(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	includeArchived, err := queryBool(r.URL.Query(), "archived")
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := ph.projectService.GetProjects(r.Context(), includeArchived)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	projectData, err := json.Marshal(response)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	ph.nw.ProjectFoundResponse(w, projectData)
+}
+
+func (ph *projectHandler) ChangeProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	projectID, err := uuid.Parse(r.PathValue("projectID"))
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.ProjectUpdateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ph.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.ProjectID = projectID
+	if err := ph.projectService.UpdateProject(r.Context(), &request); err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ph.nw.Response(w)
+}
+
+func (ph *projectHandler) ArchiveProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	projectID, err := uuid.Parse(r.PathValue("projectID"))
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.ProjectArchiveRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		ph.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.ProjectID = projectID
+	if err := ph.projectService.ArchiveProject(r.Context(), &request); err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ph.nw.Response(w)
+}
+
+func (ph *projectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		ph.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	projectID, err := uuid.Parse(r.PathValue("projectID"))
+	if err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := ph.projectService.DeleteProject(r.Context(), projectID); err != nil {
+		ph.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	ph.nw.Response(w)
+}
@@ -0,0 +1,96 @@
+package rest
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func queryList(values url.Values, key string) []string {
+	var result []string
+	for _, value := range values[key] {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
+
+func queryTime(values url.Values, key string) (*time.Time, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQueryParam, key)
+	}
+
+	return &t, nil
+}
+
+func queryInt(values url.Values, key string) (int, error) {
+	value := values.Get(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidQueryParam, key)
+	}
+
+	return n, nil
+}
+
+func queryBool(values url.Values, key string) (bool, error) {
+	value := values.Get(key)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrInvalidQueryParam, key)
+	}
+
+	return b, nil
+}
+
+func queryUUIDList(values url.Values, key string) ([]uuid.UUID, error) {
+	parts := queryList(values, key)
+
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidQueryParam, key)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func queryUUID(values url.Values, key string) (*uuid.UUID, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQueryParam, key)
+	}
+
+	return &id, nil
+}
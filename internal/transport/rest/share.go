@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type shareHandler struct {
+	shareService se.ShareUseCases
+	nw           network.NetworkWriter
+}
+
+func NewShareHandler(ss se.ShareUseCases) ShareHandler {
+	nw := network.NewNetworkWriter()
+
+	return &shareHandler{
+		shareService: ss,
+		nw:           nw,
+	}
+}
+
+func (sh *shareHandler) ShareTodo(w http.ResponseWriter, r *http.Request) {
+	sh.share(w, r, "todoID", sh.shareService.ShareTodo)
+}
+
+func (sh *shareHandler) ShareProject(w http.ResponseWriter, r *http.Request) {
+	sh.share(w, r, "projectID", sh.shareService.ShareProject)
+}
+
+func (sh *shareHandler) TodoCollaborators(w http.ResponseWriter, r *http.Request) {
+	sh.collaborators(w, r, "todoID", sh.shareService.GetTodoCollaborators)
+}
+
+func (sh *shareHandler) ProjectCollaborators(w http.ResponseWriter, r *http.Request) {
+	sh.collaborators(w, r, "projectID", sh.shareService.GetProjectCollaborators)
+}
+
+func (sh *shareHandler) RevokeTodoShare(w http.ResponseWriter, r *http.Request) {
+	sh.revoke(w, r, "todoID", sh.shareService.RevokeTodo)
+}
+
+func (sh *shareHandler) RevokeProjectShare(w http.ResponseWriter, r *http.Request) {
+	sh.revoke(w, r, "projectID", sh.shareService.RevokeProject)
+}
+
+func (sh *shareHandler) share(w http.ResponseWriter, r *http.Request, key string,
+	share func(ctx context.Context, shareRequest *dto.ShareRequest) error) {
+	if r.Method != http.MethodPost {
+		sh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PathValue(key))
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.ShareRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		sh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TargetID = targetID
+	if err := share(r.Context(), &request); err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	sh.nw.CreatedResponse(w)
+}
+
+func (sh *shareHandler) collaborators(w http.ResponseWriter, r *http.Request, key string,
+	get func(ctx context.Context, targetID uuid.UUID) ([]*dto.CollaboratorResponse, error)) {
+	if r.Method != http.MethodGet {
+		sh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PathValue(key))
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := get(r.Context(), targetID)
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	collaboratorData, err := json.Marshal(response)
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	sh.nw.JSONResponse(w, collaboratorData)
+}
+
+func (sh *shareHandler) revoke(w http.ResponseWriter, r *http.Request, key string,
+	revoke func(ctx context.Context, targetID, collaboratorID uuid.UUID) error) {
+	if r.Method != http.MethodDelete {
+		sh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PathValue(key))
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	collaboratorID, err := uuid.Parse(r.PathValue("collaboratorID"))
+	if err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := revoke(r.Context(), targetID, collaboratorID); err != nil {
+		sh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	sh.nw.Response(w)
+}
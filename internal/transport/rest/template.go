@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type templateHandler struct {
+	templateService se.TemplateUseCases
+	nw              network.NetworkWriter
+}
+
+func NewTemplateHandler(ts se.TemplateUseCases) TemplateHandler {
+	nw := network.NewNetworkWriter()
+
+	return &templateHandler{
+		templateService: ts,
+		nw:              nw,
+	}
+}
+
+func (mh *templateHandler) NewTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		mh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TemplateCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		mh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := mh.templateService.CreateTemplate(r.Context(), &request); err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	mh.nw.CreatedResponse(w)
+}
+
+func (mh *templateHandler) MyTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		mh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	templateID, err := uuid.Parse(r.PathValue("templateID"))
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := mh.templateService.GetTemplate(r.Context(), templateID)
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	templateData, err := json.Marshal(response)
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	mh.nw.JSONResponse(w, templateData)
+}
+
+func (mh *templateHandler) MyTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		mh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := mh.templateService.GetTemplates(r.Context())
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	templateData, err := json.Marshal(response)
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	mh.nw.JSONResponse(w, templateData)
+}
+
+func (mh *templateHandler) ChangeTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		mh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	templateID, err := uuid.Parse(r.PathValue("templateID"))
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TemplateUpdateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		mh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TemplateID = templateID
+	if err := mh.templateService.UpdateTemplate(r.Context(), &request); err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	mh.nw.Response(w)
+}
+
+func (mh *templateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		mh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	templateID, err := uuid.Parse(r.PathValue("templateID"))
+	if err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := mh.templateService.DeleteTemplate(r.Context(), templateID); err != nil {
+		mh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	mh.nw.Response(w)
+}
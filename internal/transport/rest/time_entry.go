@@ -0,0 +1,223 @@
+package rest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type timeEntryHandler struct {
+	timeService se.TimeEntryUseCases
+	nw          network.NetworkWriter
+}
+
+func NewTimeEntryHandler(es se.TimeEntryUseCases) TimeEntryHandler {
+	nw := network.NewNetworkWriter()
+
+	return &timeEntryHandler{
+		timeService: es,
+		nw:          nw,
+	}
+}
+
+func (eh *timeEntryHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TimerStartRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			eh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	request.TodoID = todoID
+	response, err := eh.timeService.StartTimer(r.Context(), &request)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	eh.entryResponse(w, response)
+}
+
+func (eh *timeEntryHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := eh.timeService.StopTimer(r.Context())
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	eh.entryResponse(w, response)
+}
+
+func (eh *timeEntryHandler) RunningTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := eh.timeService.GetRunningTimer(r.Context())
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	eh.entryResponse(w, response)
+}
+
+func (eh *timeEntryHandler) NewTimeEntry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TimeEntryCreateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		eh.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := eh.timeService.CreateTimeEntry(r.Context(), &request); err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	eh.nw.CreatedResponse(w)
+}
+
+func (eh *timeEntryHandler) TodoTime(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := eh.timeService.GetTodoTime(r.Context(), todoID)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	timeData, err := json.Marshal(response)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	eh.nw.JSONResponse(w, timeData)
+}
+
+func (eh *timeEntryHandler) TimeTotals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		eh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var (
+		query   = r.URL.Query()
+		request = dto.TimeTotalsRequest{GroupBy: query.Get("groupBy")}
+		err     error
+	)
+
+	if request.From, err = queryTime(query, "from"); err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if request.To, err = queryTime(query, "to"); err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := eh.timeService.GetTimeTotals(r.Context(), &request)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	timeData, err := json.Marshal(response)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	eh.nw.JSONResponse(w, timeData)
+}
+
+func (eh *timeEntryHandler) entryResponse(w http.ResponseWriter, response *dto.TimeEntryResponse) {
+	entryData, err := json.Marshal(response)
+	if err != nil {
+		eh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	eh.nw.JSONResponse(w, entryData)
+}
@@ -2,8 +2,10 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/google/uuid"
 	"github.com/identicalaffiliation/app/internal/dto"
@@ -83,6 +85,88 @@ func (th *todoHandler) MyTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", formatETag(response.Version))
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) versionConflict(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	response, err := th.todoService.GetTodo(r.Context(), todoID)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(response.Version))
+	th.nw.PreconditionFailedResponse(w, todoData)
+}
+
+func (th *todoHandler) MyTodoTree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := th.todoService.GetTodoTree(r.Context(), todoID)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) TodoOccurrences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := th.todoService.GetOccurrences(r.Context(), todoID)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
 	th.nw.TodoFoundResponse(w, todoData)
 }
 
@@ -93,7 +177,61 @@ func (th *todoHandler) MyTodos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response, err := th.todoService.GetTodos(r.Context())
+	request, err := todoListRequestFromQuery(r.URL.Query())
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if projectID := r.PathValue("projectID"); projectID != "" {
+		id, err := uuid.Parse(projectID)
+		if err != nil {
+			th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		request.ProjectID = &id
+	}
+
+	response, err := th.todoService.GetTodos(r.Context(), request)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) SearchTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	limit, err := queryInt(r.URL.Query(), "limit")
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	request := &dto.TodoSearchRequest{
+		Query: r.URL.Query().Get("q"),
+		Limit: limit,
+	}
+
+	response, err := th.todoService.SearchTodos(r.Context(), request)
 	if err != nil {
 		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
@@ -110,6 +248,110 @@ func (th *todoHandler) MyTodos(w http.ResponseWriter, r *http.Request) {
 	th.nw.TodoFoundResponse(w, todoData)
 }
 
+func (th *todoHandler) TodoBoard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var (
+		request dto.BoardRequest
+		err     error
+	)
+
+	if request.Limit, err = queryInt(r.URL.Query(), "limit"); err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if projectID := r.PathValue("projectID"); projectID != "" {
+		id, err := uuid.Parse(projectID)
+		if err != nil {
+			th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+			return
+		}
+
+		request.ProjectID = &id
+	}
+
+	response, err := th.todoService.GetBoard(r.Context(), &request)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	boardData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.JSONResponse(w, boardData)
+}
+
+func todoListRequestFromQuery(values url.Values) (*dto.TodoListRequest, error) {
+	var (
+		request dto.TodoListRequest
+		err     error
+	)
+
+	request.Statuses = queryList(values, "status")
+	request.Sort = values.Get("sort")
+	request.Cursor = values.Get("cursor")
+
+	if request.Limit, err = queryInt(values, "limit"); err != nil {
+		return nil, err
+	}
+
+	if request.CreatedAfter, err = queryTime(values, "created_after"); err != nil {
+		return nil, err
+	}
+
+	if request.CreatedBefore, err = queryTime(values, "created_before"); err != nil {
+		return nil, err
+	}
+
+	if request.UpdatedAfter, err = queryTime(values, "updated_after"); err != nil {
+		return nil, err
+	}
+
+	if request.UpdatedBefore, err = queryTime(values, "updated_before"); err != nil {
+		return nil, err
+	}
+
+	if request.DueAfter, err = queryTime(values, "due_after"); err != nil {
+		return nil, err
+	}
+
+	if request.DueBefore, err = queryTime(values, "due_before"); err != nil {
+		return nil, err
+	}
+
+	if request.Overdue, err = queryBool(values, "overdue"); err != nil {
+		return nil, err
+	}
+
+	if request.LabelIDs, err = queryUUIDList(values, "labels"); err != nil {
+		return nil, err
+	}
+	request.LabelsMode = values.Get("labels_mode")
+
+	if request.ProjectID, err = queryUUID(values, "project_id"); err != nil {
+		return nil, err
+	}
+
+	if request.IncludeArchived, err = queryBool(values, "archived"); err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
 func (th *todoHandler) ChangeTodoContent(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPatch {
 		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
@@ -140,6 +382,12 @@ func (th *todoHandler) ChangeTodoContent(w http.ResponseWriter, r *http.Request)
 
 	request.TodoID = todoID
 	if err := th.todoService.ChangeContent(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
 		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
@@ -178,6 +426,12 @@ func (th *todoHandler) ChangeTodoStatus(w http.ResponseWriter, r *http.Request)
 
 	request.TodoID = todoID
 	if err := th.todoService.ChangeStatus(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
 		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
@@ -186,8 +440,8 @@ func (th *todoHandler) ChangeTodoStatus(w http.ResponseWriter, r *http.Request)
 	th.nw.Response(w)
 }
 
-func (th *todoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
+func (th *todoHandler) ChangeTodoSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
 		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
 
 		return
@@ -200,7 +454,78 @@ func (th *todoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := th.todoService.DeleteTodo(r.Context(), todoID); err != nil {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoScheduleChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.ChangeSchedule(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) ChangeTodoProject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoProjectChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.ChangeProject(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		if errors.Is(err, se.ErrNotTodoOwner) {
+			th.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+			return
+		}
+
 		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
@@ -208,3 +533,536 @@ func (th *todoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 
 	th.nw.Response(w)
 }
+
+func (th *todoHandler) ChangeTodoAssignee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoAssigneeChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.ChangeAssignee(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) ChangeTodoParent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoParentChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.ChangeParent(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		if errors.Is(err, se.ErrNotTodoOwner) {
+			th.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) ChangeTodoRecurrence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoRecurrenceChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.ChangeRecurrence(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) ChangeTodoPosition(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoPositionChangeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.MoveTodo(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, request.TodoID)
+
+			return
+		}
+
+		if errors.Is(err, se.ErrNotTodoOwner) {
+			th.nw.ErrorResponse(w, err, http.StatusForbidden)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) AddTodoBlocker(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoDependencyRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	request.TodoID = todoID
+	if err := th.todoService.AddBlocker(r.Context(), &request); err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) RemoveTodoBlocker(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	blockerID, err := uuid.Parse(r.PathValue("blockerID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := th.todoService.RemoveBlocker(r.Context(), todoID, blockerID); err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	templateID, err := uuid.Parse(r.PathValue("templateID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TemplateInstantiateRequest
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	request.TemplateID = templateID
+	response, err := th.todoService.InstantiateTemplate(r.Context(), &request)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	instantiateData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.JSONResponse(w, instantiateData)
+}
+
+func (th *todoHandler) TodoHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := th.todoService.GetHistory(r.Context(), todoID)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) RevertTodo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	revisionID, err := uuid.Parse(r.PathValue("revisionID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := th.todoService.RevertTodo(r.Context(), todoID, revisionID); err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) TrashTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := th.todoService.GetTrash(r.Context())
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) SharedTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := th.todoService.GetSharedTodos(r.Context())
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) AssignedTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := th.todoService.GetAssignedTodos(r.Context())
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	todoData, err := json.Marshal(response)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	th.nw.TodoFoundResponse(w, todoData)
+}
+
+func (th *todoHandler) RestoreTodo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := th.todoService.RestoreTodo(r.Context(), todoID); err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	todoID, err := uuid.Parse(r.PathValue("todoID"))
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	if err := th.todoService.DeleteTodo(r.Context(), todoID); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			th.versionConflict(w, r, todoID)
+
+			return
+		}
+
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	th.nw.Response(w)
+}
+
+func (th *todoHandler) BatchTodos(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		th.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		th.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var request dto.TodoBatchRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		th.nw.ErrorResponse(w, ErrInvalidJSONBody, http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := th.todoService.BatchTodos(r.Context(), &request)
+	if err != nil && !errors.Is(err, se.ErrBatchFailed) {
+		th.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	batchData, marshalErr := json.Marshal(response)
+	if marshalErr != nil {
+		th.nw.ErrorResponse(w, marshalErr, http.StatusInternalServerError)
+
+		return
+	}
+
+	if err != nil {
+		th.nw.UnprocessableEntityResponse(w, batchData)
+
+		return
+	}
+
+	th.nw.JSONResponse(w, batchData)
+}
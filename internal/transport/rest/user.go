@@ -2,6 +2,7 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
@@ -53,9 +54,29 @@ func (uh *userHandler) MyProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", formatETag(response.Version))
 	uh.nw.UserFoundResponse(w, userData)
 }
 
+func (uh *userHandler) versionConflict(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	response, err := uh.userService.GetUser(r.Context(), userID)
+	if err != nil {
+		uh.nw.ErrorResponse(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	userData, err := json.Marshal(response)
+	if err != nil {
+		uh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(response.Version))
+	uh.nw.PreconditionFailedResponse(w, userData)
+}
+
 func (uh *userHandler) ChangeMyName(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPatch {
 		uh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
@@ -86,6 +107,12 @@ func (uh *userHandler) ChangeMyName(w http.ResponseWriter, r *http.Request) {
 
 	request.ID = userID
 	if err := uh.userService.ChangeName(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			uh.versionConflict(w, r, userID)
+
+			return
+		}
+
 		uh.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
@@ -124,6 +151,12 @@ func (uh *userHandler) ChangeMyEmail(w http.ResponseWriter, r *http.Request) {
 
 	request.ID = userID
 	if err := uh.userService.ChangeEmail(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			uh.versionConflict(w, r, userID)
+
+			return
+		}
+
 		uh.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
@@ -162,6 +195,12 @@ func (uh *userHandler) ChangeMyPassword(w http.ResponseWriter, r *http.Request)
 
 	request.ID = userID
 	if err := uh.userService.ChangePassword(r.Context(), &request); err != nil {
+		if errors.Is(err, se.ErrVersionMismatch) {
+			uh.versionConflict(w, r, userID)
+
+			return
+		}
+
 		uh.nw.ErrorResponse(w, err, http.StatusBadRequest)
 
 		return
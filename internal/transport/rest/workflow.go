@@ -0,0 +1,40 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/network"
+)
+
+type workflowHandler struct {
+	workflowService se.WorkflowUseCases
+	nw              network.NetworkWriter
+}
+
+func NewWorkflowHandler(ws se.WorkflowUseCases) WorkflowHandler {
+	nw := network.NewNetworkWriter()
+
+	return &workflowHandler{
+		workflowService: ws,
+		nw:              nw,
+	}
+}
+
+func (wh *workflowHandler) Workflow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		wh.nw.ErrorResponse(w, ErrInvalidMethod, http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	workflowData, err := json.Marshal(wh.workflowService.GetWorkflow(r.Context()))
+	if err != nil {
+		wh.nw.ErrorResponse(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	wh.nw.JSONResponse(w, workflowData)
+}
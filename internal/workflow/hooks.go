@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/logger"
+)
+
+type ReminderSkipper interface {
+	SkipReminder(ctx context.Context, todoID uuid.UUID) error
+}
+
+func NewLogHook(logger *logger.Logger) Hook {
+	return func(ctx context.Context, transition *Transition) error {
+		logger.Logger.Info("todo status changed",
+			"operation", "workflow transition",
+			"user_id", transition.UserID.String(),
+			"todo_id", transition.TodoID.String(),
+			"from", transition.From,
+			"to", transition.To,
+		)
+
+		return nil
+	}
+}
+
+func NewClearReminderHook(rs ReminderSkipper) Hook {
+	return func(ctx context.Context, transition *Transition) error {
+		return rs.SkipReminder(ctx, transition.TodoID)
+	}
+}
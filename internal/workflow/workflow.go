@@ -0,0 +1,234 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+)
+
+var (
+	ErrUnknownState         error = errors.New("unknown workflow state")
+	ErrDuplicateState       error = errors.New("duplicate workflow state")
+	ErrInvalidInitialState  error = errors.New("initial workflow state must exist and must not be terminal")
+	ErrUnknownHook          error = errors.New("unknown workflow hook")
+	ErrTransitionNotAllowed error = errors.New("status transition is not allowed")
+	ErrInvalidWIPLimit      error = errors.New("WIP limit must not be negative")
+)
+
+type Transition struct {
+	TodoID uuid.UUID
+	UserID uuid.UUID
+	From   string
+	To     string
+}
+
+type Hook func(ctx context.Context, transition *Transition) error
+
+type State struct {
+	Name        string
+	Label       string
+	Terminal    bool
+	Transitions []string
+	WIPLimit    int
+	OnEnter     []string
+	OnExit      []string
+	onEnter     []Hook
+	onExit      []Hook
+}
+
+type Workflow struct {
+	initial string
+	states  []*State
+	byName  map[string]*State
+}
+
+func DefaultConfig() *config.WorkflowConfig {
+	return &config.WorkflowConfig{
+		Initial: "todo",
+		States: []config.WorkflowStateConfig{
+			{Name: "todo", Label: "To do", Transitions: []string{"process", "done"}},
+			{Name: "process", Label: "In progress", Transitions: []string{"todo", "done"}},
+			{Name: "done", Label: "Done", Terminal: true, Transitions: []string{"todo"}},
+		},
+	}
+}
+
+func New(cfg *config.WorkflowConfig) (*Workflow, error) {
+	if cfg == nil || len(cfg.States) == 0 {
+		cfg = DefaultConfig()
+	}
+
+	wf := &Workflow{
+		initial: cfg.Initial,
+		states:  make([]*State, 0, len(cfg.States)),
+		byName:  make(map[string]*State, len(cfg.States)),
+	}
+
+	for _, stateCfg := range cfg.States {
+		if _, ok := wf.byName[stateCfg.Name]; ok || stateCfg.Name == "" {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateState, stateCfg.Name)
+		}
+
+		if stateCfg.WIPLimit < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidWIPLimit, stateCfg.Name)
+		}
+
+		state := &State{
+			Name:        stateCfg.Name,
+			Label:       stateCfg.Label,
+			Terminal:    stateCfg.Terminal,
+			Transitions: stateCfg.Transitions,
+			WIPLimit:    stateCfg.WIPLimit,
+			OnEnter:     stateCfg.OnEnter,
+			OnExit:      stateCfg.OnExit,
+		}
+
+		wf.states = append(wf.states, state)
+		wf.byName[state.Name] = state
+	}
+
+	for _, state := range wf.states {
+		for _, to := range state.Transitions {
+			if _, ok := wf.byName[to]; !ok {
+				return nil, fmt.Errorf("%w: %s -> %s", ErrUnknownState, state.Name, to)
+			}
+		}
+	}
+
+	if initial, ok := wf.byName[wf.initial]; !ok || initial.Terminal {
+		return nil, ErrInvalidInitialState
+	}
+
+	return wf, nil
+}
+
+func (wf *Workflow) BindHooks(hooks map[string]Hook) error {
+	for _, state := range wf.states {
+		var err error
+		if state.onEnter, err = resolveHooks(state.OnEnter, hooks); err != nil {
+			return err
+		}
+
+		if state.onExit, err = resolveHooks(state.OnExit, hooks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resolveHooks(names []string, hooks map[string]Hook) ([]Hook, error) {
+	resolved := make([]Hook, 0, len(names))
+	for _, name := range names {
+		hook, ok := hooks[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownHook, name)
+		}
+
+		resolved = append(resolved, hook)
+	}
+
+	return resolved, nil
+}
+
+func (wf *Workflow) Initial() string {
+	return wf.initial
+}
+
+func (wf *Workflow) States() []*State {
+	return wf.states
+}
+
+func (wf *Workflow) Has(name string) bool {
+	_, ok := wf.byName[name]
+
+	return ok
+}
+
+func (wf *Workflow) IsTerminal(name string) bool {
+	state, ok := wf.byName[name]
+
+	return ok && state.Terminal
+}
+
+func (wf *Workflow) WIPLimit(name string) int {
+	state, ok := wf.byName[name]
+	if !ok || state.Terminal {
+		return 0
+	}
+
+	return state.WIPLimit
+}
+
+func (wf *Workflow) HasWIPLimits() bool {
+	for _, state := range wf.states {
+		if !state.Terminal && state.WIPLimit > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (wf *Workflow) TerminalStates() []string {
+	terminal := make([]string, 0)
+	for _, state := range wf.states {
+		if state.Terminal {
+			terminal = append(terminal, state.Name)
+		}
+	}
+
+	return terminal
+}
+
+func (wf *Workflow) CanTransition(from, to string) bool {
+	state, ok := wf.byName[from]
+	if !ok || !wf.Has(to) {
+		return false
+	}
+
+	for _, allowed := range state.Transitions {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (wf *Workflow) Apply(ctx context.Context, transition *Transition, update func(ctx context.Context) error) error {
+	if !wf.Has(transition.To) {
+		return fmt.Errorf("%w: %s", ErrUnknownState, transition.To)
+	}
+
+	if transition.From == transition.To {
+		return update(ctx)
+	}
+
+	if from, ok := wf.byName[transition.From]; ok {
+		if !wf.CanTransition(transition.From, transition.To) {
+			return fmt.Errorf("%w: %s -> %s", ErrTransitionNotAllowed, transition.From, transition.To)
+		}
+
+		for _, hook := range from.onExit {
+			if err := hook(ctx, transition); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := update(ctx); err != nil {
+		return err
+	}
+
+	for _, hook := range wf.byName[transition.To].onEnter {
+		if err := hook(ctx, transition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -51,5 +51,9 @@ func (tv *tokenValidator) ValidateClaims(claims jwt.MapClaims) error {
 		return errors.New("token hasn't email")
 	}
 
+	if _, ok := claims["orgID"]; !ok {
+		return errors.New("token hasn't orgID")
+	}
+
 	return nil
 }
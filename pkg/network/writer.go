@@ -11,6 +11,11 @@ type NetworkWriter interface {
 	Response(w http.ResponseWriter)
 	AuthResponse(w http.ResponseWriter, authData []byte)
 	TodoFoundResponse(w http.ResponseWriter, todoData []byte)
+	LabelFoundResponse(w http.ResponseWriter, labelData []byte)
+	ProjectFoundResponse(w http.ResponseWriter, projectData []byte)
+	JSONResponse(w http.ResponseWriter, data []byte)
+	PreconditionFailedResponse(w http.ResponseWriter, data []byte)
+	UnprocessableEntityResponse(w http.ResponseWriter, data []byte)
 }
 
 type networkWriter struct{}
@@ -50,3 +55,33 @@ func (nw *networkWriter) TodoFoundResponse(w http.ResponseWriter, todoData []byt
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(todoData)
 }
+
+func (nw *networkWriter) LabelFoundResponse(w http.ResponseWriter, labelData []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusFound)
+	w.Write(labelData)
+}
+
+func (nw *networkWriter) ProjectFoundResponse(w http.ResponseWriter, projectData []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusFound)
+	w.Write(projectData)
+}
+
+func (nw *networkWriter) JSONResponse(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (nw *networkWriter) PreconditionFailedResponse(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusPreconditionFailed)
+	w.Write(data)
+}
+
+func (nw *networkWriter) UnprocessableEntityResponse(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	w.Write(data)
+}
@@ -8,3 +8,7 @@ func FlagInit() string {
 
 	return *path
 }
+
+func Args() []string {
+	return flag.Args()
+}
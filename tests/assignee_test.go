@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeAssignee(t *testing.T) {
+	type testCase struct {
+		testName      string
+		unassign      bool
+		ifMatch       int
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	lockColumns := []string{"id", "user_id", "assignee_id", "content", "status", "version", "created_at",
+		"updated_at"}
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, ownerID uuid.UUID, assigneeID any) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, ownerID, ownerID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows(lockColumns).
+				AddRow(todoID, ownerID, assigneeID, "prepare slides", psql.Todo, 1, testTime, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – editor collaborator assigned",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID) {
+				lockTodo(mock, todoID, ownerID, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CAN_ACCESS)).
+					WithArgs(todoID, assigneeID, assigneeID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_ASSIGNEE)).
+					WithArgs(assigneeID, todoID, ownerID, ownerID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, ownerID, "assignee_id", nil, assigneeID.String()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – assignee cleared",
+			unassign: true,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID) {
+				lockTodo(mock, todoID, ownerID, assigneeID)
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_ASSIGNEE)).
+					WithArgs(nil, todoID, ownerID, ownerID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, ownerID, "assignee_id", assigneeID.String(), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – assignee without editor access",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID) {
+				lockTodo(mock, todoID, ownerID, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CAN_ACCESS)).
+					WithArgs(todoID, assigneeID, assigneeID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidAssignee,
+		},
+		{
+			testName: "failure – caller without editor access",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(todoID, ownerID, ownerID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+		{
+			testName: "failure – stale version",
+			ifMatch:  2,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, assigneeID uuid.UUID) {
+				lockTodo(mock, todoID, ownerID, nil)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrVersionMismatch,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			ownerID := uuid.New()
+			assigneeID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, ownerID, assigneeID)
+
+			ctx := userContext(ownerID)
+			if testCase.ifMatch != 0 {
+				ctx = context.WithValue(ctx, "ifMatch", testCase.ifMatch)
+			}
+			request := &dto.TodoAssigneeChangeRequest{TodoID: todoID, AssigneeID: &assigneeID}
+			if testCase.unassign {
+				request.AssigneeID = nil
+			}
+			err = todoService.ChangeAssignee(ctx, request)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetAssignedTodos(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – own and shared todos assigned to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_ASSIGNED)).
+					WithArgs(userID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "assignee_id", "content", "status"}).
+						AddRow(todoID, uuid.New(), userID, "review budget", psql.Todo).
+						AddRow(uuid.New(), userID, userID, "book flights", psql.Todo))
+			},
+			expectedLen: 2,
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_ASSIGNED)).
+					WithArgs(userID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, userID, todoID)
+
+			ctx := userContext(userID)
+			todos, err := todoService.GetAssignedTodos(ctx)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, todos, testCase.expectedLen)
+				assert.Equal(t, todoID, todos[0].ID)
+				require.NotNil(t, todos[0].AssigneeID)
+				assert.Equal(t, userID, *todos[0].AssigneeID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
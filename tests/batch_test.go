@@ -0,0 +1,448 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lockBatchQuery(count int) string {
+	placeholders := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+2))
+	}
+
+	query := strings.Replace(TODO_LOCK_BATCH, "($2,$3,$4)", "("+strings.Join(placeholders, ",")+")", 1)
+
+	return strings.Replace(query, "org_id = $5", fmt.Sprintf("org_id = $%d", count+2), 1)
+}
+
+func TestCreateTodosBatch(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todos []*entity.Todo)
+		expectedError bool
+	}
+
+	userID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – todos inserted",
+			mockSetup: func(mock sqlmock.Sqlmock, todos []*entity.Todo) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_BATCH)).
+					WithArgs(todos[0].ID, userID, testOrgID, nil, nil, "buy milk", string(psql.Todo), nil, nil, nil,
+						nil, nil, "i", todos[1].ID, userID, testOrgID, nil, nil, "buy bread", string(psql.Todo), nil,
+						nil, nil, nil, nil, "r").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).
+						AddRow(todos[1].ID, testTime).AddRow(todos[0].ID, testTime))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, todos []*entity.Todo) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_BATCH)).WillReturnError(errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			todos := []*entity.Todo{
+				{ID: uuid.New(), UserID: userID, Content: "buy milk", Status: string(psql.Todo), Position: "i"},
+				{ID: uuid.New(), UserID: userID, Content: "buy bread", Status: string(psql.Todo), Position: "r"},
+			}
+
+			testCase.mockSetup(mock, todos)
+			err = repo.CreateBatch(orgContext(), todos)
+			if testCase.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testTime, todos[0].CreatedAt)
+				assert.Equal(t, testTime, todos[1].CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateContentBatch(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todos []*entity.Todo)
+		expectedError string
+	}
+
+	userID := uuid.New()
+
+	testTable := []testCase{
+		{
+			testName: "success – every todo updated",
+			mockSetup: func(mock sqlmock.Sqlmock, todos []*entity.Todo) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_UPDATE_CONTENT_BATCH)).
+					WithArgs(todos[0].ID, "buy oat milk", todos[1].ID, "buy rye bread", userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(todos[0].ID).AddRow(todos[1].ID))
+			},
+		},
+		{
+			testName: "failure – one todo missing",
+			mockSetup: func(mock sqlmock.Sqlmock, todos []*entity.Todo) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_UPDATE_CONTENT_BATCH)).
+					WithArgs(todos[0].ID, "buy oat milk", todos[1].ID, "buy rye bread", userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(todos[0].ID))
+			},
+			expectedError: "todo not found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			todos := []*entity.Todo{
+				{ID: uuid.New(), Content: "buy oat milk"},
+				{ID: uuid.New(), Content: "buy rye bread"},
+			}
+
+			testCase.mockSetup(mock, todos)
+			err = repo.UpdateContentBatch(orgContext(), todos, userID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteBatch(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, firstID, secondID uuid.UUID)
+		expectedError string
+	}
+
+	userID := uuid.New()
+	deleteQuery := strings.NewReplacer("id IN ($1) AND user_id = $2", "id IN ($1,$2) AND user_id = $3",
+		"org_id = $3", "org_id = $4").Replace(TODO_DELETE_BATCH)
+
+	testTable := []testCase{
+		{
+			testName: "success – roots and their subtasks deleted",
+			mockSetup: func(mock sqlmock.Sqlmock, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).WithArgs(firstID, secondID, userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(firstID).AddRow(secondID).AddRow(uuid.New()))
+			},
+		},
+		{
+			testName: "failure – subtasks do not make up for a missing root",
+			mockSetup: func(mock sqlmock.Sqlmock, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(deleteQuery)).WithArgs(firstID, secondID, userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(firstID).AddRow(uuid.New()))
+			},
+			expectedError: "todo not found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			firstID := uuid.New()
+			secondID := uuid.New()
+
+			testCase.mockSetup(mock, firstID, secondID)
+			err = repo.DeleteBatch(orgContext(), []uuid.UUID{firstID, secondID}, userID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBatchTodos(t *testing.T) {
+	type testCase struct {
+		testName       string
+		workflow       *config.WorkflowConfig
+		operations     func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation
+		mockSetup      func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID)
+		expectedError  error
+		expectedResult []string
+	}
+
+	testTime := time.Now()
+	done := string(psql.Done)
+	process := string(psql.Process)
+	version := 2
+	columns := []string{"id", "user_id", "content", "status", "version", "archived", "created_at", "updated_at"}
+	userRow := []string{"id", "name", "email", "password", "version", "created_at", "updated_at"}
+
+	hookWorkflow := wipWorkflow()
+	hookWorkflow.States[2].OnEnter = []string{"clear_reminder"}
+
+	testTable := []testCase{
+		{
+			testName: "success – status updates and delete",
+			workflow: wipWorkflow(),
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				return []*dto.TodoBatchOperation{
+					{Op: "update", TodoID: firstID, Status: &done, Version: &version},
+					{Op: "delete", TodoID: secondID},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Process, 2, false, testTime, testTime).
+						AddRow(secondID, userID, "old note", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(firstID, done).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(userRow).
+						AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("process", 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Done, firstID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), firstID, userID, "status", process, done).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_DELETE_BATCH)).WithArgs(secondID, userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(secondID))
+				mock.ExpectCommit()
+			},
+			expectedResult: []string{"", ""},
+		},
+		{
+			testName: "success – enter hooks run after the status is written",
+			workflow: hookWorkflow,
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				return []*dto.TodoBatchOperation{
+					{Op: "update", TodoID: firstID, Status: &done},
+					{Op: "update", TodoID: secondID, Status: &done},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Todo, 1, false, testTime, testTime).
+						AddRow(secondID, userID, "buy bread", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(firstID, done).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(userRow).
+						AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("todo", 2))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(secondID, done).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}))
+				for _, todoID := range []uuid.UUID{firstID, secondID} {
+					mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+						WithArgs(psql.Done, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+					mock.ExpectExec(regexp.QuoteMeta(TODO_SKIP_REMINDER)).WithArgs(todoID).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+					mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+						WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Todo), done).
+						WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				}
+				mock.ExpectCommit()
+			},
+			expectedResult: []string{"", ""},
+		},
+		{
+			testName: "success – moving out of a limited status frees a slot",
+			workflow: wipWorkflow(),
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				todo := string(psql.Todo)
+
+				return []*dto.TodoBatchOperation{
+					{Op: "update", TodoID: firstID, Status: &todo},
+					{Op: "update", TodoID: secondID, Status: &process},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Process, 1, false, testTime, testTime).
+						AddRow(secondID, userID, "buy bread", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(userRow).
+						AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("process", 2).AddRow("todo", 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Todo, firstID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), firstID, userID, "status", process, string(psql.Todo)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Process, secondID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), secondID, userID, "status", string(psql.Todo), process).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+			expectedResult: []string{"", ""},
+		},
+		{
+			testName: "success – deleting a todo in a limited status frees a slot",
+			workflow: wipWorkflow(),
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				return []*dto.TodoBatchOperation{
+					{Op: "delete", TodoID: firstID},
+					{Op: "update", TodoID: secondID, Status: &process},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Process, 1, false, testTime, testTime).
+						AddRow(secondID, userID, "buy bread", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(userRow).
+						AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("process", 2).AddRow("todo", 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Process, secondID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), secondID, userID, "status", string(psql.Todo), process).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_DELETE_BATCH)).WithArgs(firstID, userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(firstID))
+				mock.ExpectCommit()
+			},
+			expectedResult: []string{"", ""},
+		},
+		{
+			testName: "failure – WIP limit reached",
+			workflow: wipWorkflow(),
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				return []*dto.TodoBatchOperation{
+					{Op: "update", TodoID: firstID, Status: &process},
+					{Op: "update", TodoID: secondID, Status: &process},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Todo, 1, false, testTime, testTime).
+						AddRow(secondID, userID, "buy bread", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(userRow).
+						AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("process", 1).AddRow("todo", 2))
+				mock.ExpectRollback()
+			},
+			expectedError:  se.ErrBatchFailed,
+			expectedResult: []string{"", "WIP limit reached: process allows 2"},
+		},
+		{
+			testName: "failure – unknown, duplicate and stale todos roll back",
+			workflow: wipWorkflow(),
+			operations: func(firstID, secondID uuid.UUID) []*dto.TodoBatchOperation {
+				return []*dto.TodoBatchOperation{
+					{Op: "update", TodoID: firstID, Status: &done, Version: &version},
+					{Op: "delete", TodoID: secondID},
+					{Op: "update", TodoID: firstID, Content: &done},
+				}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(lockBatchQuery(2))).WithArgs(userID, firstID, secondID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(firstID, userID, "buy milk", psql.Todo, 1, false, testTime, testTime))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrBatchFailed,
+			expectedResult: []string{se.ErrVersionMismatch.Error(), se.ErrInvalidTodoID.Error(),
+				se.ErrDuplicateBatchTodo.Error()},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoServiceWithWorkflow(db, testCase.workflow)
+
+			userID := uuid.New()
+			firstID := uuid.New()
+			secondID := uuid.New()
+
+			testCase.mockSetup(mock, userID, firstID, secondID)
+			ctx := userContext(userID)
+			response, err := todoService.BatchTodos(ctx, &dto.TodoBatchRequest{
+				Operations: testCase.operations(firstID, secondID),
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Len(t, response.Results, len(testCase.expectedResult))
+			for i, expected := range testCase.expectedResult {
+				assert.Equal(t, expected, response.Results[i].Error)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package tests
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wipWorkflow() *config.WorkflowConfig {
+	return &config.WorkflowConfig{
+		Initial: "todo",
+		States: []config.WorkflowStateConfig{
+			{Name: "todo", Label: "To do", Transitions: []string{"process", "done"}},
+			{Name: "process", Label: "In progress", Transitions: []string{"todo", "done"}, WIPLimit: 2},
+			{Name: "done", Label: "Done", Terminal: true, Transitions: []string{"todo"}},
+		},
+	}
+}
+
+func TestGetBoard(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID, todoIDs []uuid.UUID)
+		expectedError bool
+	}
+
+	testTime := time.Now()
+	columns := []string{"id", "user_id", "content", "status", "position", "created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – columns with counts, WIP limits and dependencies",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID, todoIDs []uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("todo", 3).AddRow("process", 1))
+				rows := sqlmock.NewRows(columns)
+				for i, position := range []string{"a", "i", "r"} {
+					rows.AddRow(todoIDs[i], userID, "task "+position, "todo", position, testTime, testTime)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_BOARD_COLUMN)).WithArgs(userID, testOrgID, false, "todo").
+					WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_BOARD_COLUMN)).WithArgs(userID, testOrgID, false, "process").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(todoIDs[3], userID, "review", "process", "m", testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_GET_BY_TODOS)).
+					WithArgs(todoIDs[0], todoIDs[1], todoIDs[3], todoIDs[0], todoIDs[1], todoIDs[3], userID).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id", "blocked_id", "created_at"}).
+						AddRow(todoIDs[3], todoIDs[1], testTime))
+			},
+		},
+		{
+			testName: "error – counting todos fails",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID, todoIDs []uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoServiceWithWorkflow(db, wipWorkflow())
+
+			userID := uuid.New()
+			todoIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+
+			testCase.mockSetup(mock, userID, todoIDs)
+			ctx := userContext(userID)
+			board, err := todoService.GetBoard(ctx, &dto.BoardRequest{Limit: 2})
+			if testCase.expectedError {
+				require.Error(t, err)
+				require.NoError(t, mock.ExpectationsWereMet())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, board.Columns, 3)
+
+			assert.Equal(t, "todo", board.Columns[0].Status)
+			assert.Equal(t, 3, board.Columns[0].Count)
+			assert.Len(t, board.Columns[0].Items, 2)
+			assert.NotEmpty(t, board.Columns[0].NextCursor)
+			assert.Equal(t, []uuid.UUID{todoIDs[3]}, board.Columns[0].Items[1].BlockedBy)
+			assert.Equal(t, []uuid.UUID{todoIDs[1]}, board.Columns[1].Items[0].Blocks)
+
+			assert.Equal(t, 2, board.Columns[1].WIPLimit)
+			assert.Len(t, board.Columns[1].Items, 1)
+			assert.Empty(t, board.Columns[1].NextCursor)
+
+			assert.Equal(t, 0, board.Columns[2].Count)
+			assert.Empty(t, board.Columns[2].Items)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangeStatusWIPLimit(t *testing.T) {
+	type testCase struct {
+		testName      string
+		status        string
+		count         int
+		mockSetup     func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	userRow := []string{"id", "name", "email", "password", "version", "created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – within WIP limit",
+			status:   string(psql.Todo),
+			count:    1,
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Process, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Todo), string(psql.Process)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – WIP limit reached",
+			status:   string(psql.Todo),
+			count:    2,
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrWIPLimitReached,
+		},
+		{
+			testName: "failure – WIP limit reached from a state removed from the workflow",
+			status:   "review",
+			count:    2,
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrWIPLimitReached,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoServiceWithWorkflow(db, wipWorkflow())
+
+			userID := uuid.New()
+			todoID := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+					AddRow(todoID, userID, "write report", testCase.status))
+			mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+				WillReturnRows(sqlmock.NewRows(userRow).
+					AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+			mock.ExpectQuery(regexp.QuoteMeta(TODO_COUNT_BY_STATUS)).WithArgs(userID, testOrgID, false).
+				WillReturnRows(sqlmock.NewRows([]string{"status", "count"}).AddRow("process", testCase.count))
+			testCase.mockSetup(mock, userID, todoID)
+
+			ctx := userContext(userID)
+			err = todoService.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{
+				TodoID:    todoID,
+				NewStatus: string(psql.Process),
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
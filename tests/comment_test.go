@@ -0,0 +1,337 @@
+package tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	re "github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commentRowColumns = []string{"id", "todo_id", "user_id", "parent_id", "body", "created_at", "updated_at",
+	"deleted_at", "reply_count"}
+
+func expectOwnedTodo(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+	mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+		WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+			AddRow(todoID, userID, "write report", psql.Todo))
+}
+
+func TestCreateComment(t *testing.T) {
+	type testCase struct {
+		testName      string
+		parentID      *uuid.UUID
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	rootID := uuid.New()
+	replyID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – top-level comment",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, nil, "**done**").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(testTime, testTime))
+			},
+		},
+		{
+			testName: "success – reply attaches to thread root",
+			parentID: &replyID,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_GET_BY_ID)).WithArgs(replyID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns).
+						AddRow(replyID, todoID, userID, rootID, "agreed", testTime, testTime, nil, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, rootID, "**done**").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(testTime, testTime))
+			},
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+		{
+			testName: "failure – parent comment not found",
+			parentID: &replyID,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_GET_BY_ID)).WithArgs(replyID, todoID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidCommentID,
+		},
+		{
+			testName: "failure – parent comment deleted",
+			parentID: &rootID,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_GET_BY_ID)).WithArgs(rootID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns).
+						AddRow(rootID, todoID, userID, nil, "gone", testTime, testTime, testTime, 1))
+			},
+			expectedError: se.ErrCommentDeleted,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			commentService := InitCommentService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = commentService.CreateComment(ctx, &dto.CommentCreateRequest{
+				TodoID:   todoID,
+				ParentID: testCase.parentID,
+				Body:     "**done**",
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateComment(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – previous body recorded as an edit",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_LOCK)).WithArgs(commentID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns[:8]).
+						AddRow(commentID, todoID, userID, nil, "first draft", testTime, testTime, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_EDIT_CREATE)).
+					WithArgs(sqlmock.AnyArg(), commentID, "first draft").
+					WillReturnRows(sqlmock.NewRows([]string{"edited_at"}).AddRow(testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_UPDATE_BODY)).WithArgs("final text", commentID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – unchanged body writes nothing",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_LOCK)).WithArgs(commentID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns[:8]).
+						AddRow(commentID, todoID, userID, nil, "final text", testTime, testTime, nil))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – not the author",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_LOCK)).WithArgs(commentID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns[:8]).
+						AddRow(commentID, todoID, uuid.New(), nil, "not yours", testTime, testTime, nil))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrNotCommentAuthor,
+		},
+		{
+			testName: "failure – comment deleted",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_LOCK)).WithArgs(commentID, todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns[:8]).
+						AddRow(commentID, todoID, userID, nil, "gone", testTime, testTime, testTime))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrCommentDeleted,
+		},
+		{
+			testName: "failure – comment not found",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_LOCK)).WithArgs(commentID, todoID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidCommentID,
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, commentID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			commentService := InitCommentService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			commentID := uuid.New()
+			testCase.mockSetup(mock, todoID, commentID, userID)
+
+			ctx := userContext(userID)
+			err = commentService.UpdateComment(ctx, &dto.CommentUpdateRequest{
+				TodoID:    todoID,
+				CommentID: commentID,
+				Body:      "final text",
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetComments(t *testing.T) {
+	type testCase struct {
+		testName        string
+		cursor          string
+		mockSetup       func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError   error
+		expectedID      uuid.UUID
+		expectedDeleted bool
+		expectedCursor  bool
+	}
+
+	firstID := uuid.New()
+	secondID := uuid.New()
+	thirdID := uuid.New()
+	testTime := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	deletedAt := testTime.Add(time.Hour)
+
+	testTable := []testCase{
+		{
+			testName: "success – first page hides deleted body and returns a cursor",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_GET_BY_TODO)).WithArgs(todoID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns).
+						AddRow(firstID, todoID, userID, nil, "removed", testTime, testTime, deletedAt, 2).
+						AddRow(secondID, todoID, userID, nil, "second", testTime.Add(time.Minute),
+							testTime.Add(time.Minute), nil, 0))
+			},
+			expectedID:      firstID,
+			expectedDeleted: true,
+			expectedCursor:  true,
+		},
+		{
+			testName: "success – next page continues after the cursor",
+			cursor:   psql.EncodeCommentCursor(&re.Comment{ID: firstID, CreatedAt: testTime}),
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(COMMENT_GET_AFTER)).WithArgs(todoID, testTime, firstID).
+					WillReturnRows(sqlmock.NewRows(commentRowColumns).
+						AddRow(secondID, todoID, userID, nil, "second", testTime.Add(time.Minute),
+							testTime.Add(time.Minute), nil, 0).
+						AddRow(thirdID, todoID, userID, nil, "third", testTime.Add(2*time.Minute),
+							testTime.Add(3*time.Minute), nil, 0))
+			},
+			expectedID:     secondID,
+			expectedCursor: true,
+		},
+		{
+			testName: "failure – malformed cursor",
+			cursor:   "not-a-cursor",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+			},
+			expectedError: psql.ErrInvalidCursor,
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			commentService := InitCommentService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			page, err := commentService.GetComments(ctx, &dto.CommentListRequest{TodoID: todoID, Limit: 1,
+				Cursor: testCase.cursor})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, page.Items, 1)
+				assert.Equal(t, testCase.expectedID, page.Items[0].ID)
+				assert.Equal(t, testCase.expectedCursor, page.NextCursor != "")
+				assert.Equal(t, testCase.expectedDeleted, page.Items[0].Deleted)
+				assert.Equal(t, testCase.expectedDeleted, page.Items[0].Body == "")
+				assert.False(t, page.Items[0].Edited)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
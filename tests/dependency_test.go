@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddBlocker(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock)
+		blockerID   uuid.UUID
+		expectedErr error
+	}
+
+	testTime := time.Now()
+	userID := uuid.New()
+	ownerID := uuid.New()
+	todoID := uuid.MustParse("10000000-0000-0000-0000-000000000000")
+	blockerID := uuid.MustParse("20000000-0000-0000-0000-000000000000")
+	todoColumns := []string{"id", "user_id", "content", "status"}
+
+	lockTodo := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows(todoColumns).AddRow(todoID, userID, "ship release", psql.Todo))
+	}
+	lockBoth := func(mock sqlmock.Sqlmock) {
+		lockTodo(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(blockerID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows(todoColumns).AddRow(blockerID, userID, "fix tests", psql.Todo))
+		mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(userRowColumns).
+				AddRow(userID, "user", "user@mail.com", "hash", 1, testTime, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – blocker added",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				lockBoth(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_REACHES)).WithArgs(todoID, blockerID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_CREATE)).WithArgs(blockerID, todoID).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+			blockerID: blockerID,
+		},
+		{
+			testName: "failure – blocker already blocked by the todo",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				lockBoth(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_REACHES)).WithArgs(todoID, blockerID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			blockerID:   blockerID,
+			expectedErr: se.ErrDependencyCycle,
+		},
+		{
+			testName: "failure – dependency already exists",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				lockBoth(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_REACHES)).WithArgs(todoID, blockerID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_CREATE)).WithArgs(blockerID, todoID).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			blockerID:   blockerID,
+			expectedErr: se.ErrDependencyExists,
+		},
+		{
+			testName: "failure – viewer cannot block the todo",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			blockerID:   blockerID,
+			expectedErr: se.ErrInvalidTodoID,
+		},
+		{
+			testName: "failure – viewer cannot use the blocker",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				lockTodo(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(blockerID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			blockerID:   blockerID,
+			expectedErr: se.ErrInvalidBlockerID,
+		},
+		{
+			testName: "failure – blocker belongs to another owner",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				lockTodo(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(blockerID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(todoColumns).AddRow(blockerID, ownerID, "fix tests", psql.Todo))
+				mock.ExpectRollback()
+			},
+			blockerID:   blockerID,
+			expectedErr: se.ErrInvalidBlockerID,
+		},
+		{
+			testName:    "failure – todo blocks itself",
+			mockSetup:   func(mock sqlmock.Sqlmock) {},
+			blockerID:   todoID,
+			expectedErr: se.ErrDependencyCycle,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			testCase.mockSetup(mock)
+			ctx := userContext(userID)
+			err = todoService.AddBlocker(ctx, &dto.TodoDependencyRequest{TodoID: todoID, BlockerID: testCase.blockerID})
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangeStatusBlocked(t *testing.T) {
+	type testCase struct {
+		testName      string
+		newStatus     psql.TodoStatus
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+				AddRow(todoID, userID, "ship release", psql.Process))
+	}
+	updateStatus := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID, status psql.TodoStatus) {
+		mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+			WithArgs(status, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+			WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Process), string(status)).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+		mock.ExpectCommit()
+	}
+
+	testTable := []testCase{
+		{
+			testName:  "success – todo without open blockers completed",
+			newStatus: psql.Done,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(todoID, string(psql.Done)).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}))
+				updateStatus(mock, todoID, userID, psql.Done)
+			},
+		},
+		{
+			testName:  "success – non-terminal status ignores blockers",
+			newStatus: psql.Todo,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				updateStatus(mock, todoID, userID, psql.Todo)
+			},
+		},
+		{
+			testName:  "failure – open blocker",
+			newStatus: psql.Done,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(todoID, string(psql.Done)).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}).AddRow(uuid.New()))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrTodoBlocked,
+		},
+		{
+			testName:  "failure – viewer cannot change the status",
+			newStatus: psql.Done,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{TodoID: todoID,
+				NewStatus: string(testCase.newStatus)})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
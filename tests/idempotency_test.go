@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	type testCase struct {
+		testName         string
+		mockSetup        func(mock sqlmock.Sqlmock)
+		expectedResponse *dto.IdempotentResponse
+		expectedError    error
+	}
+
+	scope := "user"
+	key := "retry-1"
+	fingerprint := "abc"
+	testTime := time.Now()
+	columns := []string{"scope", "key", "fingerprint", "status_code", "response_headers", "response_body", "created_at",
+		"completed_at"}
+
+	testCases := []testCase{
+		{
+			testName: "success – key reserved",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_CREATE)).WithArgs(scope, key, fingerprint).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "success – stored response replayed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_CREATE)).WithArgs(scope, key, fingerprint).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(IDEMPOTENCY_GET)).WithArgs(scope, key).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(scope, key, fingerprint, http.StatusCreated, nil, []byte{}, testTime, testTime))
+			},
+			expectedResponse: &dto.IdempotentResponse{StatusCode: http.StatusCreated, Header: http.Header{}, Body: []byte{}},
+		},
+		{
+			testName: "success – stored headers replayed",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_CREATE)).WithArgs(scope, key, fingerprint).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(IDEMPOTENCY_GET)).WithArgs(scope, key).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(scope, key, fingerprint, http.StatusOK,
+							[]byte(`{"Content-Type":["application/json"],"Etag":["\"7\""]}`), []byte{}, testTime,
+							testTime))
+			},
+			expectedResponse: &dto.IdempotentResponse{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}, "Etag": {`"7"`}},
+				Body:       []byte{},
+			},
+		},
+		{
+			testName: "error – key reused with a different body",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_CREATE)).WithArgs(scope, key, fingerprint).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(IDEMPOTENCY_GET)).WithArgs(scope, key).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(scope, key, "other", http.StatusCreated, nil, []byte{}, testTime, testTime))
+			},
+			expectedError: se.ErrIdempotencyKeyReused,
+		},
+		{
+			testName: "error – first request still running",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_CREATE)).WithArgs(scope, key, fingerprint).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(IDEMPOTENCY_GET)).WithArgs(scope, key).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(scope, key, fingerprint, nil, nil, nil, testTime, nil))
+			},
+			expectedError: se.ErrIdempotencyInProgress,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			idempotencyService := InitIdempotencyService(db, 24*time.Hour)
+
+			testCase.mockSetup(mock)
+			response, err := idempotencyService.Begin(context.Background(), scope, key, fingerprint)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedResponse, response)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIdempotencyComplete(t *testing.T) {
+	type testCase struct {
+		testName  string
+		mockSetup func(mock sqlmock.Sqlmock)
+		response  *dto.IdempotentResponse
+	}
+
+	scope := "user"
+	key := "retry-1"
+	body := []byte(`{"id":"1"}`)
+
+	testCases := []testCase{
+		{
+			testName: "success – response with headers stored",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_COMPLETE)).
+					WithArgs(http.StatusOK, []byte(`{"Content-Type":["application/json"],"Etag":["\"7\""]}`), body,
+						scope, key).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			response: &dto.IdempotentResponse{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}, "Etag": {`"7"`}},
+				Body:       body,
+			},
+		},
+		{
+			testName: "success – response without headers stored",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(IDEMPOTENCY_COMPLETE)).
+					WithArgs(http.StatusNoContent, nil, []byte{}, scope, key).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			response: &dto.IdempotentResponse{StatusCode: http.StatusNoContent, Body: []byte{}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			idempotencyService := InitIdempotencyService(db, 24*time.Hour)
+
+			testCase.mockSetup(mock)
+			err = idempotencyService.Complete(context.Background(), scope, key, testCase.response)
+			require.NoError(t, err)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
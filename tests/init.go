@@ -1,13 +1,31 @@
 package tests
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
 	"github.com/identicalaffiliation/app/internal/logger"
 	"github.com/identicalaffiliation/app/internal/repository/psql"
+	"github.com/identicalaffiliation/app/internal/service"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/internal/workflow"
+	"github.com/identicalaffiliation/app/pkg/mailer"
 	"github.com/jmoiron/sqlx"
 )
 
+var testOrgID = uuid.New()
+
+func orgContext() context.Context {
+	return context.WithValue(context.Background(), "orgID", testOrgID.String())
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return context.WithValue(orgContext(), "userID", userID.String())
+}
+
 func InitUser(db *sql.DB) psql.UserRepository {
 	sqlxDB := sqlx.NewDb(db, "postgres")
 	postgres := psql.NewPostgres()
@@ -25,3 +43,184 @@ func InitTodo(db *sql.DB) psql.TodoRepository {
 
 	return repo
 }
+
+func InitLabel(db *sql.DB) psql.LabelRepository {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	repo := psql.NewLabelRepository(postgres, logger.NewLogger())
+
+	return repo
+}
+
+func InitProject(db *sql.DB) (psql.ProjectRepository, psql.TodoRepository, psql.Transactor) {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	projectRepo := psql.NewProjectRepository(postgres, logger.NewLogger())
+	todoRepo := psql.NewTodoRepository(postgres, logger.NewLogger())
+
+	return projectRepo, todoRepo, postgres
+}
+
+func InitOccurrence(db *sql.DB) psql.OccurrenceRepository {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	repo := psql.NewOccurrenceRepository(postgres, logger.NewLogger())
+
+	return repo
+}
+
+func InitRevision(db *sql.DB) psql.RevisionRepository {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	repo := psql.NewRevisionRepository(postgres, logger.NewLogger())
+
+	return repo
+}
+
+func InitTodoService(db *sql.DB) se.TodoUseCases {
+	return InitTodoServiceWithWorkflow(db, workflow.DefaultConfig())
+}
+
+func InitTodoServiceWithWorkflow(db *sql.DB, cfg *config.WorkflowConfig) se.TodoUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+	todoRepo := psql.NewTodoRepository(postgres, log)
+	wf, _ := workflow.New(cfg)
+	_ = wf.BindHooks(map[string]workflow.Hook{
+		"log":            workflow.NewLogHook(log),
+		"clear_reminder": workflow.NewClearReminderHook(todoRepo),
+	})
+
+	return service.NewTodoService(psql.NewUserRepository(postgres, log), todoRepo,
+		psql.NewProjectRepository(postgres, log), psql.NewOccurrenceRepository(postgres, log),
+		psql.NewRevisionRepository(postgres, log), psql.NewDependencyRepository(postgres, log),
+		psql.NewTemplateRepository(postgres, log), postgres, wf)
+}
+
+func InitPurgeService(db *sql.DB, retention time.Duration) se.PurgeUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewPurgeService(psql.NewTodoRepository(postgres, log), psql.NewUserRepository(postgres, log), log,
+		&config.TrashConfig{Retention: retention, Interval: time.Hour})
+}
+
+func InitUserService(db *sql.DB) se.UserUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+
+	return service.NewUserService(psql.NewUserRepository(postgres, logger.NewLogger()), postgres)
+}
+
+func InitIdempotencyService(db *sql.DB, ttl time.Duration) se.IdempotencyUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewIdempotencyService(psql.NewIdempotencyRepository(postgres, log), log,
+		&config.IdempotencyConfig{TTL: ttl, Interval: time.Hour})
+}
+
+func InitRebalanceService(db *sql.DB, maxLength int) se.RebalanceUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewRebalanceService(psql.NewTodoRepository(postgres, log), postgres, log,
+		&config.PositionsConfig{MaxLength: maxLength, Interval: time.Hour, BatchSize: 100})
+}
+
+func InitTimeEntryService(db *sql.DB) se.TimeEntryUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+	wf, _ := workflow.New(workflow.DefaultConfig())
+	todoRepo := psql.NewTodoRepository(postgres, log)
+	todoService := service.NewTodoService(psql.NewUserRepository(postgres, log), todoRepo,
+		psql.NewProjectRepository(postgres, log), psql.NewOccurrenceRepository(postgres, log),
+		psql.NewRevisionRepository(postgres, log), psql.NewDependencyRepository(postgres, log),
+		psql.NewTemplateRepository(postgres, log), postgres, wf)
+
+	return service.NewTimeEntryService(psql.NewTimeEntryRepository(postgres, log), todoRepo, todoService, postgres)
+}
+
+func InitTemplateService(db *sql.DB) se.TemplateUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewTemplateService(psql.NewTemplateRepository(postgres, log))
+}
+
+func InitCommentService(db *sql.DB) se.CommentUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewCommentService(psql.NewCommentRepository(postgres, log), psql.NewTodoRepository(postgres, log),
+		postgres)
+}
+
+func InitShareService(db *sql.DB) se.ShareUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewShareService(psql.NewGrantRepository(postgres, log), psql.NewTodoRepository(postgres, log),
+		psql.NewProjectRepository(postgres, log), psql.NewUserRepository(postgres, log),
+		psql.NewOrganizationRepository(postgres, log))
+}
+
+func InitLinkService(db *sql.DB, cfg *config.PublicLinksConfig) se.LinkUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewLinkService(psql.NewLinkRepository(postgres, log), psql.NewTodoRepository(postgres, log),
+		psql.NewGrantRepository(postgres, log), InitTodoService(db), cfg)
+}
+
+func InitOrganizationService(db *sql.DB) se.OrganizationUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+
+	return service.NewOrganizationService(psql.NewOrganizationRepository(postgres, logger.NewLogger()), postgres)
+}
+
+func InitAuthService(db *sql.DB, secret string) se.AuthUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewAuthService(psql.NewUserRepository(postgres, log), psql.NewOrganizationRepository(postgres, log),
+		postgres, secret)
+}
+
+func InitInvitationService(db *sql.DB, m mailer.Mailer, cfg *config.InvitationsConfig) se.InvitationUseCases {
+	sqlxDB := sqlx.NewDb(db, "postgres")
+	postgres := psql.NewPostgres()
+	postgres.DB = sqlxDB
+	log := logger.NewLogger()
+
+	return service.NewInvitationService(psql.NewInvitationRepository(postgres, log),
+		psql.NewOrganizationRepository(postgres, log), psql.NewUserRepository(postgres, log),
+		InitAuthService(db, "secret"), m, postgres, cfg)
+}
@@ -0,0 +1,340 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/mailer"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var invitationColumns = []string{"id", "org_id", "email", "role", "token_hash", "invited_by", "expires_at",
+	"accepted_at", "created_at"}
+
+func invitationsConfig() *config.InvitationsConfig {
+	return &config.InvitationsConfig{
+		TTL:       48 * time.Hour,
+		AcceptURL: "https://todo.example.com/invitations/",
+	}
+}
+
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func unreachableMailer(t *testing.T) mailer.Mailer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+	portNumber, _ := strconv.Atoi(port)
+
+	return mailer.NewSMTPMailer(host, portNumber, "", "", "app@localhost")
+}
+
+func TestInvite(t *testing.T) {
+	type testCase struct {
+		testName        string
+		role            string
+		unreachable     bool
+		mockSetup       func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID)
+		expectedError   error
+		expectedMails   int
+		expectedSubject string
+	}
+
+	testTime := time.Now()
+
+	expectNamedOrganization := func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID, name string) {
+		mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("bob@example.com").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_BY_ID)).WithArgs(orgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(orgID, name, testTime))
+		mock.ExpectQuery(regexp.QuoteMeta(INVITATION_CREATE)).
+			WithArgs(sqlmock.AnyArg(), orgID, "bob@example.com", "member", sqlmock.AnyArg(), adminID,
+				sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – invitation stored and mailed",
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {
+				expectNamedOrganization(mock, orgID, adminID, "Acme")
+			},
+			expectedMails:   1,
+			expectedSubject: "Subject: Invitation to Acme\r\n",
+		},
+		{
+			testName: "success – line breaks in the organization name are folded",
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {
+				expectNamedOrganization(mock, orgID, adminID, "Acme\r\nBcc: eve@example.com")
+			},
+			expectedMails:   1,
+			expectedSubject: "Subject: Invitation to Acme Bcc: eve@example.com\r\n",
+		},
+		{
+			testName: "success – non-ASCII organization name is encoded",
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {
+				expectNamedOrganization(mock, orgID, adminID, "Äcme")
+			},
+			expectedMails:   1,
+			expectedSubject: "Subject: =?utf-8?q?Invitation_to_=C3=84cme?=\r\n",
+		},
+		{
+			testName:    "error – mail not delivered, invitation removed",
+			role:        "owner",
+			unreachable: true,
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {
+				expectNamedOrganization(mock, orgID, adminID, "Acme")
+				mock.ExpectExec(regexp.QuoteMeta(INVITATION_DELETE)).WithArgs(sqlmock.AnyArg(), orgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "failure – invitee already a member",
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {
+				bobID := uuid.New()
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("bob@example.com").
+					WillReturnRows(sqlmock.NewRows(userRowColumns).
+						AddRow(bobID, "Bob", "bob@example.com", "hashed", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, bobID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, bobID, "member", testTime))
+			},
+			expectedError: se.ErrAlreadyOrgMember,
+		},
+		{
+			testName:      "failure – members cannot invite",
+			role:          "member",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID, adminID uuid.UUID) {},
+			expectedError: se.ErrOrgForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			standIn := StartMailStandIn(t)
+			smtpMailer := mailer.NewSMTPMailer(standIn.Host, standIn.Port, "", "", "app@localhost")
+			if testCase.unreachable {
+				smtpMailer = unreachableMailer(t)
+			}
+			invitationService := InitInvitationService(db, smtpMailer, invitationsConfig())
+
+			adminID := uuid.New()
+			orgID := uuid.New()
+
+			mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, adminID).
+				WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, adminID, testCase.role, testTime))
+			testCase.mockSetup(mock, orgID, adminID)
+
+			ctx := userContext(adminID)
+			invitation, err := invitationService.Invite(ctx, &dto.InvitationCreateRequest{
+				OrgID: orgID,
+				Email: "bob@example.com",
+				Role:  "member",
+			})
+			switch {
+			case testCase.expectedError != nil:
+				assert.ErrorIs(t, err, testCase.expectedError)
+			case testCase.unreachable:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+				assert.WithinDuration(t, testTime.Add(48*time.Hour), invitation.ExpiresAt, time.Minute)
+			}
+
+			messages := standIn.Messages()
+			require.Len(t, messages, testCase.expectedMails)
+			for _, message := range messages {
+				assert.Contains(t, message, "To: bob@example.com")
+				headers, _, _ := strings.Cut(message, "\r\n\r\n")
+				assert.Contains(t, headers, testCase.expectedSubject)
+				assert.NotContains(t, headers, "\r\nBcc:")
+				assert.Regexp(t, `https://todo\.example\.com/invitations/\S+`, message)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	type testCase struct {
+		testName         string
+		request          *dto.InvitationAcceptRequest
+		mockSetup        func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID)
+		expectedError    error
+		expectedRole     string
+		expectRegistered bool
+	}
+
+	testTime := time.Now()
+	token := "valid-token"
+	userID := uuid.New()
+	userRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(userRowColumns).
+			AddRow(userID, "Carol", "carol@example.com", "hashed", 1, testTime, testTime)
+	}
+	lockInvitation := func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(INVITATION_LOCK)).WithArgs(tokenHash(token)).
+			WillReturnRows(sqlmock.NewRows(invitationColumns).AddRow(invitationID, orgID, "carol@example.com",
+				"admin", tokenHash(token), nil, testTime.Add(time.Hour), nil, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – existing user joins",
+			request:  &dto.InvitationAcceptRequest{Token: token},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				lockInvitation(mock, orgID, invitationID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnRows(userRow())
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_ADD_MEMBER)).WithArgs(orgID, userID, "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectExec(regexp.QuoteMeta(INVITATION_ACCEPT)).WithArgs(invitationID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedRole: "admin",
+		},
+		{
+			testName: "success – new user registers and joins",
+			request:  &dto.InvitationAcceptRequest{Token: token, Name: "Carol", Password: "password123"},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				lockInvitation(mock, orgID, invitationID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_CREATE)).
+					WithArgs(sqlmock.AnyArg(), "Carol", "carol@example.com", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(userID, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_CREATE)).WithArgs(sqlmock.AnyArg(), "Carol").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_ADD_MEMBER)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "owner").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnRows(userRow())
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_ADD_MEMBER)).WithArgs(orgID, userID, "admin").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectExec(regexp.QuoteMeta(INVITATION_ACCEPT)).WithArgs(invitationID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedRole:     "admin",
+			expectRegistered: true,
+		},
+		{
+			testName: "success – already a member keeps the current role",
+			request:  &dto.InvitationAcceptRequest{Token: token},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				lockInvitation(mock, orgID, invitationID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnRows(userRow())
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, userID, "owner", testTime))
+				mock.ExpectExec(regexp.QuoteMeta(INVITATION_ACCEPT)).WithArgs(invitationID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedRole: "owner",
+		},
+		{
+			testName: "failure – membership added concurrently",
+			request:  &dto.InvitationAcceptRequest{Token: token},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				lockInvitation(mock, orgID, invitationID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnRows(userRow())
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_ADD_MEMBER)).WithArgs(orgID, userID, "admin").
+					WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrAlreadyOrgMember,
+		},
+		{
+			testName: "failure – account required for unknown email",
+			request:  &dto.InvitationAcceptRequest{Token: token},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				lockInvitation(mock, orgID, invitationID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("carol@example.com").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrAccountRequired,
+		},
+		{
+			testName: "failure – used or expired invitation",
+			request:  &dto.InvitationAcceptRequest{Token: token},
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, invitationID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(INVITATION_LOCK)).WithArgs(tokenHash(token)).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidInvitation,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			invitationService := InitInvitationService(db, nil, invitationsConfig())
+
+			orgID := uuid.New()
+			invitationID := uuid.New()
+
+			testCase.mockSetup(mock, orgID, invitationID)
+			accepted, err := invitationService.AcceptInvitation(context.Background(), testCase.request)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, orgID, accepted.OrgID)
+				assert.Equal(t, testCase.expectedRole, accepted.Role)
+				assert.Equal(t, testCase.expectRegistered, accepted.Registered)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
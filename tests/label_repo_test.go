@@ -0,0 +1,259 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLabel(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, label *entity.Label)
+		expectedError error
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – label created",
+			mockSetup: func(mock sqlmock.Sqlmock, label *entity.Label) {
+				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(label.ID, testTime)
+
+				mock.ExpectQuery(regexp.QuoteMeta(LABEL_CREATE)).
+					WithArgs(label.ID, label.UserID, label.Name, label.Color).WillReturnRows(rows)
+			},
+		},
+		{
+			testName: "error – duplicate name",
+			mockSetup: func(mock sqlmock.Sqlmock, label *entity.Label) {
+				mock.ExpectQuery(regexp.QuoteMeta(LABEL_CREATE)).
+					WithArgs(label.ID, label.UserID, label.Name, label.Color).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedError: psql.ErrAlreadyExists,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitLabel(db)
+
+			label := &entity.Label{
+				ID:     uuid.New(),
+				UserID: uuid.New(),
+				Name:   "work",
+				Color:  "#ff0000",
+			}
+			testCase.mockSetup(mock, label)
+
+			err = repo.Create(context.Background(), label)
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testTime, label.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetLabels(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID, labelID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	testTime := time.Now()
+	errDatabase := errors.New("connection reset")
+	columns := []string{"id", "user_id", "name", "color", "created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – labels found",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, labelID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LABEL_GET_BY_USER_ID)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(labelID, userID, "work", "#ff0000", testTime, testTime))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – no labels",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, labelID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LABEL_GET_BY_USER_ID)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, labelID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LABEL_GET_BY_USER_ID)).WithArgs(userID).WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitLabel(db)
+
+			userID := uuid.New()
+			labelID := uuid.New()
+			testCase.mockSetup(mock, userID, labelID)
+
+			labels, err := repo.GetByUserID(context.Background(), userID)
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+			} else {
+				require.NoError(t, err)
+				require.Len(t, labels, testCase.expectedLen)
+				for _, label := range labels {
+					assert.Equal(t, &entity.Label{
+						ID:        labelID,
+						UserID:    userID,
+						Name:      "work",
+						Color:     "#ff0000",
+						CreatedAt: testTime,
+						UpdatedAt: testTime,
+					}, label)
+				}
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAttachLabel(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, labelID, userID uuid.UUID)
+		expectedError error
+	}
+
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – label attached",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, labelID, userID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(LABEL_ATTACH)).WithArgs(todoID, labelID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "success – already attached or foreign todo is a no-op",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, labelID, userID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(LABEL_ATTACH)).WithArgs(todoID, labelID, userID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, labelID, userID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(LABEL_ATTACH)).WithArgs(todoID, labelID, userID).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitLabel(db)
+
+			todoID := uuid.New()
+			labelID := uuid.New()
+			userID := uuid.New()
+			testCase.mockSetup(mock, todoID, labelID, userID)
+
+			err = repo.Attach(context.Background(), todoID, labelID, userID)
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDetachLabel(t *testing.T) {
+	type testCase struct {
+		testName      string
+		affected      int64
+		expectedError string
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – label detached",
+			affected: 1,
+		},
+		{
+			testName:      "error – label is not attached",
+			affected:      0,
+			expectedError: "label is not attached",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitLabel(db)
+
+			todoID := uuid.New()
+			labelID := uuid.New()
+			userID := uuid.New()
+
+			mock.ExpectExec(regexp.QuoteMeta(LABEL_DETACH)).WithArgs(todoID, labelID, userID).
+				WillReturnResult(sqlmock.NewResult(0, testCase.affected))
+
+			err = repo.Detach(context.Background(), todoID, labelID, userID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
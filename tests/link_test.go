@@ -0,0 +1,299 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var linkColumns = []string{"id", "user_id", "org_id", "todo_id", "filter", "expires_at", "revoked_at", "created_at"}
+
+func linksConfig() *config.PublicLinksConfig {
+	return &config.PublicLinksConfig{
+		Secret:     "link-secret",
+		BaseURL:    "https://todo.example.com/",
+		DefaultTTL: 24 * time.Hour,
+		MaxTTL:     72 * time.Hour,
+	}
+}
+
+func linkToken(t *testing.T, linkID uuid.UUID, scope string, expiresAt time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"linkID": linkID.String(),
+		"scope":  scope,
+		"exp":    expiresAt.Unix(),
+	}).SignedString([]byte(linksConfig().Secret))
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestCreateLink(t *testing.T) {
+	type testCase struct {
+		testName      string
+		request       func(todoID uuid.UUID) *dto.LinkCreateRequest
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+		expectedScope string
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – todo link signed for the default TTL",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{TodoID: &todoID}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(LINK_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, todoID, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+			},
+			expectedScope: "todo",
+		},
+		{
+			testName: "success – list link stores the filter",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{Filter: &dto.LinkFilter{Statuses: []string{"todo"}}}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LINK_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+			},
+			expectedScope: "list",
+		},
+		{
+			testName: "failure – todo and filter together",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{TodoID: &todoID, Filter: &dto.LinkFilter{}}
+			},
+			mockSetup:     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidLinkTarget,
+		},
+		{
+			testName: "failure – no target",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{}
+			},
+			mockSetup:     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidLinkTarget,
+		},
+		{
+			testName: "failure – expiry beyond the maximum TTL",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				tooLate := time.Now().Add(96 * time.Hour)
+
+				return &dto.LinkCreateRequest{TodoID: &todoID, ExpiresAt: &tooLate}
+			},
+			mockSetup:     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidLinkExpiry,
+		},
+		{
+			testName: "failure – editors cannot publish a todo",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{TodoID: &todoID}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectSharedTodo(mock, todoID, uuid.New(), userID, psql.RoleEditor)
+			},
+			expectedError: se.ErrShareForbidden,
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			request: func(todoID uuid.UUID) *dto.LinkCreateRequest {
+				return &dto.LinkCreateRequest{TodoID: &todoID}
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			linkService := InitLinkService(db, linksConfig())
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			link, err := linkService.CreateLink(ctx, testCase.request(todoID))
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectedScope, link.Scope)
+				assert.Equal(t, "https://todo.example.com/public/todos/"+link.Token, link.URL)
+				assert.WithinDuration(t, testTime.Add(24*time.Hour), link.ExpiresAt, time.Minute)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestOpenLink(t *testing.T) {
+	type testCase struct {
+		testName      string
+		scope         string
+		tamper        bool
+		mockSetup     func(mock sqlmock.Sqlmock, linkID, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	expiresAt := testTime.Add(time.Hour)
+
+	testTable := []testCase{
+		{
+			testName: "success – todo tree opened as the link owner",
+			scope:    "todo",
+			mockSetup: func(mock sqlmock.Sqlmock, linkID, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LINK_GET_ACTIVE)).WithArgs(linkID).
+					WillReturnRows(sqlmock.NewRows(linkColumns).
+						AddRow(linkID, userID, testOrgID, todoID, nil, expiresAt, nil, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TREE)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID, userID, userID, VIEWER_ROLES, 32).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "parent_id", "content", "status",
+						"created_at", "updated_at"}).
+						AddRow(todoID, userID, nil, "plan trip", psql.Todo, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta("FROM todo_dependencies d")).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id", "blocked_id", "created_at"}))
+			},
+		},
+		{
+			testName: "failure – revoked or expired link",
+			scope:    "todo",
+			mockSetup: func(mock sqlmock.Sqlmock, linkID, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LINK_GET_ACTIVE)).WithArgs(linkID).WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidLinkToken,
+		},
+		{
+			testName: "failure – token scope differs from the stored link",
+			scope:    "list",
+			mockSetup: func(mock sqlmock.Sqlmock, linkID, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(LINK_GET_ACTIVE)).WithArgs(linkID).
+					WillReturnRows(sqlmock.NewRows(linkColumns).
+						AddRow(linkID, userID, testOrgID, todoID, nil, expiresAt, nil, testTime))
+			},
+			expectedError: se.ErrInvalidLinkToken,
+		},
+		{
+			testName:      "failure – tampered signature",
+			scope:         "todo",
+			tamper:        true,
+			mockSetup:     func(mock sqlmock.Sqlmock, linkID, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidLinkToken,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			linkService := InitLinkService(db, linksConfig())
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			linkID := uuid.New()
+			testCase.mockSetup(mock, linkID, todoID, userID)
+
+			token := linkToken(t, linkID, testCase.scope, expiresAt)
+			if testCase.tamper {
+				parts := strings.Split(token, ".")
+				parts[2] = strings.Repeat("A", len(parts[2]))
+				token = strings.Join(parts, ".")
+			}
+
+			response, err := linkService.OpenLink(context.Background(), &dto.PublicTodosRequest{Token: token})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, response.Todo)
+				assert.Equal(t, todoID, response.Todo.ID)
+				assert.Equal(t, "plan trip", response.Todo.Content)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeLink(t *testing.T) {
+	type testCase struct {
+		testName      string
+		affected      int64
+		expectedError error
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – link revoked",
+			affected: 1,
+		},
+		{
+			testName:      "failure – link owned by someone else or already revoked",
+			affected:      0,
+			expectedError: se.ErrInvalidLinkID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			linkService := InitLinkService(db, linksConfig())
+
+			userID := uuid.New()
+			linkID := uuid.New()
+
+			mock.ExpectExec(regexp.QuoteMeta(LINK_REVOKE)).WithArgs(linkID, userID, testOrgID).
+				WillReturnResult(sqlmock.NewResult(0, testCase.affected))
+
+			ctx := userContext(userID)
+			err = linkService.RevokeLink(ctx, linkID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
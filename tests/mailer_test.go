@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/identicalaffiliation/app/internal/notify"
+	"github.com/identicalaffiliation/app/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPMailerHeaders(t *testing.T) {
+	type testCase struct {
+		testName        string
+		message         *mailer.Message
+		expectedError   error
+		expectedSubject string
+	}
+
+	testTable := []testCase{
+		{
+			testName:        "success – ASCII subject sent as is",
+			message:         &mailer.Message{To: []string{"bob@example.com"}, Subject: "Reminder: milk"},
+			expectedSubject: "Subject: Reminder: milk\r\n",
+		},
+		{
+			testName:        "success – non-ASCII subject is Q-encoded",
+			message:         &mailer.Message{To: []string{"bob@example.com"}, Subject: "Reminder: Milch für Ömer"},
+			expectedSubject: "Subject: =?utf-8?q?Reminder:_Milch_f=C3=BCr_=C3=96mer?=\r\n",
+		},
+		{
+			testName:      "failure – line break in the subject",
+			message:       &mailer.Message{To: []string{"bob@example.com"}, Subject: "Hi\r\nBcc: eve@example.com"},
+			expectedError: mailer.ErrInvalidHeader,
+		},
+		{
+			testName:      "failure – line break in a recipient",
+			message:       &mailer.Message{To: []string{"bob@example.com\nBcc: eve@example.com"}, Subject: "Hi"},
+			expectedError: mailer.ErrInvalidHeader,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			standIn := StartMailStandIn(t)
+			smtpMailer := mailer.NewSMTPMailer(standIn.Host, standIn.Port, "", "", "app@localhost")
+
+			err := smtpMailer.Send(context.Background(), testCase.message)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				assert.Empty(t, standIn.Messages())
+
+				return
+			}
+
+			require.NoError(t, err)
+			messages := standIn.Messages()
+			require.Len(t, messages, 1)
+			assert.Contains(t, messages[0], testCase.expectedSubject)
+		})
+	}
+}
+
+func TestEmailNotifierSubject(t *testing.T) {
+	type testCase struct {
+		testName        string
+		content         string
+		expectedSubject string
+	}
+
+	testTable := []testCase{
+		{
+			testName:        "success – content used as subject",
+			content:         "buy milk",
+			expectedSubject: "Subject: Reminder: buy milk\r\n",
+		},
+		{
+			testName:        "success – multi-line content folded into one header",
+			content:         "buy milk\r\nBcc: eve@example.com",
+			expectedSubject: "Subject: Reminder: buy milk Bcc: eve@example.com\r\n",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			standIn := StartMailStandIn(t)
+			smtpMailer := mailer.NewSMTPMailer(standIn.Host, standIn.Port, "", "", "app@localhost")
+			notifier := notify.NewEmailNotifier(smtpMailer)
+
+			err := notifier.Notify(context.Background(), &notify.Notification{
+				UserEmail: "bob@example.com",
+				UserName:  "Bob",
+				Content:   testCase.content,
+			})
+			require.NoError(t, err)
+
+			messages := standIn.Messages()
+			require.Len(t, messages, 1)
+			headers, _, _ := strings.Cut(messages[0], "\r\n\r\n")
+			assert.Contains(t, headers, testCase.expectedSubject)
+			assert.NotContains(t, headers, "\r\nBcc:")
+		})
+	}
+}
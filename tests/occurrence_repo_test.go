@@ -0,0 +1,257 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOccurrence(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, occurrence *entity.TodoOccurrence)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – occurrence recorded",
+			mockSetup: func(mock sqlmock.Sqlmock, occurrence *entity.TodoOccurrence) {
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_CREATE)).
+					WithArgs(occurrence.ID, occurrence.SeriesID, occurrence.UserID, occurrence.TodoID, nil,
+						occurrence.Content, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"completed_at"}).AddRow(testTime))
+			},
+		},
+		{
+			testName: "failure – occurrence already recorded",
+			mockSetup: func(mock sqlmock.Sqlmock, occurrence *entity.TodoOccurrence) {
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_CREATE)).
+					WithArgs(occurrence.ID, occurrence.SeriesID, occurrence.UserID, occurrence.TodoID, nil,
+						occurrence.Content, nil).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedError: psql.ErrAlreadyExists,
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, occurrence *entity.TodoOccurrence) {
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_CREATE)).
+					WithArgs(occurrence.ID, occurrence.SeriesID, occurrence.UserID, occurrence.TodoID, nil,
+						occurrence.Content, nil).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitOccurrence(db)
+
+			todoID := uuid.New()
+			occurrence := &entity.TodoOccurrence{
+				ID:       uuid.New(),
+				SeriesID: uuid.New(),
+				UserID:   uuid.New(),
+				TodoID:   &todoID,
+				Content:  "water plants",
+			}
+			testCase.mockSetup(mock, occurrence)
+
+			err = repo.Create(context.Background(), occurrence)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testTime, occurrence.CompletedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetOccurrences(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, seriesID, userID uuid.UUID)
+		expectedLen int
+	}
+
+	testTime := time.Now()
+	columns := []string{"id", "series_id", "user_id", "todo_id", "next_todo_id", "content", "due_at", "completed_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – occurrences found",
+			mockSetup: func(mock sqlmock.Sqlmock, seriesID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_GET_BY_SERIES_ID)).WithArgs(seriesID, userID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(uuid.New(), seriesID, userID, uuid.New(), uuid.New(), "water plants", testTime,
+							testTime))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – series of another user is empty",
+			mockSetup: func(mock sqlmock.Sqlmock, seriesID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_GET_BY_SERIES_ID)).WithArgs(seriesID, userID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitOccurrence(db)
+
+			seriesID := uuid.New()
+			userID := uuid.New()
+			testCase.mockSetup(mock, seriesID, userID)
+
+			occurrences, err := repo.GetBySeriesID(context.Background(), seriesID, userID)
+			require.NoError(t, err)
+			require.Len(t, occurrences, testCase.expectedLen)
+			for _, occurrence := range occurrences {
+				assert.Equal(t, seriesID, occurrence.SeriesID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCompleteRecurringTodo(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	dueAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	recurrence := "FREQ=DAILY"
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID, status psql.TodoStatus) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status", "due_at", "recurrence",
+				"recurrence_start", "series_id", "position", "created_at", "updated_at"}).
+				AddRow(todoID, userID, "water plants", status, dueAt, recurrence, dueAt, todoID, "m", dueAt, dueAt))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – next occurrence created",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID, psql.Todo)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(todoID, string(psql.Done)).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Done, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Todo), string(psql.Done)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_NEXT_POSITION)).WithArgs(userID, todoID, testOrgID, "m").
+					WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("r"))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, nil, "water plants", string(psql.Todo),
+						dueAt.Add(24*time.Hour), nil, recurrence, dueAt, todoID, "p").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), time.Now()))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_RECURRENCE)).
+					WithArgs(nil, dueAt, todoID, todoID, userID, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "recurrence", recurrence, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectQuery(regexp.QuoteMeta(OCCURRENCE_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, todoID, sqlmock.AnyArg(), "water plants", dueAt).
+					WillReturnRows(sqlmock.NewRows([]string{"completed_at"}).AddRow(time.Now()))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – completing a done todo keeps the series untouched",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID, psql.Done)
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Done, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – open blocker keeps the series untouched",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID, psql.Todo)
+				mock.ExpectQuery(regexp.QuoteMeta(DEPENDENCY_OPEN_BLOCKERS)).WithArgs(todoID, string(psql.Done)).
+					WillReturnRows(sqlmock.NewRows([]string{"blocker_id"}).AddRow(uuid.New()))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrTodoBlocked,
+		},
+		{
+			testName: "failure – todo not found",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{TodoID: todoID,
+				NewStatus: string(psql.Done)})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,523 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/hash"
+	"github.com/identicalaffiliation/app/pkg/jwtoken"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var membershipColumns = []string{"org_id", "user_id", "role", "created_at"}
+
+func TestRegisterCreatesPersonalOrganization(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock)
+		expectedError error
+	}
+
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – user owns a new organization",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(USER_CREATE)).
+					WithArgs(sqlmock.AnyArg(), "Alice", "alice@example.com", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), time.Now()))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_CREATE)).WithArgs(sqlmock.AnyArg(), "Alice").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_ADD_MEMBER)).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "owner").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "error – organization insert fails and the user is rolled back",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(USER_CREATE)).
+					WithArgs(sqlmock.AnyArg(), "Alice", "alice@example.com", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), time.Now()))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_CREATE)).WithArgs(sqlmock.AnyArg(), "Alice").
+					WillReturnError(errDatabase)
+				mock.ExpectRollback()
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			authService := InitAuthService(db, "secret")
+
+			testCase.mockSetup(mock)
+
+			err = authService.Register(context.Background(), &dto.UserRegisterRequest{
+				Name:     "Alice",
+				Email:    "alice@example.com",
+				Password: "password123",
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLoginCarriesOrgClaim(t *testing.T) {
+	type testCase struct {
+		testName      string
+		password      string
+		orgID         *uuid.UUID
+		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedError error
+		expectedRole  string
+	}
+
+	orgID := uuid.New()
+	testTime := time.Now()
+
+	hashed, err := hash.NewHasher().HashPassword("password123")
+	require.NoError(t, err)
+
+	expectUser := func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+		mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("alice@example.com").
+			WillReturnRows(sqlmock.NewRows(userRowColumns).
+				AddRow(userID, "Alice", "alice@example.com", hashed, 1, testTime, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – default organization",
+			password: "password123",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				expectUser(mock, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_DEFAULT_MEMBERSHIP)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, userID, "admin", testTime))
+			},
+			expectedRole: "admin",
+		},
+		{
+			testName: "success – requested organization",
+			password: "password123",
+			orgID:    &orgID,
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				expectUser(mock, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, userID, "member", testTime))
+			},
+			expectedRole: "member",
+		},
+		{
+			testName: "failure – not a member of the requested organization",
+			password: "password123",
+			orgID:    &orgID,
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				expectUser(mock, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns))
+			},
+			expectedError: se.ErrNotOrgMember,
+		},
+		{
+			testName: "failure – wrong password",
+			password: "password124",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				expectUser(mock, userID)
+			},
+			expectedError: se.ErrInvalidPassword,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			authService := InitAuthService(db, "secret")
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			response, err := authService.Login(context.Background(), &dto.UserLoginRequest{
+				Email:    "alice@example.com",
+				Password: testCase.password,
+				OrgID:    testCase.orgID,
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, orgID, response.OrgID)
+				assert.Equal(t, testCase.expectedRole, response.Role)
+
+				claims, err := jwtoken.NewTokenValidator("secret").ValidateTokenWithClaims(response.Token)
+				require.NoError(t, err)
+				assert.Equal(t, orgID.String(), claims["orgID"])
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTodoQueriesAreScopedToOrganization(t *testing.T) {
+	type testCase struct {
+		testName      string
+		scoped        bool
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID, orgID uuid.UUID)
+		expectedError error
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – active organization filters by org_id",
+			scoped:   true,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID, orgID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID+" AND org_id = $5")).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, orgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+						AddRow(todoID, userID, "quarterly report", psql.Todo))
+			},
+		},
+		{
+			testName: "failure – no active organization matches nothing",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID, orgID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID+" AND FALSE")).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoRepo := InitTodo(db)
+
+			userID := uuid.New()
+			orgID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID, orgID)
+
+			ctx := context.Background()
+			if testCase.scoped {
+				ctx = context.WithValue(ctx, "orgID", orgID.String())
+			}
+			todo, err := todoRepo.GetTodoByUserID(ctx, todoID, userID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, todoID, todo.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangeMemberRole(t *testing.T) {
+	type testCase struct {
+		testName      string
+		actorID       uuid.UUID
+		memberID      uuid.UUID
+		role          string
+		mockSetup     func(mock sqlmock.Sqlmock, orgID uuid.UUID)
+		expectedError error
+	}
+
+	ownerID := uuid.New()
+	adminID := uuid.New()
+	memberID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – admin promotes a member",
+			actorID:  adminID,
+			memberID: memberID,
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(ORG_UPDATE_ROLE)).WithArgs("admin", orgID, memberID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – unchanged role writes nothing",
+			actorID:  ownerID,
+			memberID: adminID,
+			role:     "admin",
+			mockSetup: func(mock sqlmock.Sqlmock, orgID uuid.UUID) {
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName:      "failure – last owner cannot be demoted",
+			actorID:       ownerID,
+			memberID:      ownerID,
+			role:          "member",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrLastOrgOwner,
+		},
+		{
+			testName:      "failure – admins cannot grant ownership",
+			actorID:       adminID,
+			memberID:      memberID,
+			role:          "owner",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrOrgForbidden,
+		},
+		{
+			testName:      "failure – members cannot change roles",
+			actorID:       memberID,
+			memberID:      adminID,
+			role:          "member",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrOrgForbidden,
+		},
+		{
+			testName:      "failure – caller outside the organization",
+			actorID:       uuid.New(),
+			memberID:      memberID,
+			role:          "admin",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrNotOrgMember,
+		},
+		{
+			testName:      "failure – unknown member",
+			actorID:       ownerID,
+			memberID:      uuid.New(),
+			role:          "admin",
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrInvalidMemberID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			organizationService := InitOrganizationService(db)
+
+			orgID := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(ORG_LOCK_MEMBERSHIPS)).WithArgs(orgID).
+				WillReturnRows(sqlmock.NewRows(membershipColumns).
+					AddRow(orgID, ownerID, "owner", testTime).
+					AddRow(orgID, adminID, "admin", testTime).
+					AddRow(orgID, memberID, "member", testTime))
+			testCase.mockSetup(mock, orgID)
+
+			ctx := userContext(testCase.actorID)
+			err = organizationService.ChangeMemberRole(ctx, &dto.MemberRoleChangeRequest{
+				OrgID:    orgID,
+				MemberID: testCase.memberID,
+				Role:     testCase.role,
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	type testCase struct {
+		testName      string
+		actorID       uuid.UUID
+		memberID      uuid.UUID
+		mockSetup     func(mock sqlmock.Sqlmock, orgID uuid.UUID)
+		expectedError error
+	}
+
+	ownerID := uuid.New()
+	adminID := uuid.New()
+	memberID := uuid.New()
+	otherID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – member leaves",
+			actorID:  memberID,
+			memberID: memberID,
+			mockSetup: func(mock sqlmock.Sqlmock, orgID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(ORG_REMOVE_MEMBER)).WithArgs(orgID, memberID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – admin removes a member",
+			actorID:  adminID,
+			memberID: otherID,
+			mockSetup: func(mock sqlmock.Sqlmock, orgID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(ORG_REMOVE_MEMBER)).WithArgs(orgID, otherID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName:      "failure – members cannot remove others",
+			actorID:       memberID,
+			memberID:      otherID,
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrOrgForbidden,
+		},
+		{
+			testName:      "failure – admins cannot remove an owner",
+			actorID:       adminID,
+			memberID:      ownerID,
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrOrgForbidden,
+		},
+		{
+			testName:      "failure – last owner cannot leave",
+			actorID:       ownerID,
+			memberID:      ownerID,
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrLastOrgOwner,
+		},
+		{
+			testName:      "failure – caller outside the organization",
+			actorID:       uuid.New(),
+			memberID:      otherID,
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID uuid.UUID) { mock.ExpectRollback() },
+			expectedError: se.ErrNotOrgMember,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			organizationService := InitOrganizationService(db)
+
+			orgID := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(ORG_LOCK_MEMBERSHIPS)).WithArgs(orgID).
+				WillReturnRows(sqlmock.NewRows(membershipColumns).
+					AddRow(orgID, ownerID, "owner", testTime).
+					AddRow(orgID, adminID, "admin", testTime).
+					AddRow(orgID, memberID, "member", testTime).
+					AddRow(orgID, otherID, "member", testTime))
+			testCase.mockSetup(mock, orgID)
+
+			ctx := userContext(testCase.actorID)
+			err = organizationService.RemoveMember(ctx, orgID, testCase.memberID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCheckMembership(t *testing.T) {
+	type testCase struct {
+		testName      string
+		orgID         uuid.UUID
+		mockSetup     func(mock sqlmock.Sqlmock, orgID, userID uuid.UUID)
+		expectedError error
+	}
+
+	orgID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – active member",
+			orgID:    orgID,
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).AddRow(orgID, userID, "member", testTime))
+			},
+		},
+		{
+			testName: "failure – membership was removed",
+			orgID:    orgID,
+			mockSetup: func(mock sqlmock.Sqlmock, orgID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(orgID, userID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrNotOrgMember,
+		},
+		{
+			testName:      "failure – token without organization",
+			orgID:         uuid.Nil,
+			mockSetup:     func(mock sqlmock.Sqlmock, orgID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidOrgID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			organizationService := InitOrganizationService(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, testCase.orgID, userID)
+
+			err = organizationService.CheckMembership(context.Background(), testCase.orgID, userID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
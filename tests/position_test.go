@@ -0,0 +1,348 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/rank"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBetween(t *testing.T) {
+	type testCase struct {
+		testName      string
+		lower         string
+		upper         string
+		expected      string
+		expectedError error
+	}
+
+	testTable := []testCase{
+		{testName: "success – empty list", expected: "i"},
+		{testName: "success – append", lower: "i", expected: "r"},
+		{testName: "success – prepend", upper: "i", expected: "9"},
+		{testName: "success – adjacent digits", lower: "i", upper: "j", expected: "ii"},
+		{testName: "success – shared prefix", lower: "0000001i", upper: "0000002i", expected: "0000002"},
+		{testName: "error – reversed range", lower: "r", upper: "i", expectedError: rank.ErrInvalidRange},
+		{testName: "error – trailing zero", lower: "i0", expectedError: rank.ErrInvalidKey},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := rank.Between(testCase.lower, testCase.upper)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, key)
+			assert.Less(t, testCase.lower, key)
+			if testCase.upper != "" {
+				assert.Less(t, key, testCase.upper)
+			}
+		})
+	}
+}
+
+func TestRankRepeatedInsert(t *testing.T) {
+	type testCase struct {
+		testName string
+		prepend  bool
+	}
+
+	testTable := []testCase{
+		{testName: "success – repeated insert before the upper key"},
+		{testName: "success – repeated insert after the lower key", prepend: true},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			lower, upper := "i", "j"
+			for range 200 {
+				key, err := rank.Between(lower, upper)
+				require.NoError(t, err)
+				require.Less(t, lower, key)
+				require.Less(t, key, upper)
+
+				if testCase.prepend {
+					lower = key
+				} else {
+					upper = key
+				}
+			}
+		})
+	}
+}
+
+func TestRankSpread(t *testing.T) {
+	type testCase struct {
+		testName string
+		count    int
+	}
+
+	testTable := []testCase{
+		{testName: "success – single key", count: 1},
+		{testName: "success – keys fill one digit", count: 17},
+		{testName: "success – keys widen past one digit", count: 18},
+		{testName: "success – large list", count: 2000},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			keys := rank.Spread(testCase.count)
+			require.Len(t, keys, testCase.count)
+
+			for i := 1; i < len(keys); i++ {
+				require.Less(t, keys[i-1], keys[i])
+				require.Len(t, keys[i], len(keys[0]))
+			}
+
+			_, err := rank.Between("", keys[0])
+			require.NoError(t, err)
+			_, err = rank.Between(keys[len(keys)-1], "")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMoveTodo(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID)
+		request     func(todoID, neighborID uuid.UUID) *dto.TodoPositionChangeRequest
+		expectedErr error
+	}
+
+	testTime := time.Now()
+	columns := []string{"id", "user_id", "content", "status", "position", "created_at", "updated_at"}
+
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(todoID, userID, "buy milk", "todo", "z", testTime, testTime))
+	}
+	after := func(todoID, neighborID uuid.UUID) *dto.TodoPositionChangeRequest {
+		return &dto.TodoPositionChangeRequest{TodoID: todoID, After: &neighborID}
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – moved after a neighbor",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(neighborID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(neighborID, userID, "buy bread", "todo", "i", testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_NEXT_POSITION)).WithArgs(userID, todoID, testOrgID, "i").
+					WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow("j"))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_POSITION)).WithArgs("ii", todoID, userID, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			request: after,
+		},
+		{
+			testName: "success – moved to the top",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(neighborID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(neighborID, userID, "buy bread", "todo", "i", testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_PREV_POSITION)).WithArgs(userID, todoID, testOrgID, "i").
+					WillReturnRows(sqlmock.NewRows([]string{"position"}))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_POSITION)).WithArgs("9", todoID, userID, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			request: func(todoID, neighborID uuid.UUID) *dto.TodoPositionChangeRequest {
+				return &dto.TodoPositionChangeRequest{TodoID: todoID, Before: &neighborID}
+			},
+		},
+		{
+			testName: "failure – neighbor missing or only viewable",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(neighborID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			request:     after,
+			expectedErr: se.ErrInvalidNeighbor,
+		},
+		{
+			testName: "failure – neighbor belongs to another owner",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(neighborID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(neighborID, uuid.New(), "buy bread", "todo", "i", testTime, testTime))
+				mock.ExpectRollback()
+			},
+			request:     after,
+			expectedErr: se.ErrInvalidNeighbor,
+		},
+		{
+			testName: "failure – editor cannot reorder",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(todoID, uuid.New(), "buy milk", "todo", "z", testTime, testTime))
+				mock.ExpectRollback()
+			},
+			request:     after,
+			expectedErr: se.ErrNotTodoOwner,
+		},
+		{
+			testName:  "failure – no neighbors",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {},
+			request: func(todoID, neighborID uuid.UUID) *dto.TodoPositionChangeRequest {
+				return &dto.TodoPositionChangeRequest{TodoID: todoID}
+			},
+			expectedErr: se.ErrInvalidPosition,
+		},
+		{
+			testName:  "failure – todo is its own neighbor",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, neighborID, userID uuid.UUID) {},
+			request: func(todoID, neighborID uuid.UUID) *dto.TodoPositionChangeRequest {
+				return &dto.TodoPositionChangeRequest{TodoID: todoID, After: &todoID}
+			},
+			expectedErr: se.ErrInvalidPosition,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			neighborID := uuid.New()
+
+			testCase.mockSetup(mock, todoID, neighborID, userID)
+			ctx := userContext(userID)
+			err = todoService.MoveTodo(ctx, testCase.request(todoID, neighborID))
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRebalancePositions(t *testing.T) {
+	type testCase struct {
+		testName           string
+		mockSetup          func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID)
+		expectedError      error
+		expectedRebalanced int
+	}
+
+	keys := rank.Spread(2)
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – long positions respread",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LONG_POSITION_USERS)).WithArgs(24).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK_POSITIONS)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+						AddRow(firstID, "iiiiiiiiiiiiiiiiiiiiiiiiii").AddRow(secondID, "iiiiiiiiiiiiiiiiiiiiiiiiij"))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_UPDATE_POSITIONS)+"$").
+					WithArgs(firstID, keys[0], secondID, keys[1], userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(firstID).AddRow(secondID))
+				mock.ExpectCommit()
+			},
+			expectedRebalanced: 1,
+		},
+		{
+			testName: "success – nothing to rebalance",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LONG_POSITION_USERS)).WithArgs(24).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+			},
+		},
+		{
+			testName: "error – position update fails",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LONG_POSITION_USERS)).WithArgs(24).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK_POSITIONS)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).
+						AddRow(firstID, "iiiiiiiiiiiiiiiiiiiiiiiiii").AddRow(secondID, "iiiiiiiiiiiiiiiiiiiiiiiiij"))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_UPDATE_POSITIONS)+"$").
+					WithArgs(firstID, keys[0], secondID, keys[1], userID).
+					WillReturnError(errDatabase)
+				mock.ExpectRollback()
+			},
+			expectedError: errDatabase,
+		},
+		{
+			testName: "error – user lookup fails",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, firstID, secondID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LONG_POSITION_USERS)).WithArgs(24).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			rebalanceService := InitRebalanceService(db, 24)
+
+			userID := uuid.New()
+			firstID := uuid.New()
+			secondID := uuid.New()
+			testCase.mockSetup(mock, userID, firstID, secondID)
+
+			rebalanced, err := rebalanceService.Rebalance(context.Background())
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedRebalanced, rebalanced)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/repository/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateProject(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, project *entity.Project)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – project created",
+			mockSetup: func(mock sqlmock.Sqlmock, project *entity.Project) {
+				mock.ExpectQuery(regexp.QuoteMeta(PROJECT_CREATE)).
+					WithArgs(project.ID, project.UserID, testOrgID, project.Name, project.Color, project.Position).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(project.ID, testTime))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, project *entity.Project) {
+				mock.ExpectQuery(regexp.QuoteMeta(PROJECT_CREATE)).
+					WithArgs(project.ID, project.UserID, testOrgID, project.Name, project.Color, project.Position).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo, _, _ := InitProject(db)
+
+			project := &entity.Project{
+				ID:       uuid.New(),
+				UserID:   uuid.New(),
+				Name:     "home",
+				Color:    "#00ff00",
+				Position: 1,
+			}
+			testCase.mockSetup(mock, project)
+
+			err = repo.Create(orgContext(), project)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, testTime, project.CreatedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetProjects(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID, projectID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	testTime := time.Now()
+	errDatabase := errors.New("connection reset")
+	columns := []string{"id", "user_id", "name", "color", "archived", "position", "created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – active projects found",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, projectID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(PROJECT_GET_BY_USER_ID)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(projectID, userID, "home", "", false, 0, testTime, testTime))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – no projects",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, projectID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(PROJECT_GET_BY_USER_ID)).WithArgs(userID, testOrgID, false).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, projectID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(PROJECT_GET_BY_USER_ID)).WithArgs(userID, testOrgID, false).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo, _, _ := InitProject(db)
+
+			userID := uuid.New()
+			projectID := uuid.New()
+			testCase.mockSetup(mock, userID, projectID)
+
+			projects, err := repo.GetByUserID(orgContext(), userID, false)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, projects, testCase.expectedLen)
+				for _, project := range projects {
+					assert.Equal(t, projectID, project.ID)
+					assert.Equal(t, "home", project.Name)
+				}
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestArchiveProjectTransaction(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, projectID, userID uuid.UUID)
+		expectedError bool
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – project and todos archived",
+			mockSetup: func(mock sqlmock.Sqlmock, projectID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(PROJECT_SET_ARCHIVED)).
+					WithArgs(true, projectID, userID, testOrgID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(PROJECT_ARCHIVE_TODOS)).
+					WithArgs(true, projectID, userID, testOrgID).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "error – todos update rolled back",
+			mockSetup: func(mock sqlmock.Sqlmock, projectID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(PROJECT_SET_ARCHIVED)).
+					WithArgs(true, projectID, userID, testOrgID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(PROJECT_ARCHIVE_TODOS)).
+					WithArgs(true, projectID, userID, testOrgID).WillReturnError(errors.New("connection reset"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			projectRepo, todoRepo, transactor := InitProject(db)
+
+			projectID := uuid.New()
+			userID := uuid.New()
+			testCase.mockSetup(mock, projectID, userID)
+
+			err = transactor.WithinTransaction(orgContext(), func(ctx context.Context) error {
+				if err := projectRepo.SetArchived(ctx, true, projectID, userID); err != nil {
+					return err
+				}
+
+				return todoRepo.SetArchivedByProject(ctx, true, projectID, userID)
+			})
+			if testCase.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,12 +1,121 @@
 package tests
 
+import "github.com/lib/pq"
+
+var (
+	VIEWER_ROLES = pq.Array([]string{"viewer", "editor", "owner"})
+	EDITOR_ROLES = pq.Array([]string{"editor", "owner"})
+	OWNER_ROLES  = pq.Array([]string{"owner"})
+)
+
 const (
-	TODO_CREATE_QUERY         string = `INSERT INTO todos (id,user_id,content,status) VALUES ($1,$2,$3,$4) RETURNING id, created_at`
-	TODO_GET_TODOS_BY_USER_ID string = `SELECT id, user_id, content, status, created_at, updated_at FROM todos`
-	TODO_GET_TODO_BY_USER_ID  string = `SELECT id, user_id, content, status, created_at, updated_at FROM todos WHERE user_id = $1 AND id = $2`
-	TODO_UPDATE_STATUS        string = `UPDATE todos SET status = $1 WHERE id = $2 AND user_id = $3`
-	TODO_UPDATE_CONTENT       string = `UPDATE todos SET content = $1 WHERE id = $2 AND user_id = $3`
-	TODO_DELETE               string = `DELETE FROM todos WHERE id = $1 AND user_id = $2`
-
-	USER_GET_BY_EMAIL string = `SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1`
+	TODO_CREATE_QUERY         string = `INSERT INTO todos (id,user_id,org_id,project_id,parent_id,content,status,due_at,remind_at,recurrence,recurrence_start,series_id,position) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) RETURNING id, created_at`
+	TODO_GET_TODOS_BY_USER_ID string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL) AS children_total, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL AND c.status IN ('done')) AS children_done FROM todos`
+	TODO_GET_TODOS_PAGE       string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL) AS children_total, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL AND c.status IN ('done')) AS children_done FROM todos WHERE user_id = $1 AND deleted_at IS NULL AND org_id = $2 AND archived = $3 AND status IN ($4) AND (created_at, id) < ($5::timestamptz, $6::uuid) ORDER BY created_at DESC, id DESC LIMIT 21`
+	TODO_GET_TODOS_ALL_LABELS string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL) AS children_total, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL AND c.status IN ('done')) AS children_done FROM todos WHERE user_id = $1 AND deleted_at IS NULL AND org_id = $2 AND archived = $3 AND (SELECT count(*) FROM todo_labels tl WHERE tl.todo_id = todos.id AND tl.label_id = ANY($4::uuid[])) = $5 ORDER BY created_at ASC, id ASC LIMIT 21`
+	TODO_GET_TODO_BY_USER_ID  string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL) AS children_total, (SELECT count(*) FROM todos c WHERE c.parent_id = todos.id AND c.deleted_at IS NULL AND c.status IN ('done')) AS children_done FROM todos WHERE id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL`
+	TODO_GET_TREE             string = `WITH RECURSIVE tree AS (SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, 0 AS depth FROM todos WHERE id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND org_id = $5 AND deleted_at IS NULL UNION ALL SELECT t.id, t.user_id, t.project_id, t.parent_id, t.content, t.status, t.due_at, t.remind_at, t.recurrence, t.recurrence_start, t.series_id, t.assignee_id, t.archived, t.version, t.position, t.created_at, t.updated_at, tree.depth + 1 FROM todos t JOIN tree ON t.parent_id = tree.id WHERE (t.user_id = $6 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $7 AND g.role = ANY($8) AND (g.todo_id = t.id OR g.project_id = t.project_id))) AND t.deleted_at IS NULL AND tree.depth < $9) SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (SELECT count(*) FROM todos c WHERE c.parent_id = tree.id AND c.deleted_at IS NULL) AS children_total, (SELECT count(*) FROM todos c WHERE c.parent_id = tree.id AND c.deleted_at IS NULL AND c.status IN ('done')) AS children_done FROM tree ORDER BY depth, created_at, id`
+	TODO_SEARCH               string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (ts_rank(search_vector, to_tsquery('simple', $1))) AS rank, (ts_headline('simple', content, to_tsquery('simple', $2), $3)) AS snippet FROM todos WHERE user_id = $4 AND deleted_at IS NULL AND org_id = $5 AND search_vector @@ to_tsquery('simple', $6) ORDER BY rank DESC, created_at DESC LIMIT 20`
+	TODO_SEARCH_SIMILAR       string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, (word_similarity($1, content)) AS rank, content AS snippet FROM todos WHERE user_id = $2 AND deleted_at IS NULL AND org_id = $3 AND word_similarity($4, content) >= $5 ORDER BY rank DESC, created_at DESC LIMIT 20`
+	TODO_UPDATE_STATUS        string = `UPDATE todos SET status = $1, updated_at = now(), version = version + 1 WHERE id = $2 AND (todos.user_id = $3 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $4 AND g.role = ANY($5) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL`
+	TODO_UPDATE_CONTENT       string = `UPDATE todos SET content = $1, updated_at = now(), version = version + 1 WHERE id = $2 AND (todos.user_id = $3 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $4 AND g.role = ANY($5) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL`
+	TODO_UPDATE_SCHEDULE      string = `UPDATE todos SET due_at = $1, remind_at = $2, reminded_at = $3, updated_at = now(), version = version + 1 WHERE id = $4 AND (todos.user_id = $5 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $6 AND g.role = ANY($7) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL`
+	TODO_UPDATE_PROJECT       string = `UPDATE todos SET project_id = $1, archived = $2, updated_at = now(), version = version + 1 WHERE id = $3 AND user_id = $4 AND deleted_at IS NULL`
+	TODO_IS_ANCESTOR          string = `WITH RECURSIVE ancestors AS (SELECT id, parent_id FROM todos WHERE id = $1 AND org_id = $2 UNION SELECT t.id, t.parent_id FROM todos t JOIN ancestors a ON t.id = a.parent_id WHERE t.org_id = $3) SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $4)`
+	TODO_UPDATE_PARENT        string = `UPDATE todos SET parent_id = $1, updated_at = now(), version = version + 1 WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL`
+	TODO_LOCK                 string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at FROM todos WHERE id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL AND org_id = $5 FOR UPDATE`
+	TODO_UPDATE_RECURRENCE    string = `UPDATE todos SET recurrence = $1, recurrence_start = $2, series_id = $3, updated_at = now(), version = version + 1 WHERE id = $4 AND user_id = $5 AND deleted_at IS NULL`
+	TODO_CLAIM_REMINDERS      string = `UPDATE todos t SET reminded_at = now() FROM users u WHERE u.id = t.user_id AND u.deleted_at IS NULL AND t.id IN ( SELECT id FROM todos WHERE remind_at <= $1 AND reminded_at IS NULL AND deleted_at IS NULL ORDER BY remind_at LIMIT 100 FOR UPDATE SKIP LOCKED ) RETURNING t.id, t.user_id, u.email, u.name, t.content, t.due_at, t.remind_at`
+	TODO_GET_TRASH            string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at, deleted_at FROM todos WHERE user_id = $1 AND deleted_at IS NOT NULL AND org_id = $2 ORDER BY deleted_at DESC, id`
+	TODO_RESTORE              string = `WITH RECURSIVE subtree AS (SELECT id, deleted_at FROM todos WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL UNION SELECT t.id, t.deleted_at FROM todos t JOIN subtree ON t.parent_id = subtree.id WHERE t.deleted_at = subtree.deleted_at) UPDATE todos SET deleted_at = $3, updated_at = now(), version = version + 1 WHERE id IN (SELECT id FROM subtree)`
+	TODO_PURGE                string = `DELETE FROM todos WHERE deleted_at < $1`
+	TODO_DELETE               string = `WITH RECURSIVE subtree AS (SELECT id FROM todos WHERE id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL UNION SELECT t.id FROM todos t JOIN subtree ON t.parent_id = subtree.id WHERE t.deleted_at IS NULL) UPDATE todos SET deleted_at = now(), version = version + 1 WHERE id IN (SELECT id FROM subtree)`
+	TODO_GET_LAST_POSITION    string = `SELECT COALESCE(max(position), '') FROM todos WHERE user_id = $1 AND org_id = $2`
+	TODO_NEXT_POSITION        string = `SELECT position FROM todos WHERE user_id = $1 AND id <> $2 AND deleted_at IS NULL AND org_id = $3 AND position > $4 ORDER BY position ASC LIMIT 1`
+	TODO_PREV_POSITION        string = `SELECT position FROM todos WHERE user_id = $1 AND id <> $2 AND deleted_at IS NULL AND org_id = $3 AND position < $4 ORDER BY position DESC LIMIT 1`
+	TODO_UPDATE_POSITION      string = `UPDATE todos SET position = $1, updated_at = now(), version = version + 1 WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL`
+	TODO_LONG_POSITION_USERS  string = `SELECT user_id FROM todos GROUP BY user_id HAVING max(length(position)) > $1 ORDER BY user_id LIMIT 100`
+	TODO_LOCK_POSITIONS       string = `SELECT id, position FROM todos WHERE user_id = $1 ORDER BY position, id FOR UPDATE`
+	TODO_UPDATE_POSITIONS     string = `WITH v (id, position) AS (VALUES ($1::uuid, $2), ($3::uuid, $4)) UPDATE todos t SET position = v.position FROM v WHERE t.id = v.id AND t.user_id = $5 RETURNING t.id`
+	TODO_COUNT_BY_STATUS      string = `SELECT status, count(*) AS count FROM todos WHERE user_id = $1 AND deleted_at IS NULL AND org_id = $2 AND archived = $3 GROUP BY status`
+	TODO_GET_BOARD_COLUMN     string = `WHERE user_id = $1 AND deleted_at IS NULL AND org_id = $2 AND archived = $3 AND status IN ($4) ORDER BY position ASC, id ASC LIMIT 3`
+	TODO_CREATE_BATCH         string = `INSERT INTO todos (id,user_id,org_id,project_id,parent_id,content,status,due_at,remind_at,recurrence,recurrence_start,series_id,position) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13),($14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26) RETURNING id, created_at`
+	TODO_LOCK_BATCH           string = `SELECT id, user_id, project_id, parent_id, content, status, due_at, remind_at, recurrence, recurrence_start, series_id, assignee_id, archived, version, position, created_at, updated_at FROM todos WHERE user_id = $1 AND id IN ($2,$3,$4) AND deleted_at IS NULL AND org_id = $5 ORDER BY id FOR UPDATE`
+	TODO_UPDATE_CONTENT_BATCH string = `WITH v (id, content) AS (VALUES ($1::uuid, $2), ($3::uuid, $4)) UPDATE todos t SET content = v.content, updated_at = now(), version = t.version + 1 FROM v WHERE t.id = v.id AND t.user_id = $5 AND t.deleted_at IS NULL AND t.org_id = $6 RETURNING t.id`
+	TODO_DELETE_BATCH         string = `WITH RECURSIVE subtree AS ( SELECT id FROM todos WHERE id IN ($1) AND user_id = $2 AND deleted_at IS NULL UNION SELECT t.id FROM todos t JOIN subtree ON t.parent_id = subtree.id WHERE t.deleted_at IS NULL) UPDATE todos SET deleted_at = now(), version = version + 1 WHERE id IN (SELECT id FROM subtree) AND org_id = $3 RETURNING id`
+	TODO_SKIP_REMINDER        string = `UPDATE todos SET reminded_at = now() WHERE id = $1 AND reminded_at IS NULL AND remind_at IS NOT NULL`
+
+	REVISION_CREATE         string = `INSERT INTO todo_revisions (id,todo_id,actor_id,field,old_value,new_value) VALUES ($1,$2,$3,$4,$5,$6) RETURNING created_at`
+	REVISION_GET_BY_TODO_ID string = `SELECT r.id, r.todo_id, r.actor_id, r.field, r.old_value, r.new_value, r.created_at FROM todo_revisions r JOIN todos t ON t.id = r.todo_id WHERE r.todo_id = $1 AND t.user_id = $2 ORDER BY r.created_at DESC, r.id`
+	REVISION_GET_BY_ID      string = `SELECT r.id, r.todo_id, r.actor_id, r.field, r.old_value, r.new_value, r.created_at FROM todo_revisions r JOIN todos t ON t.id = r.todo_id WHERE r.id = $1 AND r.todo_id = $2 AND t.user_id = $3`
+
+	PROJECT_CREATE         string = `INSERT INTO projects (id,user_id,org_id,name,color,position) VALUES ($1,$2,$3,$4,$5,$6) RETURNING id, created_at`
+	PROJECT_GET_BY_USER_ID string = `SELECT id, user_id, name, color, archived, position, created_at, updated_at FROM projects WHERE user_id = $1 AND org_id = $2 AND archived = $3 ORDER BY position, name`
+	PROJECT_SET_ARCHIVED   string = `UPDATE projects SET archived = $1, updated_at = now() WHERE id = $2 AND user_id = $3`
+	PROJECT_ARCHIVE_TODOS  string = `UPDATE todos SET archived = $1, updated_at = now(), version = version + 1 WHERE project_id = $2 AND user_id = $3`
+
+	OCCURRENCE_CREATE           string = `INSERT INTO todo_occurrences (id,series_id,user_id,todo_id,next_todo_id,content,due_at) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING completed_at`
+	OCCURRENCE_GET_BY_SERIES_ID string = `SELECT id, series_id, user_id, todo_id, next_todo_id, content, due_at, completed_at FROM todo_occurrences WHERE series_id = $1 AND user_id = $2 ORDER BY completed_at DESC`
+
+	DEPENDENCY_CREATE        string = `INSERT INTO todo_dependencies (blocker_id,blocked_id) VALUES ($1,$2) RETURNING created_at`
+	DEPENDENCY_REACHES       string = `WITH RECURSIVE chain AS (SELECT blocked_id FROM todo_dependencies WHERE blocker_id = $1 UNION SELECT d.blocked_id FROM todo_dependencies d JOIN chain ON d.blocker_id = chain.blocked_id) SELECT EXISTS (SELECT 1 FROM chain WHERE blocked_id = $2)`
+	DEPENDENCY_OPEN_BLOCKERS string = `SELECT d.blocker_id FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id WHERE d.blocked_id = $1 AND b.deleted_at IS NULL AND b.status NOT IN ($2) ORDER BY d.blocker_id`
+	DEPENDENCY_GET_BY_TODOS  string = `SELECT d.blocker_id, d.blocked_id, d.created_at FROM todo_dependencies d JOIN todos b ON b.id = d.blocker_id JOIN todos t ON t.id = d.blocked_id WHERE (d.blocker_id IN ($1,$2,$3) OR d.blocked_id IN ($4,$5,$6)) AND t.user_id = $7 AND b.deleted_at IS NULL AND t.deleted_at IS NULL ORDER BY d.created_at, d.blocker_id, d.blocked_id`
+
+	TIME_ENTRY_CREATE   string = `INSERT INTO time_entries (id,user_id,todo_id,started_at,stopped_at,note) VALUES ($1,$2,$3,$4,$5,$6) RETURNING created_at`
+	TIME_ENTRY_STOP     string = `UPDATE time_entries SET stopped_at = GREATEST(now(), started_at) WHERE user_id = $1 AND stopped_at IS NULL RETURNING id, user_id, todo_id, started_at, stopped_at, note, created_at`
+	TIME_TOTALS_BY_DAY  string = `SELECT date_trunc('day', e.started_at AT TIME ZONE 'UTC') AS period, CAST(sum(extract(epoch FROM COALESCE(e.stopped_at, now()) - e.started_at)) AS bigint) AS seconds FROM time_entries e JOIN todos t ON t.id = e.todo_id WHERE e.user_id = $1 AND t.deleted_at IS NULL AND e.started_at >= $2 GROUP BY period ORDER BY period`
+	TIME_TOTALS_BY_TODO string = `SELECT e.todo_id, CAST(sum(extract(epoch FROM COALESCE(e.stopped_at, now()) - e.started_at)) AS bigint) AS seconds FROM time_entries e JOIN todos t ON t.id = e.todo_id WHERE e.user_id = $1 AND t.deleted_at IS NULL GROUP BY e.todo_id ORDER BY seconds DESC, e.todo_id`
+
+	TEMPLATE_CREATE    string = `INSERT INTO todo_templates (id,user_id,name,description,items) VALUES ($1,$2,$3,$4,$5) RETURNING created_at, updated_at`
+	TEMPLATE_GET_BY_ID string = `SELECT id, user_id, name, description, items, created_at, updated_at FROM todo_templates WHERE user_id = $1 AND id = $2`
+
+	GRANT_UPSERT    string = `INSERT INTO grants (id,user_id,todo_id,project_id,role,granted_by) VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT (todo_id, user_id) WHERE todo_id IS NOT NULL DO UPDATE SET role = EXCLUDED.role, granted_by = EXCLUDED.granted_by RETURNING id, created_at`
+	GRANT_GET_ROLES string = `SELECT g.role FROM grants g WHERE g.user_id = $1 AND (g.todo_id = $2 OR g.project_id = (SELECT project_id FROM todos WHERE id = $3))`
+	GRANT_DELETE    string = `DELETE FROM grants WHERE user_id = $1 AND todo_id = $2`
+	TODO_GET_SHARED string = `FROM todos WHERE user_id <> $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL AND org_id = $5 ORDER BY created_at DESC, id`
+
+	TODO_CAN_ACCESS      string = `SELECT EXISTS ( SELECT 1 FROM todos WHERE id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL AND org_id = $5 )`
+	TODO_UPDATE_ASSIGNEE string = `UPDATE todos SET assignee_id = $1, updated_at = now(), version = version + 1 WHERE id = $2 AND (todos.user_id = $3 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $4 AND g.role = ANY($5) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL`
+	TODO_GET_ASSIGNED    string = `FROM todos WHERE assignee_id = $1 AND (todos.user_id = $2 OR EXISTS (SELECT 1 FROM grants g WHERE g.user_id = $3 AND g.role = ANY($4) AND (g.todo_id = todos.id OR g.project_id = todos.project_id))) AND deleted_at IS NULL AND org_id = $5 ORDER BY due_at NULLS LAST, created_at DESC, id`
+
+	LINK_CREATE     string = `INSERT INTO share_links (id,user_id,org_id,todo_id,filter,expires_at) VALUES ($1,$2,$3,$4,$5,$6) RETURNING created_at`
+	LINK_GET_ACTIVE string = `SELECT id, user_id, org_id, todo_id, filter, expires_at, revoked_at, created_at FROM share_links WHERE id = $1 AND revoked_at IS NULL AND expires_at > now()`
+	LINK_REVOKE     string = `UPDATE share_links SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	COMMENT_CREATE      string = `INSERT INTO comments (id,todo_id,user_id,parent_id,body) VALUES ($1,$2,$3,$4,$5) RETURNING created_at, updated_at`
+	COMMENT_GET_BY_ID   string = `SELECT id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at, (SELECT count(*) FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL) AS reply_count FROM comments WHERE id = $1 AND todo_id = $2`
+	COMMENT_LOCK        string = `SELECT id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at FROM comments WHERE id = $1 AND todo_id = $2 FOR UPDATE`
+	COMMENT_UPDATE_BODY string = `UPDATE comments SET body = $1, updated_at = now() WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL RETURNING updated_at`
+	COMMENT_EDIT_CREATE string = `INSERT INTO comment_edits (id,comment_id,body) VALUES ($1,$2,$3) RETURNING edited_at`
+	COMMENT_GET_BY_TODO string = `SELECT id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at, (SELECT count(*) FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL) AS reply_count FROM comments WHERE todo_id = $1 AND (deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL)) AND parent_id IS NULL ORDER BY created_at, id LIMIT 2`
+	COMMENT_GET_AFTER   string = `SELECT id, todo_id, user_id, parent_id, body, created_at, updated_at, deleted_at, (SELECT count(*) FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL) AS reply_count FROM comments WHERE todo_id = $1 AND (deleted_at IS NULL OR EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL)) AND parent_id IS NULL AND (created_at, id) > ($2, $3) ORDER BY created_at, id LIMIT 2`
+
+	LABEL_CREATE         string = `INSERT INTO labels (id,user_id,name,color) VALUES ($1,$2,$3,$4) RETURNING id, created_at`
+	LABEL_GET_BY_USER_ID string = `SELECT id, user_id, name, color, created_at, updated_at FROM labels WHERE user_id = $1 ORDER BY name`
+	LABEL_ATTACH         string = `INSERT INTO todo_labels (todo_id,label_id) SELECT t.id, l.id FROM todos t JOIN labels l ON l.user_id = t.user_id WHERE t.id = $1 AND l.id = $2 AND t.user_id = $3 AND t.deleted_at IS NULL ON CONFLICT DO NOTHING`
+	LABEL_DETACH         string = `DELETE FROM todo_labels WHERE todo_id = $1 AND label_id = $2 AND label_id IN ( SELECT id FROM labels WHERE user_id = $3 )`
+
+	IDEMPOTENCY_CREATE   string = `INSERT INTO idempotency_keys (scope,key,fingerprint) VALUES ($1,$2,$3) ON CONFLICT DO NOTHING`
+	IDEMPOTENCY_GET      string = `SELECT scope, key, fingerprint, status_code, response_headers, response_body, created_at, completed_at FROM idempotency_keys WHERE scope = $1 AND key = $2`
+	IDEMPOTENCY_COMPLETE string = `UPDATE idempotency_keys SET status_code = $1, response_headers = $2, response_body = $3, completed_at = now() WHERE scope = $4 AND key = $5`
+
+	USER_GET_BY_EMAIL string = `SELECT id, name, email, password, version, created_at, updated_at FROM users WHERE deleted_at IS NULL AND email = $1`
+	USER_LOCK         string = `SELECT id, name, email, password, version, created_at, updated_at FROM users WHERE deleted_at IS NULL AND id = $1 FOR UPDATE`
+	USER_PURGE        string = `DELETE FROM users WHERE deleted_at < $1`
+	USER_CREATE       string = `INSERT INTO users (id,name,email,password) VALUES ($1,$2,$3,$4) RETURNING id, created_at`
+
+	ORG_CREATE             string = `INSERT INTO organizations (id,name) VALUES ($1,$2) RETURNING created_at`
+	ORG_ADD_MEMBER         string = `INSERT INTO memberships (org_id,user_id,role) VALUES ($1,$2,$3) RETURNING created_at`
+	ORG_DEFAULT_MEMBERSHIP string = `SELECT org_id, user_id, role, created_at FROM memberships WHERE user_id = $1 ORDER BY created_at, org_id LIMIT 1`
+	ORG_LOCK_MEMBERSHIPS   string = `SELECT org_id, user_id, role, created_at FROM memberships WHERE org_id = $1 ORDER BY user_id FOR UPDATE`
+	ORG_UPDATE_ROLE        string = `UPDATE memberships SET role = $1 WHERE org_id = $2 AND user_id = $3`
+	ORG_REMOVE_MEMBER      string = `DELETE FROM memberships WHERE org_id = $1 AND user_id = $2`
+	ORG_GET_MEMBERSHIP     string = `SELECT org_id, user_id, role, created_at FROM memberships WHERE org_id = $1 AND user_id = $2`
+	ORG_GET_BY_ID          string = `SELECT id, name, created_at FROM organizations WHERE id = $1`
+
+	INVITATION_CREATE string = `INSERT INTO invitations (id,org_id,email,role,token_hash,invited_by,expires_at) VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING created_at`
+	INVITATION_LOCK   string = `SELECT id, org_id, email, role, token_hash, invited_by, expires_at, accepted_at, created_at FROM invitations WHERE token_hash = $1 AND accepted_at IS NULL AND expires_at > now() FOR UPDATE`
+	INVITATION_ACCEPT string = `UPDATE invitations SET accepted_at = now() WHERE id = $1 AND accepted_at IS NULL`
+	INVITATION_DELETE string = `DELETE FROM invitations WHERE id = $1 AND org_id = $2 AND accepted_at IS NULL`
 )
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var revisionColumns = []string{"id", "todo_id", "actor_id", "field", "old_value", "new_value", "created_at"}
+
+func TestGetRevisions(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedLen int
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – revisions found",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_TODO_ID)).WithArgs(todoID, userID).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(uuid.New(), todoID, userID, "content", "milk", "milk and bread", testTime))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – todo of another user has no visible revisions",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_TODO_ID)).WithArgs(todoID, userID).
+					WillReturnRows(sqlmock.NewRows(revisionColumns))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitRevision(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			revisions, err := repo.GetByTodoID(context.Background(), todoID, userID)
+			require.NoError(t, err)
+			require.Len(t, revisions, testCase.expectedLen)
+			if testCase.expectedLen > 0 {
+				assert.Equal(t, "content", revisions[0].Field)
+				require.NotNil(t, revisions[0].OldValue)
+				assert.Equal(t, "milk", *revisions[0].OldValue)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevertTodoContent(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, revisionID, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – previous content restored",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, revisionID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_ID)).WithArgs(revisionID, todoID, userID).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(revisionID, todoID, userID, "content", "milk", "milk and bread", testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status", "created_at",
+						"updated_at"}).
+						AddRow(todoID, userID, "milk and bread", "todo", testTime, testTime))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).
+					WithArgs("milk", todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "content", "milk and bread", "milk").
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – revision without a previous value",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, revisionID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_ID)).WithArgs(revisionID, todoID, userID).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(revisionID, todoID, userID, "content", nil, "milk", testTime))
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrRevisionNotRevertible,
+		},
+		{
+			testName: "failure – revision not visible to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, revisionID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_ID)).WithArgs(revisionID, todoID, userID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidRevisionID,
+		},
+		{
+			testName: "failure – viewer cannot revert",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, revisionID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_GET_BY_ID)).WithArgs(revisionID, todoID, userID).
+					WillReturnRows(sqlmock.NewRows(revisionColumns).
+						AddRow(revisionID, todoID, uuid.New(), "content", "milk", "milk and bread", testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			revisionID := uuid.New()
+			testCase.mockSetup(mock, todoID, revisionID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.RevertTodo(ctx, todoID, revisionID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
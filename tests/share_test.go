@@ -0,0 +1,289 @@
+package tests
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var userRowColumns = []string{"id", "name", "email", "password", "version", "created_at", "updated_at"}
+
+func expectSharedTodo(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID, roles ...psql.Role) {
+	mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+		WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+			AddRow(todoID, ownerID, "write report", psql.Todo))
+
+	rows := sqlmock.NewRows([]string{"role"})
+	for _, role := range roles {
+		rows.AddRow(role)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(GRANT_GET_ROLES)).WithArgs(userID, todoID, todoID).WillReturnRows(rows)
+}
+
+func TestShareTodo(t *testing.T) {
+	type testCase struct {
+		testName      string
+		email         string
+		owner         bool
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID)
+		expectedError error
+	}
+
+	collaboratorID := uuid.New()
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – owner shares with a collaborator",
+			owner:    true,
+			email:    "bob@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, ownerID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("bob@example.com").
+					WillReturnRows(sqlmock.NewRows(userRowColumns).
+						AddRow(collaboratorID, "bob", "bob@example.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(testOrgID, collaboratorID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).
+						AddRow(testOrgID, collaboratorID, "member", testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(GRANT_UPSERT)).
+					WithArgs(sqlmock.AnyArg(), collaboratorID, todoID, nil, "editor", ownerID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+			},
+		},
+		{
+			testName: "success – co-owner shares through an owner grant",
+			email:    "bob@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectSharedTodo(mock, todoID, ownerID, userID, psql.RoleViewer, psql.RoleOwner)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("bob@example.com").
+					WillReturnRows(sqlmock.NewRows(userRowColumns).
+						AddRow(collaboratorID, "bob", "bob@example.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(testOrgID, collaboratorID).
+					WillReturnRows(sqlmock.NewRows(membershipColumns).
+						AddRow(testOrgID, collaboratorID, "member", testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(GRANT_UPSERT)).
+					WithArgs(sqlmock.AnyArg(), collaboratorID, todoID, nil, "editor", userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+			},
+		},
+		{
+			testName: "failure – editors cannot share",
+			email:    "eve@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectSharedTodo(mock, todoID, ownerID, userID, psql.RoleEditor)
+			},
+			expectedError: se.ErrShareForbidden,
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			email:    "eve@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+		{
+			testName: "failure – sharing with the owner",
+			email:    "alice@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectSharedTodo(mock, todoID, ownerID, userID, psql.RoleOwner)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("alice@example.com").
+					WillReturnRows(sqlmock.NewRows(userRowColumns).
+						AddRow(ownerID, "alice", "alice@example.com", "hash", 1, testTime, testTime))
+			},
+			expectedError: se.ErrShareWithOwner,
+		},
+		{
+			testName: "failure – unknown collaborator email",
+			owner:    true,
+			email:    "nobody@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, ownerID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("nobody@example.com").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrShareUserNotFound,
+		},
+		{
+			testName: "failure – collaborator outside the organization",
+			owner:    true,
+			email:    "bob@example.com",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, ownerID)
+				mock.ExpectQuery(regexp.QuoteMeta(USER_GET_BY_EMAIL)).WithArgs("bob@example.com").
+					WillReturnRows(sqlmock.NewRows(userRowColumns).
+						AddRow(collaboratorID, "bob", "bob@example.com", "hash", 1, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(ORG_GET_MEMBERSHIP)).WithArgs(testOrgID, collaboratorID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrNotOrgMember,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			shareService := InitShareService(db)
+
+			ownerID := uuid.New()
+			userID := uuid.New()
+			todoID := uuid.New()
+			if testCase.owner {
+				userID = ownerID
+			}
+			testCase.mockSetup(mock, todoID, ownerID, userID)
+
+			err = shareService.ShareTodo(userContext(userID), &dto.ShareRequest{TargetID: todoID, Email: testCase.email,
+				Role: "editor"})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRevokeTodo(t *testing.T) {
+	type testCase struct {
+		testName      string
+		leave         bool
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, ownerID, userID, collaboratorID uuid.UUID)
+		expectedError error
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – owner revokes a collaborator",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID, collaboratorID uuid.UUID) {
+				expectSharedTodo(mock, todoID, ownerID, userID, psql.RoleOwner)
+				mock.ExpectExec(regexp.QuoteMeta(GRANT_DELETE)).WithArgs(collaboratorID, todoID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "success – collaborator leaves without owner rights",
+			leave:    true,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID, collaboratorID uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(GRANT_DELETE)).WithArgs(collaboratorID, todoID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			testName: "failure – viewers cannot revoke others",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, ownerID, userID, collaboratorID uuid.UUID) {
+				expectSharedTodo(mock, todoID, ownerID, userID, psql.RoleViewer)
+			},
+			expectedError: se.ErrShareForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			shareService := InitShareService(db)
+
+			ownerID := uuid.New()
+			userID := uuid.New()
+			todoID := uuid.New()
+			collaboratorID := uuid.New()
+			if testCase.leave {
+				collaboratorID = userID
+			}
+			testCase.mockSetup(mock, todoID, ownerID, userID, collaboratorID)
+
+			ctx := userContext(userID)
+			err = shareService.RevokeTodo(ctx, todoID, collaboratorID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetSharedTodos(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – todos shared with the user",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_SHARED)).
+					WithArgs(userID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+						AddRow(todoID, uuid.New(), "review draft", psql.Todo))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_SHARED)).
+					WithArgs(userID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, userID, todoID)
+
+			ctx := userContext(userID)
+			todos, err := todoService.GetSharedTodos(ctx)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, todos, testCase.expectedLen)
+				assert.Equal(t, todoID, todos[0].ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type MailStandIn struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	mu       sync.Mutex
+	messages []string
+}
+
+func StartMailStandIn(t *testing.T) *MailStandIn {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen smtp stand-in: %v", err)
+	}
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+
+	ms := &MailStandIn{Host: host, Port: portNumber, listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go ms.serve()
+
+	return ms
+}
+
+func (ms *MailStandIn) Messages() []string {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return append([]string(nil), ms.messages...)
+}
+
+func (ms *MailStandIn) serve() {
+	for {
+		conn, err := ms.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go ms.handle(conn)
+	}
+}
+
+func (ms *MailStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost stand-in")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case command == "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if dataLine == ".\r\n" {
+					break
+				}
+
+				data.WriteString(dataLine)
+			}
+
+			ms.mu.Lock()
+			ms.messages = append(ms.messages, data.String())
+			ms.mu.Unlock()
+
+			reply("250 queued")
+		case command == "QUIT":
+			reply("221 bye")
+
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
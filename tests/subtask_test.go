@@ -0,0 +1,221 @@
+package tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeParent(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID)
+		parentID    uuid.UUID
+		expectedErr error
+	}
+
+	testTime := time.Now()
+	userID := uuid.New()
+	todoID := uuid.New()
+	parentID := uuid.New()
+
+	lockTodo := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status", "version"}).
+				AddRow(todoID, userID, "write report", psql.Todo, 1))
+	}
+	lockParent := func(mock sqlmock.Sqlmock, parentID, ownerID uuid.UUID) {
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+				AddRow(parentID, ownerID, "quarterly review", psql.Todo))
+	}
+	lockOwner := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows(userRowColumns).
+				AddRow(userID, "Alice", "alice@example.com", "hashed", 1, testTime, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – parent changed",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {
+				lockTodo(mock)
+				lockParent(mock, parentID, userID)
+				lockOwner(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_IS_ANCESTOR)).WithArgs(parentID, testOrgID, testOrgID, todoID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_PARENT)).WithArgs(parentID, todoID, userID, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "parent_id", nil, parentID.String()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+			parentID: parentID,
+		},
+		{
+			testName: "failure – new parent is a descendant at any depth",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {
+				lockTodo(mock)
+				lockParent(mock, parentID, userID)
+				lockOwner(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_IS_ANCESTOR)).WithArgs(parentID, testOrgID, testOrgID, todoID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			parentID:    parentID,
+			expectedErr: se.ErrTodoCycle,
+		},
+		{
+			testName: "failure – parent missing or only viewable",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {
+				lockTodo(mock)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			parentID:    parentID,
+			expectedErr: se.ErrInvalidParentID,
+		},
+		{
+			testName: "failure – parent belongs to another owner",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {
+				lockTodo(mock)
+				lockParent(mock, parentID, uuid.New())
+				mock.ExpectRollback()
+			},
+			parentID:    parentID,
+			expectedErr: se.ErrInvalidParentID,
+		},
+		{
+			testName: "failure – editor cannot change the parent",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status", "version"}).
+						AddRow(todoID, uuid.New(), "write report", psql.Todo, 1))
+				mock.ExpectRollback()
+			},
+			parentID:    parentID,
+			expectedErr: se.ErrNotTodoOwner,
+		},
+		{
+			testName:    "failure – todo is its own parent",
+			mockSetup:   func(mock sqlmock.Sqlmock, todoID, parentID, userID uuid.UUID) {},
+			parentID:    todoID,
+			expectedErr: se.ErrTodoCycle,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			testCase.mockSetup(mock, todoID, testCase.parentID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.ChangeParent(ctx, &dto.TodoParentChangeRequest{TodoID: todoID, ParentID: &testCase.parentID})
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateSubtask(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, parentID, userID uuid.UUID)
+		expectedErr error
+	}
+
+	testTime := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – subtask created under own todo",
+			mockSetup: func(mock sqlmock.Sqlmock, parentID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+						AddRow(parentID, userID, "quarterly review", psql.Todo))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_LAST_POSITION)).WithArgs(userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(""))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, parentID, "collect numbers", string(psql.Todo),
+						nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – parent missing or only viewable",
+			mockSetup: func(mock sqlmock.Sqlmock, parentID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: se.ErrInvalidParentID,
+		},
+		{
+			testName: "failure – parent belongs to another owner",
+			mockSetup: func(mock sqlmock.Sqlmock, parentID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+						AddRow(parentID, uuid.New(), "quarterly review", psql.Todo))
+				mock.ExpectRollback()
+			},
+			expectedErr: se.ErrInvalidParentID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			parentID := uuid.New()
+
+			testCase.mockSetup(mock, parentID, userID)
+			ctx := userContext(userID)
+			err = todoService.CreateTodo(ctx, &dto.TodoCreateRequest{Content: "collect numbers", ParentID: &parentID})
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
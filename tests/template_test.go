@@ -0,0 +1,242 @@
+package tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const onboardingItems string = `[{"content":"Onboard {{name}}","children":[{"content":"Create accounts for {{name}}","dueInDays":1}]}]`
+
+func TestInstantiateTemplate(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID)
+		variables   map[string]string
+		parentID    bool
+		expectedErr error
+		expectedIDs int
+	}
+
+	testTime := time.Now()
+	parentColumns := []string{"id", "user_id", "content", "status"}
+
+	testTable := []testCase{
+		{
+			testName: "success – todo tree created",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_LAST_POSITION)).WithArgs(userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(""))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, nil, "Onboard Alice", string(psql.Todo), nil,
+						nil, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, sqlmock.AnyArg(), "Create accounts for Alice",
+						string(psql.Todo), sqlmock.AnyArg(), nil, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+				mock.ExpectCommit()
+			},
+			variables:   map[string]string{"name": "Alice"},
+			expectedIDs: 2,
+		},
+		{
+			testName: "success – todo tree created under own parent",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(parentColumns).AddRow(parentID, userID, "hiring", psql.Todo))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_LAST_POSITION)).WithArgs(userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(""))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, parentID, "Onboard Alice", string(psql.Todo),
+						nil, nil, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).
+					WithArgs(sqlmock.AnyArg(), userID, testOrgID, nil, sqlmock.AnyArg(), "Create accounts for Alice",
+						string(psql.Todo), sqlmock.AnyArg(), nil, nil, nil, nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(uuid.New(), testTime))
+				mock.ExpectCommit()
+			},
+			variables:   map[string]string{"name": "Alice"},
+			parentID:    true,
+			expectedIDs: 2,
+		},
+		{
+			testName: "failure – parent missing or only viewable",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			variables:   map[string]string{"name": "Alice"},
+			parentID:    true,
+			expectedErr: se.ErrInvalidParentID,
+		},
+		{
+			testName: "failure – parent belongs to another owner",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).
+					WithArgs(parentID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(parentColumns).AddRow(parentID, uuid.New(), "hiring", psql.Todo))
+				mock.ExpectRollback()
+			},
+			variables:   map[string]string{"name": "Alice"},
+			parentID:    true,
+			expectedErr: se.ErrInvalidParentID,
+		},
+		{
+			testName: "failure – missing variable",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, parentID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expectedErr: se.ErrMissingVariable,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			templateID := uuid.New()
+			parentID := uuid.New()
+
+			mock.ExpectQuery(regexp.QuoteMeta(TEMPLATE_GET_BY_ID)).WithArgs(userID, templateID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "description", "items", "created_at",
+					"updated_at"}).
+					AddRow(templateID, userID, "onboarding", nil, []byte(onboardingItems), testTime, testTime))
+			testCase.mockSetup(mock, userID, parentID)
+
+			request := &dto.TemplateInstantiateRequest{TemplateID: templateID, Variables: testCase.variables}
+			if testCase.parentID {
+				request.ParentID = &parentID
+			}
+
+			ctx := userContext(userID)
+			response, err := todoService.InstantiateTemplate(ctx, request)
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, response.TodoIDs, testCase.expectedIDs)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateTemplate(t *testing.T) {
+	type testCase struct {
+		testName      string
+		items         func() []*dto.TemplateItem
+		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	nestedItems := func(levels int) []*dto.TemplateItem {
+		item := &dto.TemplateItem{Content: "leaf"}
+		for range levels {
+			item = &dto.TemplateItem{Content: "step", Children: []*dto.TemplateItem{item}}
+		}
+
+		return []*dto.TemplateItem{item}
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – template stored",
+			items: func() []*dto.TemplateItem {
+				return nestedItems(1)
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TEMPLATE_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, "onboarding", nil, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(testTime, testTime))
+			},
+		},
+		{
+			testName: "failure – template name already taken",
+			items: func() []*dto.TemplateItem {
+				return nestedItems(1)
+			},
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TEMPLATE_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, "onboarding", nil, sqlmock.AnyArg()).
+					WillReturnError(&pq.Error{Code: "23505"})
+			},
+			expectedError: se.ErrTemplateExists,
+		},
+		{
+			testName: "failure – items nested too deep",
+			items: func() []*dto.TemplateItem {
+				return nestedItems(8)
+			},
+			mockSetup:     func(mock sqlmock.Sqlmock, userID uuid.UUID) {},
+			expectedError: se.ErrTemplateTooLarge,
+		},
+		{
+			testName: "failure – too many items",
+			items: func() []*dto.TemplateItem {
+				items := make([]*dto.TemplateItem, 0, 50)
+				for range 50 {
+					items = append(items, nestedItems(4)...)
+				}
+
+				return items
+			},
+			mockSetup:     func(mock sqlmock.Sqlmock, userID uuid.UUID) {},
+			expectedError: se.ErrTemplateTooLarge,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			templateService := InitTemplateService(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			ctx := userContext(userID)
+			err = templateService.CreateTemplate(ctx, &dto.TemplateCreateRequest{
+				Name:  "onboarding",
+				Items: testCase.items(),
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package tests
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTimer(t *testing.T) {
+	type testCase struct {
+		testName      string
+		status        psql.TodoStatus
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	visibleTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+			WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+				AddRow(todoID, userID, "write report", psql.Todo))
+	}
+	createEntry := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectQuery(regexp.QuoteMeta(TIME_ENTRY_CREATE)).
+			WithArgs(sqlmock.AnyArg(), userID, todoID, sqlmock.AnyArg(), nil, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – timer started and todo moved",
+			status:   psql.Process,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				visibleTodo(mock, todoID, userID)
+				createEntry(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+						AddRow(todoID, userID, "write report", psql.Todo))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Process, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Todo), string(psql.Process)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – todo already in the requested status",
+			status:   psql.Todo,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				visibleTodo(mock, todoID, userID)
+				createEntry(mock, todoID, userID)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – timer already running",
+			status:   psql.Process,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				visibleTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_ENTRY_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, todoID, sqlmock.AnyArg(), nil, nil).
+					WillReturnError(&pq.Error{Code: "23505"})
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrTimerRunning,
+		},
+		{
+			testName: "failure – todo not visible to the user",
+			status:   psql.Process,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+		{
+			testName: "failure – viewer cannot move the todo",
+			status:   psql.Process,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				visibleTodo(mock, todoID, userID)
+				createEntry(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			timeService := InitTimeEntryService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			status := string(testCase.status)
+			ctx := userContext(userID)
+			entry, err := timeService.StartTimer(ctx, &dto.TimerStartRequest{TodoID: todoID, Status: &status})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, entry.Running)
+				assert.Equal(t, todoID, entry.TodoID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	type testCase struct {
+		testName        string
+		mockSetup       func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedError   error
+		expectedSeconds int64
+	}
+
+	startedAt := time.Now().Add(-90 * time.Minute)
+	stoppedAt := time.Now()
+
+	testTable := []testCase{
+		{
+			testName: "success – running timer stopped",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_ENTRY_STOP)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "todo_id", "started_at", "stopped_at",
+						"note", "created_at"}).
+						AddRow(uuid.New(), userID, uuid.New(), startedAt, stoppedAt, nil, startedAt))
+			},
+			expectedSeconds: 90 * 60,
+		},
+		{
+			testName: "failure – no running timer",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_ENTRY_STOP)).WithArgs(userID).WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrNoRunningTimer,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			timeService := InitTimeEntryService(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			ctx := userContext(userID)
+			entry, err := timeService.StopTimer(ctx)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.False(t, entry.Running)
+				assert.Equal(t, testCase.expectedSeconds, entry.Seconds)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCreateTimeEntry(t *testing.T) {
+	type testCase struct {
+		testName      string
+		stoppedAfter  time.Duration
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	startedAt := time.Now().Add(-time.Hour)
+
+	testTable := []testCase{
+		{
+			testName:     "success – manual entry recorded",
+			stoppedAfter: 30 * time.Minute,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				expectOwnedTodo(mock, todoID, userID)
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_ENTRY_CREATE)).
+					WithArgs(sqlmock.AnyArg(), userID, todoID, startedAt, startedAt.Add(30*time.Minute), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+			},
+		},
+		{
+			testName:      "failure – stop before start",
+			stoppedAfter:  -time.Minute,
+			mockSetup:     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidTimeRange,
+		},
+		{
+			testName:      "failure – stop in the future",
+			stoppedAfter:  2 * time.Hour,
+			mockSetup:     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {},
+			expectedError: se.ErrInvalidTimeRange,
+		},
+		{
+			testName:     "failure – todo not visible to the user",
+			stoppedAfter: 30 * time.Minute,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: se.ErrInvalidTodoID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			timeService := InitTimeEntryService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = timeService.CreateTimeEntry(ctx, &dto.TimeEntryCreateRequest{
+				TodoID:    todoID,
+				StartedAt: startedAt,
+				StoppedAt: startedAt.Add(testCase.stoppedAfter),
+			})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetTimeTotals(t *testing.T) {
+	type testCase struct {
+		testName      string
+		request       *dto.TimeTotalsRequest
+		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedError bool
+		expectedLen   int
+	}
+
+	todoID := uuid.New()
+	from := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	testTable := []testCase{
+		{
+			testName: "success – totals grouped by day",
+			request:  &dto.TimeTotalsRequest{GroupBy: "day", From: &from},
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_TOTALS_BY_DAY)).WithArgs(userID, from).
+					WillReturnRows(sqlmock.NewRows([]string{"period", "seconds"}).
+						AddRow(from, 3600).AddRow(from.AddDate(0, 0, 1), 1800))
+			},
+			expectedLen: 2,
+		},
+		{
+			testName: "success – totals grouped by todo by default",
+			request:  &dto.TimeTotalsRequest{},
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TIME_TOTALS_BY_TODO)).WithArgs(userID).
+					WillReturnRows(sqlmock.NewRows([]string{"todo_id", "seconds"}).AddRow(todoID, 5400))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName:      "failure – unsupported grouping",
+			request:       &dto.TimeTotalsRequest{GroupBy: "month"},
+			mockSetup:     func(mock sqlmock.Sqlmock, userID uuid.UUID) {},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			timeService := InitTimeEntryService(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			ctx := userContext(userID)
+			totals, err := timeService.GetTimeTotals(ctx, testCase.request)
+			if testCase.expectedError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, totals, testCase.expectedLen)
+				if testCase.request.GroupBy == "day" {
+					assert.Equal(t, from, *totals[0].Period)
+					assert.Equal(t, int64(1800), totals[1].Seconds)
+				} else {
+					assert.Equal(t, todoID, *totals[0].TodoID)
+				}
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
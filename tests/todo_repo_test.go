@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"regexp"
 	"testing"
@@ -33,7 +34,7 @@ func TestCreateTodo(t *testing.T) {
 			mockSetup: func(mock sqlmock.Sqlmock, id, user_id uuid.UUID, content string, status psql.TodoStatus) {
 				rows := sqlmock.NewRows([]string{"id", "created_at"}).AddRow(todoID, testTime)
 
-				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).WithArgs(id, user_id, content, status).WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CREATE_QUERY)).WithArgs(id, user_id, testOrgID, nil, nil, content, status, nil, nil, nil, nil, nil, "").WillReturnRows(rows)
 			},
 			inputTodo: &entity.Todo{
 				ID:      todoID,
@@ -63,7 +64,7 @@ func TestCreateTodo(t *testing.T) {
 			testCase.mockSetup(mock, testCase.expected.ID, testCase.expected.UserID,
 				testCase.expected.Content, psql.TodoStatus(testCase.expected.Status))
 
-			err = repo.Create(context.Background(), testCase.inputTodo)
+			err = repo.Create(orgContext(), testCase.inputTodo)
 			require.NoError(t, err)
 			assert.Equal(t, testCase.expected.ID, testCase.inputTodo.ID)
 			assert.Equal(t, testCase.expected.CreatedAt, testCase.inputTodo.CreatedAt)
@@ -78,6 +79,7 @@ func TestGetTodos(t *testing.T) {
 		testName      string
 		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID)
 		userID        uuid.UUID
+		filter        *psql.TodoFilter
 		expectedTodos []*entity.Todo
 	}
 
@@ -91,6 +93,7 @@ func TestGetTodos(t *testing.T) {
 	contenta := "running"
 	status := psql.Todo
 	statusa := psql.Done
+	cursorTime := testTime.Add(-time.Hour).Format(time.RFC3339Nano)
 
 	testTable := []testCase{
 		{
@@ -100,7 +103,7 @@ func TestGetTodos(t *testing.T) {
 					AddRow(todoID, userID, content, status, testTime, testTime).
 					AddRow(todoIDa, userIDa, contenta, statusa, testTimea, testTimea)
 
-				mock.ExpectQuery(TODO_GET_TODOS_BY_USER_ID).WithArgs(userID).WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODOS_BY_USER_ID)).WithArgs(userID, testOrgID).WillReturnRows(rows)
 			},
 			userID: userID,
 			expectedTodos: []*entity.Todo{
@@ -122,6 +125,51 @@ func TestGetTodos(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "success – filtered page after cursor",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "content", "status", "created_at", "updated_at"}).
+					AddRow(todoID, userID, content, status, testTime, testTime)
+
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODOS_PAGE)).
+					WithArgs(userID, testOrgID, false, string(status), cursorTime, todoIDa).WillReturnRows(rows)
+			},
+			userID: userID,
+			filter: &psql.TodoFilter{
+				Statuses:   []string{string(status)},
+				SortBy:     psql.SortByCreatedAt,
+				Descending: true,
+				Cursor:     &psql.TodoCursor{SortBy: psql.SortByCreatedAt, Value: cursorTime, ID: todoIDa},
+				Limit:      21,
+			},
+			expectedTodos: []*entity.Todo{
+				{
+					ID:        todoID,
+					UserID:    userID,
+					Content:   content,
+					Status:    string(status),
+					CreatedAt: testTime,
+					UpdatedAt: testTime,
+				},
+			},
+		},
+		{
+			testName: "success – todos with all labels",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "content", "status", "created_at", "updated_at"})
+
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODOS_ALL_LABELS)).
+					WithArgs(userID, testOrgID, false, sqlmock.AnyArg(), 2).WillReturnRows(rows)
+			},
+			userID: userID,
+			filter: &psql.TodoFilter{
+				LabelIDs:  []uuid.UUID{uuid.New(), uuid.New()},
+				AllLabels: true,
+				SortBy:    psql.SortByCreatedAt,
+				Limit:     21,
+			},
+			expectedTodos: []*entity.Todo{},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -135,7 +183,7 @@ func TestGetTodos(t *testing.T) {
 
 			testCase.mockSetup(mock, testCase.userID)
 
-			result, err := repo.GetTodosByUserID(context.Background(), testCase.userID)
+			result, err := repo.GetTodosByUserID(orgContext(), testCase.userID, testCase.filter)
 			require.NoError(t, err)
 
 			assert.NotNil(t, result)
@@ -146,6 +194,96 @@ func TestGetTodos(t *testing.T) {
 	}
 }
 
+func TestTodoCursor(t *testing.T) {
+	type testCase struct {
+		testName      string
+		sortBy        psql.TodoSortField
+		encoded       func(todo *entity.Todo) string
+		expectedValue string
+		expectedError error
+	}
+
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+
+	testTable := []testCase{
+		{
+			testName:      "success – created_at cursor keeps nanoseconds",
+			sortBy:        psql.SortByCreatedAt,
+			expectedValue: "2024-05-01T10:30:00.123456Z",
+		},
+		{
+			testName:      "success – content cursor",
+			sortBy:        psql.SortByContent,
+			expectedValue: "breakfast",
+		},
+		{
+			testName:      "success – position cursor",
+			sortBy:        psql.SortByPosition,
+			expectedValue: "i",
+		},
+		{
+			testName: "error – not base64",
+			encoded: func(todo *entity.Todo) string {
+				return "not a cursor"
+			},
+			expectedError: psql.ErrInvalidCursor,
+		},
+		{
+			testName: "error – not json",
+			encoded: func(todo *entity.Todo) string {
+				return encode("breakfast")
+			},
+			expectedError: psql.ErrInvalidCursor,
+		},
+		{
+			testName: "error – unknown sort field",
+			encoded: func(todo *entity.Todo) string {
+				return encode(`{"s":"priority","v":"1","id":"` + todo.ID.String() + `"}`)
+			},
+			expectedError: psql.ErrInvalidCursor,
+		},
+		{
+			testName: "error – missing todo id",
+			encoded: func(todo *entity.Todo) string {
+				return encode(`{"s":"content","v":"breakfast"}`)
+			},
+			expectedError: psql.ErrInvalidCursor,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			todo := &entity.Todo{
+				ID:        uuid.New(),
+				Content:   "breakfast",
+				Position:  "i",
+				CreatedAt: time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC),
+			}
+
+			encoded := psql.EncodeTodoCursor(todo, testCase.sortBy)
+			if testCase.encoded != nil {
+				encoded = testCase.encoded(todo)
+			}
+
+			cursor, err := psql.DecodeTodoCursor(encoded)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.sortBy, cursor.SortBy)
+			assert.Equal(t, todo.ID, cursor.ID)
+			assert.Equal(t, testCase.expectedValue, cursor.Value)
+		})
+	}
+}
+
 func TestGetTodoByID(t *testing.T) {
 	type testCase struct {
 		testName     string
@@ -168,7 +306,7 @@ func TestGetTodoByID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id", "user_id", "content", "status", "created_at", "updated_at"}).
 					AddRow(todoID, userID, content, status, testTime, testTime)
 
-				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).WithArgs(userID, todoID).WillReturnRows(rows)
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).WillReturnRows(rows)
 
 			},
 			userID: userID,
@@ -195,7 +333,7 @@ func TestGetTodoByID(t *testing.T) {
 
 			testCase.mockSetup(mock, testCase.userID, testCase.todoID)
 
-			result, err := repo.GetTodoByUserID(context.Background(), testCase.todoID, testCase.userID)
+			result, err := repo.GetTodoByUserID(orgContext(), testCase.todoID, testCase.userID)
 			require.NoError(t, err)
 
 			assert.NotNil(t, result)
@@ -208,6 +346,209 @@ func TestGetTodoByID(t *testing.T) {
 	}
 }
 
+func TestGetTodoTree(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, rootID, childID, userID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	testTime := time.Now()
+	errDatabase := errors.New("connection reset")
+	columns := []string{"id", "user_id", "parent_id", "content", "status", "children_total", "children_done",
+		"created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – root with progress and child",
+			mockSetup: func(mock sqlmock.Sqlmock, rootID, childID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TREE)).
+					WithArgs(rootID, userID, userID, VIEWER_ROLES, testOrgID, userID, userID, VIEWER_ROLES, 32).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(rootID, userID, nil, "trip", psql.Todo, 1, 1, testTime, testTime).
+						AddRow(childID, userID, rootID, "tickets", psql.Done, 0, 0, testTime, testTime))
+			},
+			expectedLen: 2,
+		},
+		{
+			testName: "success – root not visible to the user",
+			mockSetup: func(mock sqlmock.Sqlmock, rootID, childID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TREE)).
+					WithArgs(rootID, userID, userID, VIEWER_ROLES, testOrgID, userID, userID, VIEWER_ROLES, 32).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, rootID, childID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TREE)).
+					WithArgs(rootID, userID, userID, VIEWER_ROLES, testOrgID, userID, userID, VIEWER_ROLES, 32).
+					WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			userID := uuid.New()
+			rootID := uuid.New()
+			childID := uuid.New()
+			testCase.mockSetup(mock, rootID, childID, userID)
+
+			result, err := repo.GetTree(orgContext(), rootID, userID)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, result, testCase.expectedLen)
+				if testCase.expectedLen > 0 {
+					assert.Nil(t, result[0].ParentID)
+					assert.Equal(t, 1, result[0].ChildrenTotal)
+					assert.Equal(t, 1, result[0].ChildrenDone)
+					require.NotNil(t, result[1].ParentID)
+					assert.Equal(t, rootID, *result[1].ParentID)
+				}
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateParent(t *testing.T) {
+	type testCase struct {
+		testName      string
+		detach        bool
+		affected      int64
+		expectedError string
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – parent set",
+			affected: 1,
+		},
+		{
+			testName: "success – todo detached to top level",
+			detach:   true,
+			affected: 1,
+		},
+		{
+			testName:      "error – todo not found",
+			affected:      0,
+			expectedError: "todo not found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			parentID := uuid.New()
+			parent := &parentID
+			if testCase.detach {
+				parent = nil
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_PARENT)).WithArgs(parent, todoID, userID, testOrgID).
+				WillReturnResult(sqlmock.NewResult(0, testCase.affected))
+
+			err = repo.UpdateParent(orgContext(), parent, todoID, userID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSearchTodos(t *testing.T) {
+	type testCase struct {
+		testName        string
+		mockSetup       func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		query           string
+		expectedSnippet string
+	}
+
+	testTime := time.Now()
+	todoID := uuid.New()
+	userID := uuid.New()
+	columns := []string{"id", "user_id", "content", "status", "created_at", "updated_at", "rank", "snippet"}
+
+	testTable := []testCase{
+		{
+			testName: "success – full-text match",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(todoID, userID, "buy milk", psql.Todo, testTime, testTime, 0.6, "buy <mark>milk</mark>")
+
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_SEARCH)).
+					WithArgs("buy:* & mil:*", "buy:* & mil:*", sqlmock.AnyArg(), userID, testOrgID, "buy:* & mil:*").
+					WillReturnRows(rows)
+			},
+			query:           "Buy mil",
+			expectedSnippet: "buy <mark>milk</mark>",
+		},
+		{
+			testName: "success – trigram fallback",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_SEARCH)).
+					WithArgs("mlik:*", "mlik:*", sqlmock.AnyArg(), userID, testOrgID, "mlik:*").
+					WillReturnRows(sqlmock.NewRows(columns))
+
+				rows := sqlmock.NewRows(columns).
+					AddRow(todoID, userID, "buy milk", psql.Todo, testTime, testTime, 0.4, "buy milk")
+
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_SEARCH_SIMILAR)).
+					WithArgs("mlik", userID, testOrgID, "mlik", 0.3).WillReturnRows(rows)
+			},
+			query:           "mlik",
+			expectedSnippet: "buy milk",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			testCase.mockSetup(mock, userID)
+
+			result, err := repo.Search(orgContext(), userID, testCase.query, 20)
+			require.NoError(t, err)
+			require.Len(t, result, 1)
+			assert.Equal(t, todoID, result[0].ID)
+			assert.Equal(t, testCase.expectedSnippet, result[0].Snippet)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUpdateStatus(t *testing.T) {
 	type testCase struct {
 		testName      string
@@ -228,7 +569,7 @@ func TestUpdateStatus(t *testing.T) {
 			testName: "success – todos found",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, status psql.TodoStatus) {
 
-				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).WithArgs(status, todoID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).WithArgs(status, todoID, userID, userID, EDITOR_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 			},
 			userID:        userID,
@@ -239,7 +580,7 @@ func TestUpdateStatus(t *testing.T) {
 		{
 			testName: "error – todo not found",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, status psql.TodoStatus) {
-				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).WithArgs(status, todoID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).WithArgs(status, todoID, userID, userID, EDITOR_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			userID:        userID,
 			todoID:        todoID,
@@ -259,14 +600,14 @@ func TestUpdateStatus(t *testing.T) {
 
 			if testCase.expectedError != "" {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID, status)
-				err := repo.UpdateStatus(context.Background(), testCase.status, testCase.todoID, testCase.userID)
+				err := repo.UpdateStatus(orgContext(), testCase.status, testCase.todoID, testCase.userID)
 				require.Error(t, err)
 				assert.Equal(t, err.Error(), testCase.expectedError)
 
 				require.NoError(t, mock.ExpectationsWereMet())
 			} else {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID, status)
-				err := repo.UpdateStatus(context.Background(), testCase.status, testCase.todoID, testCase.userID)
+				err := repo.UpdateStatus(orgContext(), testCase.status, testCase.todoID, testCase.userID)
 				require.NoError(t, err)
 
 				require.NoError(t, mock.ExpectationsWereMet())
@@ -295,7 +636,7 @@ func TestUpdateContent(t *testing.T) {
 			testName: "success – todo updated",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, content string) {
 
-				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).WithArgs(content, todoID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).WithArgs(content, todoID, userID, userID, EDITOR_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 			},
 			userID:        userID,
@@ -306,7 +647,7 @@ func TestUpdateContent(t *testing.T) {
 		{
 			testName: "error – todo not found",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, content string) {
-				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).WithArgs(content, todoID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).WithArgs(content, todoID, userID, userID, EDITOR_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			userID:        userID,
 			todoID:        todoID,
@@ -326,14 +667,14 @@ func TestUpdateContent(t *testing.T) {
 
 			if testCase.expectedError != "" {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID, testCase.content)
-				err := repo.UpdateContent(context.Background(), testCase.content, testCase.todoID, testCase.userID)
+				err := repo.UpdateContent(orgContext(), testCase.content, testCase.todoID, testCase.userID)
 				require.Error(t, err)
 				assert.Equal(t, err.Error(), testCase.expectedError)
 
 				require.NoError(t, mock.ExpectationsWereMet())
 			} else {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID, testCase.content)
-				err := repo.UpdateContent(context.Background(), testCase.content, testCase.todoID, testCase.userID)
+				err := repo.UpdateContent(orgContext(), testCase.content, testCase.todoID, testCase.userID)
 				require.NoError(t, err)
 
 				require.NoError(t, mock.ExpectationsWereMet())
@@ -342,6 +683,134 @@ func TestUpdateContent(t *testing.T) {
 	}
 }
 
+func TestUpdateSchedule(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, dueAt, remindAt *time.Time)
+		dueAt         *time.Time
+		remindAt      *time.Time
+		expectedError string
+	}
+
+	todoID := uuid.New()
+	userID := uuid.New()
+	dueAt := time.Now().Add(24 * time.Hour)
+	remindAt := dueAt.Add(-time.Hour)
+
+	testTable := []testCase{
+		{
+			testName: "success – schedule updated",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, dueAt, remindAt *time.Time) {
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_SCHEDULE)).WithArgs(*dueAt, *remindAt, nil, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			dueAt:    &dueAt,
+			remindAt: &remindAt,
+		},
+		{
+			testName: "error – todo not found",
+			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID, dueAt, remindAt *time.Time) {
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_SCHEDULE)).WithArgs(*dueAt, nil, nil, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			dueAt:         &dueAt,
+			expectedError: "todo not found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			testCase.mockSetup(mock, userID, todoID, testCase.dueAt, testCase.remindAt)
+
+			err = repo.UpdateSchedule(orgContext(), testCase.dueAt, testCase.remindAt, todoID, userID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestClaimDueReminders(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+		expectedLen   int
+	}
+
+	now := time.Now()
+	errDatabase := errors.New("connection reset")
+	columns := []string{"id", "user_id", "email", "name", "content", "due_at", "remind_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – due reminder claimed",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CLAIM_REMINDERS)).WithArgs(now).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(todoID, userID, "123@mail.ru", "vlad", "breakfast", now.Add(time.Hour), now))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – nothing due",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CLAIM_REMINDERS)).WithArgs(now).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			testName: "error – database failure",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_CLAIM_REMINDERS)).WithArgs(now).WillReturnError(errDatabase)
+			},
+			expectedError: errDatabase,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			todoID := uuid.New()
+			userID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			reminders, err := repo.ClaimDueReminders(context.Background(), now, 100)
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, reminders, testCase.expectedLen)
+				for _, reminder := range reminders {
+					assert.Equal(t, todoID, reminder.TodoID)
+					assert.Equal(t, "123@mail.ru", reminder.UserEmail)
+					assert.Equal(t, now, reminder.RemindAt)
+				}
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDeleteTodo(t *testing.T) {
 	type testCase struct {
 		testName      string
@@ -360,7 +829,7 @@ func TestDeleteTodo(t *testing.T) {
 			testName: "success – todo deleted",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
 
-				mock.ExpectExec(regexp.QuoteMeta(TODO_DELETE)).WithArgs(todoID, userID).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_DELETE)).WithArgs(todoID, userID, userID, OWNER_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 1))
 
 			},
 			userID:        userID,
@@ -370,7 +839,7 @@ func TestDeleteTodo(t *testing.T) {
 		{
 			testName: "error – todo not found",
 			mockSetup: func(mock sqlmock.Sqlmock, userID, todoID uuid.UUID) {
-				mock.ExpectExec(regexp.QuoteMeta(TODO_DELETE)).WithArgs(todoID, userID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(TODO_DELETE)).WithArgs(todoID, userID, userID, OWNER_ROLES, testOrgID).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
 			userID:        userID,
 			todoID:        todoID,
@@ -389,14 +858,14 @@ func TestDeleteTodo(t *testing.T) {
 
 			if testCase.expectedError != "" {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID)
-				err := repo.Delete(context.Background(), testCase.todoID, testCase.userID)
+				err := repo.Delete(orgContext(), testCase.todoID, testCase.userID)
 				require.Error(t, err)
 				assert.Equal(t, err.Error(), testCase.expectedError)
 
 				require.NoError(t, mock.ExpectationsWereMet())
 			} else {
 				testCase.mockSetup(mock, testCase.userID, testCase.todoID)
-				err := repo.Delete(context.Background(), testCase.todoID, testCase.userID)
+				err := repo.Delete(orgContext(), testCase.todoID, testCase.userID)
 				require.NoError(t, err)
 
 				require.NoError(t, mock.ExpectationsWereMet())
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrash(t *testing.T) {
+	type testCase struct {
+		testName    string
+		mockSetup   func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedLen int
+	}
+
+	testTime := time.Now()
+	deletedAt := testTime.Add(-time.Hour)
+	columns := []string{"id", "user_id", "content", "status", "created_at", "updated_at", "deleted_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – trashed todos found",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TRASH)).WithArgs(userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(uuid.New(), userID, "old groceries", psql.Todo, testTime, testTime, deletedAt))
+			},
+			expectedLen: 1,
+		},
+		{
+			testName: "success – empty trash",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TRASH)).WithArgs(userID, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			repo := InitTodo(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			todos, err := repo.GetTrash(orgContext(), userID)
+			require.NoError(t, err)
+			require.Len(t, todos, testCase.expectedLen)
+			for _, todo := range todos {
+				require.NotNil(t, todo.DeletedAt)
+				assert.Equal(t, deletedAt, *todo.DeletedAt)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestoreTodo(t *testing.T) {
+	type testCase struct {
+		testName      string
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError string
+	}
+
+	testTime := time.Now()
+	columns := []string{"id", "user_id", "parent_id", "content", "status", "created_at", "updated_at"}
+
+	testTable := []testCase{
+		{
+			testName: "success – top-level todo restored",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(TODO_RESTORE)).WithArgs(todoID, userID, nil, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(todoID, userID, nil, "tickets", psql.Todo, testTime, testTime))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "success – trashed parent detached",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				parentID := uuid.New()
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(TODO_RESTORE)).WithArgs(todoID, userID, nil, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(todoID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(todoID, userID, parentID, "tickets", psql.Todo, testTime, testTime))
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_GET_TODO_BY_USER_ID)).
+					WithArgs(parentID, userID, userID, VIEWER_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_PARENT)).WithArgs(nil, todoID, userID, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "parent_id", parentID.String(), nil).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – todo not in the user's trash",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(TODO_RESTORE)).WithArgs(todoID, userID, nil, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: "todo not found",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.RestoreTodo(ctx, todoID)
+			if testCase.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, testCase.expectedError, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	type testCase struct {
+		testName       string
+		mockSetup      func(mock sqlmock.Sqlmock)
+		expectedError  error
+		expectedPurged int64
+	}
+
+	errDatabase := errors.New("connection reset")
+
+	testTable := []testCase{
+		{
+			testName: "success – todos and users purged",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(TODO_PURGE)).WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta(USER_PURGE)).WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedPurged: 4,
+		},
+		{
+			testName: "error – user purge fails after todos",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(TODO_PURGE)).WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta(USER_PURGE)).WithArgs(sqlmock.AnyArg()).
+					WillReturnError(errDatabase)
+			},
+			expectedError:  errDatabase,
+			expectedPurged: 3,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			purgeService := InitPurgeService(db, 720*time.Hour)
+
+			testCase.mockSetup(mock)
+
+			purged, err := purgeService.Purge(context.Background())
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedPurged, purged)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
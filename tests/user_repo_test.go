@@ -88,7 +88,7 @@ func TestGetAllUsers(t *testing.T) {
 		{
 			testName: "success – users found",
 			setupMock: func(mock sqlmock.Sqlmock, repo *psql.UserRepository) {
-				query := `SELECT id, name, email, password, created_at, updated_at FROM users`
+				query := `SELECT id, name, email, password, version, created_at, updated_at FROM users WHERE deleted_at IS NULL ORDER BY email`
 
 				rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "created_at", "updated_at"}).
 					AddRow(validID, "vlad", "123@mail.ru", "123123", testTime, testTime).
@@ -153,7 +153,7 @@ func TestGetByID(t *testing.T) {
 		{
 			testName: "success – user found",
 			mockSetup: func(mock sqlmock.Sqlmock, expected *entity.User) {
-				query := `SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1`
+				query := `SELECT id, name, email, password, version, created_at, updated_at FROM users WHERE deleted_at IS NULL AND id = $1`
 
 				rows := sqlmock.NewRows([]string{"id", "name", "email", "password", "created_at", "updated_at"}).
 					AddRow(expected.ID, expected.Name, expected.Email, expected.Password,
@@ -278,7 +278,7 @@ func TestChangeName(t *testing.T) {
 		{
 			testName: "success – name updated",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET name = \$1 WHERE id = \$2`
+				query := `UPDATE users SET name = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("a", id).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -289,7 +289,7 @@ func TestChangeName(t *testing.T) {
 		{
 			testName: "error – invalid user ID",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET name = \$1 WHERE id = \$2`
+				query := `UPDATE users SET name = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("b", id).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -342,7 +342,7 @@ func TestChangeEmail(t *testing.T) {
 		{
 			testName: "success – email updated",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET email = \$1 WHERE id = \$2`
+				query := `UPDATE users SET email = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("a", id).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -353,7 +353,7 @@ func TestChangeEmail(t *testing.T) {
 		{
 			testName: "error – invalid user ID",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET email = \$1 WHERE id = \$2`
+				query := `UPDATE users SET email = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("b", id).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -406,7 +406,7 @@ func TestChangePassword(t *testing.T) {
 		{
 			testName: "success – password updated",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET password = \$1 WHERE id = \$2`
+				query := `UPDATE users SET password = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("a", id).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -417,7 +417,7 @@ func TestChangePassword(t *testing.T) {
 		{
 			testName: "error – invalid user ID",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `UPDATE users SET password = \$1 WHERE id = \$2`
+				query := `UPDATE users SET password = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
 
 				mock.ExpectExec(query).WithArgs("b", id).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -468,7 +468,7 @@ func TestDelete(t *testing.T) {
 		{
 			testName: "success – user deleted",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `DELETE FROM users WHERE id = \$1`
+				query := `UPDATE users SET deleted_at = now\(\), version = version \+ 1 WHERE deleted_at IS NULL AND id = \$1`
 
 				mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -479,7 +479,7 @@ func TestDelete(t *testing.T) {
 		{
 			testName: "error – invalid id",
 			mockSetup: func(mock sqlmock.Sqlmock, id uuid.UUID) {
-				query := `DELETE FROM users WHERE id = \$1`
+				query := `UPDATE users SET deleted_at = now\(\), version = version \+ 1 WHERE deleted_at IS NULL AND id = \$1`
 
 				mock.ExpectExec(query).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
 			},
@@ -0,0 +1,194 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/dto"
+	se "github.com/identicalaffiliation/app/internal/service/entity"
+	"github.com/identicalaffiliation/app/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeContentVersion(t *testing.T) {
+	type testCase struct {
+		testName      string
+		ifMatch       int
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status", "version", "created_at",
+				"updated_at"}).
+				AddRow(todoID, userID, "milk and bread", "todo", 3, testTime, testTime))
+	}
+	updateContent := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_CONTENT)).
+			WithArgs("milk", todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+			WithArgs(sqlmock.AnyArg(), todoID, userID, "content", "milk and bread", "milk").
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(testTime))
+		mock.ExpectCommit()
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – matching version",
+			ifMatch:  3,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				updateContent(mock, todoID, userID)
+			},
+		},
+		{
+			testName: "success – no precondition",
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				updateContent(mock, todoID, userID)
+			},
+		},
+		{
+			testName: "failure – stale version",
+			ifMatch:  2,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrVersionMismatch,
+		},
+		{
+			testName: "failure – viewer cannot edit",
+			ifMatch:  3,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			if testCase.ifMatch != 0 {
+				ctx = context.WithValue(ctx, "ifMatch", testCase.ifMatch)
+			}
+			err = todoService.ChangeContent(ctx, &dto.TodoContentChangeRequest{TodoID: todoID, NewContent: "milk"})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestChangeNameVersion(t *testing.T) {
+	type testCase struct {
+		testName      string
+		ifMatch       int
+		password      string
+		mockSetup     func(mock sqlmock.Sqlmock, userID uuid.UUID)
+		expectedError error
+	}
+
+	testTime := time.Now()
+
+	hashed, err := hash.NewHasher().HashPassword("password1")
+	require.NoError(t, err)
+
+	lockUser := func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(USER_LOCK)).WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "version", "created_at",
+				"updated_at"}).
+				AddRow(userID, "vlad", "123@mail.ru", hashed, 5, testTime, testTime))
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – matching version",
+			ifMatch:  5,
+			password: "password1",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				lockUser(mock, userID)
+				query := `UPDATE users SET name = \$1, version = version \+ 1 WHERE deleted_at IS NULL AND id = \$2`
+				mock.ExpectExec(query).
+					WithArgs("vladislav", userID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName: "failure – stale version",
+			ifMatch:  4,
+			password: "password1",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				lockUser(mock, userID)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrVersionMismatch,
+		},
+		{
+			testName: "failure – wrong password with matching version",
+			ifMatch:  5,
+			password: "password2",
+			mockSetup: func(mock sqlmock.Sqlmock, userID uuid.UUID) {
+				lockUser(mock, userID)
+				mock.ExpectRollback()
+			},
+			expectedError: se.ErrInvalidPassword,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			userService := InitUserService(db)
+
+			userID := uuid.New()
+			testCase.mockSetup(mock, userID)
+
+			ctx := context.WithValue(context.Background(), "ifMatch", testCase.ifMatch)
+			err = userService.ChangeName(ctx, &dto.ChangeUserNameRequest{ID: userID, Name: "vladislav",
+				Password: testCase.password})
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
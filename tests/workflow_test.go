@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/identicalaffiliation/app/internal/config"
+	"github.com/identicalaffiliation/app/internal/dto"
+	"github.com/identicalaffiliation/app/internal/repository/psql"
+	"github.com/identicalaffiliation/app/internal/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowConfig(t *testing.T) {
+	type testCase struct {
+		testName      string
+		cfg           *config.WorkflowConfig
+		expectedError error
+	}
+
+	testTable := []testCase{
+		{
+			testName: "success – default workflow",
+			cfg:      &config.WorkflowConfig{},
+		},
+		{
+			testName: "error – unknown transition target",
+			cfg: &config.WorkflowConfig{
+				Initial: "todo",
+				States:  []config.WorkflowStateConfig{{Name: "todo", Transitions: []string{"review"}}},
+			},
+			expectedError: workflow.ErrUnknownState,
+		},
+		{
+			testName: "error – duplicate state",
+			cfg: &config.WorkflowConfig{
+				Initial: "todo",
+				States:  []config.WorkflowStateConfig{{Name: "todo"}, {Name: "todo"}},
+			},
+			expectedError: workflow.ErrDuplicateState,
+		},
+		{
+			testName: "error – negative WIP limit",
+			cfg: &config.WorkflowConfig{
+				Initial: "todo",
+				States:  []config.WorkflowStateConfig{{Name: "todo", WIPLimit: -1}},
+			},
+			expectedError: workflow.ErrInvalidWIPLimit,
+		},
+		{
+			testName: "error – terminal initial state",
+			cfg: &config.WorkflowConfig{
+				Initial: "done",
+				States:  []config.WorkflowStateConfig{{Name: "done", Terminal: true}},
+			},
+			expectedError: workflow.ErrInvalidInitialState,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			wf, err := workflow.New(testCase.cfg)
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "todo", wf.Initial())
+			assert.Equal(t, []string{"done"}, wf.TerminalStates())
+		})
+	}
+}
+
+func TestWorkflowHooks(t *testing.T) {
+	type testCase struct {
+		testName      string
+		from          string
+		to            string
+		unbound       bool
+		hookError     error
+		expectedError error
+		expectedCalls []string
+	}
+
+	errHook := errors.New("hook failed")
+
+	testTable := []testCase{
+		{
+			testName:      "success – exit and enter hooks wrap the update",
+			from:          "todo",
+			to:            "done",
+			expectedCalls: []string{"todo->done", "update", "todo->done"},
+		},
+		{
+			testName:      "success – same state skips hooks",
+			from:          "todo",
+			to:            "todo",
+			expectedCalls: []string{"update"},
+		},
+		{
+			testName:      "failure – transition not allowed",
+			from:          "done",
+			to:            "todo",
+			expectedError: workflow.ErrTransitionNotAllowed,
+			expectedCalls: []string{},
+		},
+		{
+			testName:      "failure – unknown target state",
+			from:          "todo",
+			to:            "review",
+			expectedError: workflow.ErrUnknownState,
+			expectedCalls: []string{},
+		},
+		{
+			testName:      "failure – exit hook error stops the update",
+			from:          "todo",
+			to:            "done",
+			hookError:     errHook,
+			expectedError: errHook,
+			expectedCalls: []string{"todo->done"},
+		},
+		{
+			testName:      "failure – hook not bound",
+			unbound:       true,
+			expectedError: workflow.ErrUnknownHook,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			wf, err := workflow.New(&config.WorkflowConfig{
+				Initial: "todo",
+				States: []config.WorkflowStateConfig{
+					{Name: "todo", Transitions: []string{"done"}, OnExit: []string{"record"}},
+					{Name: "done", Terminal: true, OnEnter: []string{"record"}},
+				},
+			})
+			require.NoError(t, err)
+
+			if testCase.unbound {
+				require.True(t, errors.Is(wf.BindHooks(map[string]workflow.Hook{}), testCase.expectedError))
+
+				return
+			}
+
+			calls := make([]string, 0)
+			record := func(ctx context.Context, transition *workflow.Transition) error {
+				calls = append(calls, transition.From+"->"+transition.To)
+
+				return testCase.hookError
+			}
+			require.NoError(t, wf.BindHooks(map[string]workflow.Hook{"record": record}))
+
+			transition := &workflow.Transition{From: testCase.from, To: testCase.to}
+			err = wf.Apply(context.Background(), transition, func(ctx context.Context) error {
+				calls = append(calls, "update")
+
+				return nil
+			})
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expectedCalls, calls)
+		})
+	}
+}
+
+func TestChangeStatusTransitionNotAllowed(t *testing.T) {
+	type testCase struct {
+		testName      string
+		newStatus     psql.TodoStatus
+		mockSetup     func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID)
+		expectedError error
+	}
+
+	lockTodo := func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(TODO_LOCK)).WithArgs(todoID, userID, userID, EDITOR_ROLES, testOrgID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "content", "status"}).
+				AddRow(todoID, userID, "breakfast", psql.Done))
+	}
+
+	testTable := []testCase{
+		{
+			testName:  "success – done todo reopened",
+			newStatus: psql.Todo,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectExec(regexp.QuoteMeta(TODO_UPDATE_STATUS)).
+					WithArgs(psql.Todo, todoID, userID, userID, EDITOR_ROLES, testOrgID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(regexp.QuoteMeta(REVISION_CREATE)).
+					WithArgs(sqlmock.AnyArg(), todoID, userID, "status", string(psql.Done), string(psql.Todo)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(time.Now()))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			testName:  "failure – done todo cannot move to process",
+			newStatus: psql.Process,
+			mockSetup: func(mock sqlmock.Sqlmock, todoID, userID uuid.UUID) {
+				lockTodo(mock, todoID, userID)
+				mock.ExpectRollback()
+			},
+			expectedError: workflow.ErrTransitionNotAllowed,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			todoService := InitTodoService(db)
+
+			userID := uuid.New()
+			todoID := uuid.New()
+			testCase.mockSetup(mock, todoID, userID)
+
+			ctx := userContext(userID)
+			err = todoService.ChangeStatus(ctx, &dto.TodoStatusChangeRequest{TodoID: todoID,
+				NewStatus: string(testCase.newStatus)})
+			if testCase.expectedError != nil {
+				require.True(t, errors.Is(err, testCase.expectedError))
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}